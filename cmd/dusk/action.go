@@ -25,9 +25,10 @@ import (
 )
 
 var (
-	log     *logrus.Entry
-	config  string
-	datadir string
+	log          *logrus.Entry
+	config       string
+	datadir      string
+	snapshotFile string
 )
 
 func action(ctx *cli.Context) error {
@@ -44,6 +45,8 @@ func action(ctx *cli.Context) error {
 		config = "dusk"
 	}
 
+	snapshotFile = ctx.GlobalString(SnapshotFlag.Name)
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
 
@@ -106,7 +109,11 @@ func action(ctx *cli.Context) error {
 	log.Info("Selected network", "Network", cfg.Get().General.Network)
 
 	// Setting up the EventBus and the startup processes (like Chain and CommitteeStore)
-	srv := Setup()
+	srv, err := Setup()
+	if err != nil {
+		return err
+	}
+
 	defer srv.Close()
 
 	// Setting up profiling tools, if enabled
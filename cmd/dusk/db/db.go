@@ -0,0 +1,44 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package db
+
+import (
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/urfave/cli"
+)
+
+// openDB loads the node configuration and opens the node database. The
+// returned function closes it.
+func openDB(c *cli.Context) (database.DB, func(), error) {
+	configFile := c.GlobalString("config")
+	if configFile == "" {
+		configFile = "dusk.toml"
+	}
+
+	if err := cfg.Load("dusk", nil, func() (string, error) {
+		return configFile, nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	drvr, err := database.From(cfg.Get().Database.Driver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := drvr.Open(cfg.Get().Database.Dir, protocol.MagicFromConfig(), false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, func() {
+		_ = db.Close()
+		_ = drvr.Close()
+	}, nil
+}
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/badger"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/urfave/cli"
+)
+
+// ToFlag sets the folder of the migrated database.
+var ToFlag = cli.StringFlag{
+	Name:  "to",
+	Usage: "folder of the badger database to create",
+}
+
+// MigrateAction copies the heavy database of the node into a new badger
+// database. It must be run while the node is stopped.
+func MigrateAction(c *cli.Context) error {
+	dir := c.String(ToFlag.Name)
+	if dir == "" {
+		return errors.New("the folder of the badger database is required")
+	}
+
+	src, closeSrc, err := openDB(c)
+	if err != nil {
+		return err
+	}
+
+	defer closeSrc()
+
+	if cfg.Get().Database.Driver != heavy.DriverName {
+		return fmt.Errorf("only a %s database can be migrated", heavy.DriverName)
+	}
+
+	if dir == cfg.Get().Database.Dir {
+		return errors.New("the badger database must be created in another folder")
+	}
+
+	drvr, err := database.From(badger.DriverName)
+	if err != nil {
+		return err
+	}
+
+	dst, err := drvr.Open(dir, protocol.MagicFromConfig(), false)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = dst.Close()
+		_ = drvr.Close()
+	}()
+
+	copied, err := badger.MigrateFromHeavy(src, dst)
+	if err != nil {
+		return fmt.Errorf("migration stopped after %d records: %v", copied, err)
+	}
+
+	fmt.Printf("%d records copied, set database.driver to %q and database.dir to %q to use the migrated database\n", copied, badger.DriverName, dir)
+	return nil
+}
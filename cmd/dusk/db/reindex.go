@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/urfave/cli"
+)
+
+// ReindexAction adds the transactions of the stored chain to the secondary tx
+// index. It must be run while the node is stopped.
+func ReindexAction(c *cli.Context) error {
+	db, closeDB, err := openDB(c)
+	if err != nil {
+		return err
+	}
+
+	defer closeDB()
+
+	indexed, err := heavy.IndexTxs(db)
+	if err == database.ErrTxIndexDisabled {
+		return errors.New("the tx index must be enabled with database.txIndex")
+	}
+
+	if err != nil {
+		return fmt.Errorf("reindex stopped after %d transactions: %v", indexed, err)
+	}
+
+	fmt.Printf("%d transactions indexed\n", indexed)
+	return nil
+}
@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package db
+
+import (
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/urfave/cli"
+)
+
+// RepairFlag enables the rebuilding of the secondary indices.
+var RepairFlag = cli.BoolFlag{
+	Name:  "repair",
+	Usage: "rebuild the height, TxID and chain state indices from the stored blocks",
+}
+
+// VerifyAction checks the integrity of the node database, and optionally
+// repairs the secondary indices. It must be run while the node is stopped.
+func VerifyAction(c *cli.Context) error {
+	db, closeDB, err := openDB(c)
+	if err != nil {
+		return err
+	}
+
+	defer closeDB()
+
+	r, err := heavy.Verify(db)
+	if err != nil {
+		return err
+	}
+
+	printReport(r)
+
+	if len(r.Issues) == 0 || !c.Bool(RepairFlag.Name) {
+		if len(r.Issues) > 0 {
+			return fmt.Errorf("%d issues found, run with --%s to rebuild the indices", len(r.Issues), RepairFlag.Name)
+		}
+
+		return nil
+	}
+
+	fixed, err := heavy.Repair(db)
+	if err != nil {
+		return fmt.Errorf("repair failed: %v", err)
+	}
+
+	for _, f := range fixed {
+		fmt.Println("fixed:", f)
+	}
+
+	// Issues in the headers or transactions cannot be repaired
+	r, err = heavy.Verify(db)
+	if err != nil {
+		return err
+	}
+
+	printReport(r)
+
+	if len(r.Issues) > 0 {
+		return fmt.Errorf("%d issues left after the repair, the chain must be restored from a snapshot", len(r.Issues))
+	}
+
+	return nil
+}
+
+func printReport(r *heavy.VerifyReport) {
+	for _, issue := range r.Issues {
+		fmt.Println("issue:", issue)
+	}
+
+	fmt.Printf("%d blocks checked, chain tip at height %d, %d issues found\n", r.Blocks, r.TipHeight, len(r.Issues))
+}
@@ -26,6 +26,11 @@ var (
 		Name:  "datadir",
 		Usage: "Data directory for the node",
 	}
+	// SnapshotFlag flag to bootstrap the node from a snapshot archive.
+	SnapshotFlag = cli.StringFlag{
+		Name:  "snapshot",
+		Usage: "snapshot archive to import into an empty database on startup",
+	}
 )
 
 var (
@@ -37,5 +42,6 @@ var (
 	GlobalFlags = []cli.Flag{
 		ConfigFlag,
 		DataDirFlag,
+		SnapshotFlag,
 	}
 )
@@ -11,7 +11,10 @@ import (
 	"os"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/cmd/dusk/db"
 	"github.com/dusk-network/dusk-blockchain/cmd/dusk/genesis"
+	"github.com/dusk-network/dusk-blockchain/cmd/dusk/revert"
+	"github.com/dusk-network/dusk-blockchain/cmd/dusk/snapshot"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
@@ -41,6 +44,53 @@ func init() {
 			Usage:   "serializes the genesis block and prints it",
 			Action:  genesis.Action,
 		},
+		{
+			Name:   "revert",
+			Usage:  "deletes the last N blocks from the node database (node must be stopped)",
+			Flags:  []cli.Flag{revert.BlocksFlag},
+			Action: revert.Action,
+		},
+		{
+			Name:  "snapshot",
+			Usage: "exports or imports a snapshot of the chain (node must be stopped)",
+			Subcommands: []cli.Command{
+				{
+					Name:   "export",
+					Usage:  "writes the chain and the current provisioner set to a snapshot archive",
+					Flags:  []cli.Flag{snapshot.FileFlag},
+					Action: snapshot.ExportAction,
+				},
+				{
+					Name:   "import",
+					Usage:  "loads a snapshot archive into an empty node database",
+					Flags:  []cli.Flag{snapshot.FileFlag},
+					Action: snapshot.ImportAction,
+				},
+			},
+		},
+		{
+			Name:  "db",
+			Usage: "maintenance of the node database (node must be stopped)",
+			Subcommands: []cli.Command{
+				{
+					Name:   "verify",
+					Usage:  "checks the integrity of the stored chain and its indices",
+					Flags:  []cli.Flag{db.RepairFlag},
+					Action: db.VerifyAction,
+				},
+				{
+					Name:   "reindex",
+					Usage:  "adds the stored transactions to the secondary tx index",
+					Action: db.ReindexAction,
+				},
+				{
+					Name:   "migrate",
+					Usage:  "copies the heavy database into a new badger database",
+					Flags:  []cli.Flag{db.ToFlag},
+					Action: db.MigrateAction,
+				},
+			},
+		},
 	}
 	app.Flags = append(app.Flags, CLIFlags...)
 	app.Flags = append(app.Flags, GlobalFlags...)
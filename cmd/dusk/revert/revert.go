@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package revert
+
+import (
+	"errors"
+	"fmt"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/badger"
+	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/urfave/cli"
+)
+
+// BlocksFlag sets the number of blocks to revert.
+var BlocksFlag = cli.Uint64Flag{
+	Name:  "blocks",
+	Usage: "number of blocks to revert from the chain tip",
+	Value: 1,
+}
+
+// Action reverts the last N blocks stored in the node database. It must be
+// run while the node is stopped. Blocks already executed by the Rusk state
+// can not be reverted (see database.RevertTip).
+func Action(c *cli.Context) error {
+	n := c.Uint64(BlocksFlag.Name)
+	if n == 0 {
+		return errors.New("the number of blocks to revert must be greater than 0")
+	}
+
+	configFile := c.GlobalString("config")
+	if configFile == "" {
+		configFile = "dusk.toml"
+	}
+
+	if err := cfg.Load("dusk", nil, func() (string, error) {
+		return configFile, nil
+	}); err != nil {
+		return err
+	}
+
+	drvr, err := database.From(cfg.Get().Database.Driver)
+	if err != nil {
+		return err
+	}
+
+	db, err := drvr.Open(cfg.Get().Database.Dir, protocol.MagicFromConfig(), false)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = db.Close()
+		_ = drvr.Close()
+	}()
+
+	height, err := database.RevertTip(db, n, participation.Revert)
+	if errors.Is(err, database.ErrStateAhead) {
+		return fmt.Errorf("%v: Rusk can not revert its state, resync the node from scratch instead", err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("revert stopped at height %d: %v", height, err)
+	}
+
+	fmt.Printf("chain tip reverted to height %d\n", height)
+	return nil
+}
@@ -8,24 +8,28 @@ package main
 
 import (
 	"bufio"
-	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"os"
+	"reflect"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/cmd/dusk/snapshot"
+
 	"github.com/dusk-network/dusk-blockchain/pkg/api"
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/bidautomaton"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakeautomaton"
 	walletdb "github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/badger"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
@@ -117,19 +121,31 @@ func readPassword(prompt string) ([]byte, error) {
 // Setup creates a new EventBus, generates the BLS and the ED25519 Keys,
 // launches a new `CommitteeStore`, launches the Blockchain process, creates
 // and launches a monitor client (if configuration demands it), and inits the
-// Stake and Blind Bid channels.
-func Setup() *Server {
+// Stake and Blind Bid channels. An error is returned if the node can not
+// start with its current state.
+func Setup() (*Server, error) {
 	var pw string
 
 	ctx := context.Background()
 	_, err := os.Stat(cfg.Get().Wallet.File)
 
+	// The mnemonic phrase restores the wallet when its file is not found, and
+	// is checked against it otherwise
+	mnemonic := os.Getenv("DUSK_WALLET_MNEMONIC")
+
 	switch {
 	case cfg.Get().General.TestHarness:
 		pw = os.Getenv("DUSK_WALLET_PASS")
 	case os.IsNotExist(err):
 		fmt.Fprintln(os.Stderr, "Wallet file not found. Creating new file...")
 
+		if mnemonic == "" && terminal.IsTerminal(int(os.Stdin.Fd())) {
+			mnemonic, err = getPassword("Enter the mnemonic phrase of the wallet to restore, or leave empty to create a new one:")
+			if err != nil {
+				log.Panic(err)
+			}
+		}
+
 		for {
 			pw, err = getPassword("Enter password:")
 			if err != nil {
@@ -168,6 +184,13 @@ func Setup() *Server {
 
 	_, db := heavy.CreateDBConnection()
 
+	// Bootstrap the chain from a snapshot archive, rather than replaying
+	// every block. The node resumes syncing from the snapshot tip.
+	var snapshotHdr *heavy.SnapshotHeader
+	if snapshotFile != "" {
+		snapshotHdr = importSnapshot(db, snapshotFile)
+	}
+
 	processor := peer.NewMessageProcessor(eventBus)
 	registerPeerServices(processor, db, eventBus, rpcBus)
 
@@ -178,12 +201,24 @@ func Setup() *Server {
 
 	proxy, ruskConn := setupGRPCClients(gctx)
 
+	if snapshotHdr != nil {
+		if err = verifySnapshotProvisioners(ctx, proxy, snapshotHdr); err != nil {
+			_ = ruskConn.Close()
+			return nil, err
+		}
+	}
+
 	var w *wallet.Wallet
 
 	if _, err = os.Stat(cfg.Get().Wallet.File); err == nil {
 		w, err = loadWallet(pw)
+		if err == nil && mnemonic != "" {
+			if err = verifyMnemonic(mnemonic, pw, cfg.Get().Wallet.File, proxy.KeyMaster()); err != nil {
+				err = fmt.Errorf("DUSK_WALLET_MNEMONIC does not restore the wallet file %s: %w", cfg.Get().Wallet.File, err)
+			}
+		}
 	} else {
-		w, err = createWallet(nil, pw, proxy.KeyMaster())
+		w, err = createWallet(mnemonic, pw, proxy.KeyMaster())
 	}
 
 	if err != nil {
@@ -196,7 +231,7 @@ func Setup() *Server {
 
 	// Instantiate API server
 	if cfg.Get().API.Enabled {
-		if apiServer, e := api.NewHTTPServer(eventBus, rpcBus); e != nil {
+		if apiServer, e := api.NewHTTPServer(eventBus, rpcBus, db); e != nil {
 			log.Errorf("API http server error: %v", e)
 		} else {
 			go func() {
@@ -243,6 +278,9 @@ func Setup() *Server {
 	gossip := protocol.NewGossip(protocol.TestNet)
 	connector := peer.NewConnector(eventBus, gossip, cfg.Get().Network.Port, processor, protocol.ServiceFlag(cfg.Get().Network.ServiceFlag), peer.Create)
 
+	// Share the known peers with the network
+	processor.Register(topics.GetAddrs, connector.ProcessGetAddrs)
+
 	seeders := cfg.Get().Network.Seeder.Addresses
 	for _, seeder := range seeders {
 		if err = connector.Connect(seeder); err != nil {
@@ -250,10 +288,18 @@ func Setup() *Server {
 		}
 	}
 
+	// Fall back on the peers known from the previous runs
+	if connector.GetConnectionsCount() == 0 {
+		log.Warn("could not contact any voucher seeders, connecting to the known peers")
+		connector.FillConnections()
+	}
+
 	if connector.GetConnectionsCount() == 0 {
-		panic("could not contact any voucher seeders")
+		panic("could not contact any voucher seeders or known peers")
 	}
 
+	go connector.MaintainConnections(ctx)
+
 	// creating the Server
 	srv := &Server{
 		eventBus:      eventBus,
@@ -274,6 +320,10 @@ func Setup() *Server {
 	_ = stakeautomaton.New(eventBus, rpcBus, grpcServer)
 	_ = bidautomaton.New(eventBus, rpcBus, grpcServer)
 
+	// Setting up the submission of slashes for equivocating provisioners
+	reporter := slashing.NewReporter(eventBus, rpcBus, db, proxy.Provider())
+	go reporter.Listen(ctx)
+
 	// Setting up and launch kadcast peer
 	srv.launchKadcastPeer(processor)
 
@@ -300,7 +350,7 @@ func Setup() *Server {
 		panic(err)
 	}
 
-	return srv
+	return srv, nil
 }
 
 // Close the chain and the connections created through the RPC bus.
@@ -337,6 +387,45 @@ func registerPeerServices(processor *peer.MessageProcessor, db database.DB, even
 	processor.Register(topics.Challenge, responding.CompleteChallenge)
 }
 
+// importSnapshot loads a snapshot archive into the database. A database which
+// already holds a chain is left untouched, so that the node can be restarted
+// with the same flag.
+func importSnapshot(db database.DB, path string) *heavy.SnapshotHeader {
+	hdr, err := snapshot.Import(db, path)
+	if err == heavy.ErrDatabaseNotEmpty {
+		log.WithField("snapshot", path).Warn("database already holds a chain, snapshot not imported")
+		return nil
+	}
+
+	if err != nil {
+		log.WithError(err).WithField("snapshot", path).Panic("could not import snapshot")
+	}
+
+	log.WithField("height", hdr.Height).
+		WithField("tip", hex.EncodeToString(hdr.TipHash)).
+		Info("chain imported from snapshot")
+
+	return hdr
+}
+
+// verifySnapshotProvisioners ensures that the Rusk state was restored at the
+// height of an imported snapshot, as the node could not validate any further
+// block otherwise. The snapshot does not carry the Rusk state: it has to be
+// restored on the Rusk side, from the state of the node the snapshot was
+// exported from.
+func verifySnapshotProvisioners(ctx context.Context, proxy transactions.Proxy, hdr *heavy.SnapshotHeader) error {
+	provisioners, err := proxy.Executor().GetProvisioners(ctx)
+	if err != nil {
+		return fmt.Errorf("could not fetch the provisioners from Rusk to verify the snapshot: %w", err)
+	}
+
+	if !reflect.DeepEqual(provisioners.Members, hdr.Provisioners.Members) {
+		return fmt.Errorf("the provisioners reported by Rusk do not match the snapshot at height %d: the Rusk state must be restored at the snapshot height before starting the node", hdr.Height)
+	}
+
+	return nil
+}
+
 func setupGRPCClients(ctx context.Context) (transactions.Proxy, *grpc.ClientConn) {
 	addr := cfg.Get().RPC.Rusk.Address
 	if cfg.Get().RPC.Rusk.Network == "unix" {
@@ -350,10 +439,11 @@ func setupGRPCClients(ctx context.Context) (transactions.Proxy, *grpc.ClientConn
 	transferClient, _ := client.CreateTransferClient(ctx, addr)
 	stakeClient, _ := client.CreateStakeClient(ctx, addr)
 	walletClient, _ := client.CreateWalletClient(ctx, addr)
+	txClient, _ := client.CreateTransactionServiceClient(ctx, addr)
 
 	txTimeout := time.Duration(cfg.Get().RPC.Rusk.ContractTimeout) * time.Millisecond
 	defaultTimeout := time.Duration(cfg.Get().RPC.Rusk.DefaultTimeout) * time.Millisecond
-	return transactions.NewProxy(ruskClient, keysClient, blindbidServiceClient, bidServiceClient, transferClient, stakeClient, walletClient, txTimeout, defaultTimeout), ruskConn
+	return transactions.NewProxy(ruskClient, keysClient, blindbidServiceClient, bidServiceClient, transferClient, stakeClient, walletClient, txClient, txTimeout, defaultTimeout), ruskConn
 }
 
 func loadWallet(password string) (*wallet.Wallet, error) {
@@ -363,42 +453,57 @@ func loadWallet(password string) (*wallet.Wallet, error) {
 		return nil, err
 	}
 
+	if legacy, err := wallet.IsLegacyFile(cfg.Get().Wallet.File); err == nil && legacy {
+		log.WithField("file", cfg.Get().Wallet.File).
+			Warn("wallet file uses the legacy keystore format, please run `wallet migrate` while the node is stopped")
+	}
+
 	// Then load the wallet
-	return wallet.LoadFromFile(testnet, db, password, cfg.Get().Wallet.File)
+	w, err := wallet.LoadFromFile(testnet, db, password, cfg.Get().Wallet.File)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.IsWatchOnly() {
+		log.WithField("address", string(w.PublicKey.ToAddr())).
+			Info("watch-only wallet loaded, the node will not sign any transaction")
+
+		// The consensus keys are generated on every start, and hold no stake
+		log.WithField("bls_key", hex.EncodeToString(w.Keys().BLSPubKeyBytes)).
+			Warn("watch-only wallet has no staking identity, the node takes part in the consensus with ephemeral keys")
+	}
+
+	return w, nil
 }
 
-func createWallet(seed []byte, password string, keyMaster transactions.KeyMaster) (*wallet.Wallet, error) {
+func createWallet(mnemonic, password string, keyMaster transactions.KeyMaster) (*wallet.Wallet, error) {
 	// First load the database
 	db, err := walletdb.New(cfg.Get().Wallet.Store)
 	if err != nil {
 		return nil, err
 	}
 
-	if seed == nil {
-		seed, err = wallet.GenerateNewSeed(nil)
+	generated := mnemonic == ""
+	if generated {
+		mnemonic, err = wallet.NewMnemonic(nil)
 		if err != nil {
+			_ = db.Close()
 			return nil, err
 		}
 	}
 
-	sk, pk, vk, err := keyMaster.GenerateKeys(context.Background(), seed)
+	seed, err := wallet.SeedFromMnemonic(mnemonic)
 	if err != nil {
+		_ = db.Close()
 		return nil, err
 	}
 
-	skBuf := new(bytes.Buffer)
-	if err = keys.MarshalSecretKey(skBuf, &sk); err != nil {
+	keysJSON, err := wallet.KeysFromSeed(context.Background(), seed, keyMaster)
+	if err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 
-	keysJSON := wallet.KeysJSON{
-		Seed:      seed,
-		SecretKey: skBuf.Bytes(),
-		PublicKey: pk,
-		ViewKey:   vk,
-	}
-
 	// Then create the wallet with seed and password
 	w, err := wallet.LoadFromSeed(testnet, db, password, cfg.Get().Wallet.File, keysJSON)
 	if err != nil {
@@ -406,5 +511,69 @@ func createWallet(seed []byte, password string, keyMaster transactions.KeyMaster
 		return nil, err
 	}
 
+	if generated {
+		// Make sure the phrase restores the keys stored in the new wallet
+		// file. Otherwise, the wallet is not created, as the phrase is not
+		// a backup of it.
+		if err := verifyMnemonic(mnemonic, password, cfg.Get().Wallet.File, keyMaster); err != nil {
+			_ = db.Close()
+			_ = os.Remove(cfg.Get().Wallet.File)
+			return nil, fmt.Errorf("the mnemonic phrase does not restore the new wallet: %w", err)
+		}
+
+		// The phrase is not printed, as the output of the node may be
+		// persisted in its logs
+		file, err := saveMnemonic(mnemonic, cfg.Get().Wallet.File)
+		if err != nil {
+			_ = db.Close()
+			_ = os.Remove(cfg.Get().Wallet.File)
+			return nil, fmt.Errorf("saving the mnemonic phrase of the new wallet: %w", err)
+		}
+
+		log.WithField("file", file).
+			Warn("the mnemonic phrase of the new wallet is the only way to restore it if its file is lost: write it down, keep it in a safe place, and delete the file")
+	}
+
 	return w, nil
 }
+
+// saveMnemonic writes the mnemonic phrase of a new wallet next to the wallet
+// file, readable by its owner only, and returns the path of the file. An
+// existing file is never overwritten.
+func saveMnemonic(mnemonic, walletFile string) (string, error) {
+	file := walletFile + ".mnemonic"
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = fmt.Fprintln(f, mnemonic); err != nil {
+		_ = f.Close()
+		_ = os.Remove(file)
+		return "", err
+	}
+
+	return file, f.Close()
+}
+
+// verifyMnemonic restores the keys of a wallet from its mnemonic phrase, and
+// checks them against the ones stored in the wallet file.
+func verifyMnemonic(mnemonic, password, file string, keyMaster transactions.KeyMaster) error {
+	stored, err := wallet.LoadKeys(password, file)
+	if err != nil {
+		return err
+	}
+
+	seed, err := wallet.SeedFromMnemonic(mnemonic)
+	if err != nil {
+		return err
+	}
+
+	restored, err := wallet.KeysFromSeed(context.Background(), seed, keyMaster)
+	if err != nil {
+		return err
+	}
+
+	return wallet.VerifyKeys(stored, restored)
+}
@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/client"
+	"github.com/urfave/cli"
+)
+
+// FileFlag sets the path of the snapshot archive.
+var FileFlag = cli.StringFlag{
+	Name:  "file",
+	Usage: "path of the snapshot archive",
+}
+
+// ExportAction writes the chain stored in the node database to a snapshot
+// archive, together with the provisioner set reported by Rusk. It must be run
+// while the node is stopped, and Rusk is running.
+func ExportAction(c *cli.Context) error {
+	path := c.String(FileFlag.Name)
+	if path == "" {
+		return errors.New("the path of the snapshot archive is required")
+	}
+
+	if err := loadConfig(c); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	provisioners, err := ruskProvisioners(ctx)
+	if err != nil {
+		return err
+	}
+
+	drvr, db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = db.Close()
+		_ = drvr.Close()
+	}()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	hdr, err := heavy.ExportSnapshot(db, f, protocol.MagicFromConfig(), &provisioners)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("chain exported at height %d (tip %x)\n", hdr.Height, hdr.TipHash)
+	return nil
+}
+
+// ImportAction loads a snapshot archive into an empty node database. It must
+// be run while the node is stopped.
+func ImportAction(c *cli.Context) error {
+	path := c.String(FileFlag.Name)
+	if path == "" {
+		return errors.New("the path of the snapshot archive is required")
+	}
+
+	if err := loadConfig(c); err != nil {
+		return err
+	}
+
+	drvr, db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = db.Close()
+		_ = drvr.Close()
+	}()
+
+	hdr, err := Import(db, path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("chain imported at height %d (tip %x)\n", hdr.Height, hdr.TipHash)
+	fmt.Println("the Rusk state must be restored at the same height before starting the node (see pkg/core/database/README.md)")
+	return nil
+}
+
+// Import loads the snapshot archive at the given path into an empty database.
+func Import(db database.DB, path string) (*heavy.SnapshotHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return heavy.ImportSnapshot(db, f, protocol.MagicFromConfig())
+}
+
+func loadConfig(c *cli.Context) error {
+	configFile := c.GlobalString("config")
+	if configFile == "" {
+		configFile = "dusk.toml"
+	}
+
+	return cfg.Load("dusk", nil, func() (string, error) {
+		return configFile, nil
+	})
+}
+
+func openDB() (database.Driver, database.DB, error) {
+	drvr, err := database.From(cfg.Get().Database.Driver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := drvr.Open(cfg.Get().Database.Dir, protocol.MagicFromConfig(), false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return drvr, db, nil
+}
+
+// ruskProvisioners fetches the current provisioner set from Rusk.
+func ruskProvisioners(ctx context.Context) (user.Provisioners, error) {
+	conf := cfg.Get().RPC.Rusk
+
+	addr := conf.Address
+	if conf.Network == "unix" {
+		addr = "unix://" + conf.Address
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, time.Duration(conf.ConnectionTimeout)*time.Millisecond)
+	defer cancel()
+
+	stateClient, conn := client.CreateStateClient(cctx, addr)
+	defer conn.Close()
+
+	txTimeout := time.Duration(conf.ContractTimeout) * time.Millisecond
+	defaultTimeout := time.Duration(conf.DefaultTimeout) * time.Millisecond
+
+	proxy := transactions.NewProxy(stateClient, nil, nil, nil, nil, nil, nil, nil, txTimeout, defaultTimeout)
+	return proxy.Executor().GetProvisioners(ctx)
+}
@@ -0,0 +1,325 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package command
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	"github.com/urfave/cli"
+
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/conf"
+)
+
+var (
+	// ToFlag sets the address receiving a transfer.
+	ToFlag = cli.StringFlag{
+		Name:   "to",
+		Usage:  "address of the recipient",
+		EnvVar: "DUSK_WALLET_TO",
+	}
+	// AmountFlag sets the amount of a transaction, in DUSK.
+	AmountFlag = cli.StringFlag{
+		Name:   "amount",
+		Usage:  "amount of DUSK, eg: --amount=12.5",
+		EnvVar: "DUSK_WALLET_AMOUNT",
+	}
+	// FeeFlag sets the fee of a transaction, in DUSK.
+	FeeFlag = cli.StringFlag{
+		Name:   "fee",
+		Usage:  "fee of the transaction, in DUSK",
+		EnvVar: "DUSK_WALLET_FEE",
+		Value:  "0.00000001",
+	}
+	// LockTimeFlag sets the number of blocks a stake or a bid is locked for.
+	LockTimeFlag = cli.Uint64Flag{
+		Name:   "locktime",
+		Usage:  "number of blocks the DUSK are locked for",
+		EnvVar: "DUSK_WALLET_LOCKTIME",
+	}
+)
+
+// Commands are the non-interactive commands of the wallet.
+var Commands = []cli.Command{
+	{
+		Name:   "balance",
+		Usage:  "prints the unlocked and locked balance of the wallet, in atomic units",
+		Action: BalanceAction,
+	},
+	{
+		Name:   "address",
+		Usage:  "prints the address of the wallet",
+		Action: AddressAction,
+	},
+	{
+		Name:   "history",
+		Usage:  "prints the transactions sent and received by the wallet",
+		Action: HistoryAction,
+	},
+	{
+		Name:   "transfer",
+		Usage:  "sends DUSK to an address",
+		Flags:  []cli.Flag{ToFlag, AmountFlag, FeeFlag},
+		Action: TransferAction,
+	},
+	{
+		Name:   "stake",
+		Usage:  "stakes DUSK to take part in the consensus as a provisioner",
+		Flags:  []cli.Flag{AmountFlag, FeeFlag, LockTimeFlag},
+		Action: StakeAction,
+	},
+	{
+		Name:   "bid",
+		Usage:  "bids DUSK to take part in the consensus as a block generator",
+		Flags:  []cli.Flag{AmountFlag, FeeFlag, LockTimeFlag},
+		Action: BidAction,
+	},
+	{
+		Name:   "automate-stakes",
+		Usage:  "makes the node renew its stake automatically",
+		Action: AutomateStakesAction,
+	},
+	{
+		Name:   "automate-bids",
+		Usage:  "makes the node renew its bid automatically",
+		Action: AutomateBidsAction,
+	},
+}
+
+func init() {
+	for i := range Commands {
+		Commands[i].OnUsageError = OnUsageError
+	}
+}
+
+type balanceJSON struct {
+	UnlockedBalance uint64 `json:"unlocked_balance"`
+	LockedBalance   uint64 `json:"locked_balance"`
+}
+
+type addressJSON struct {
+	Address string `json:"address"`
+}
+
+type txJSON struct {
+	Hash string `json:"hash"`
+}
+
+type responseJSON struct {
+	Response string `json:"response"`
+}
+
+type txRecordJSON struct {
+	Type         string `json:"type"`
+	Direction    string `json:"direction"`
+	Height       uint64 `json:"height"`
+	Timestamp    int64  `json:"timestamp"`
+	Amount       uint64 `json:"amount"`
+	Fee          uint64 `json:"fee"`
+	UnlockHeight uint64 `json:"unlock_height"`
+	Hash         string `json:"hash"`
+	Data         string `json:"data"`
+	Obfuscated   bool   `json:"obfuscated"`
+}
+
+// withClient connects to the node, and runs a request through the client.
+// The failures of the request are reported with ExitRequest.
+func withClient(ctx *cli.Context, request func(context.Context, *conf.NodeClient) (interface{}, error)) error {
+	client, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	res, err := request(reqCtx, client)
+	if err != nil {
+		return fail(ExitRequest, err)
+	}
+
+	return output(res)
+}
+
+// BalanceAction prints the balance of the wallet.
+func BalanceAction(ctx *cli.Context) error {
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.WalletClient.GetBalance(c, &node.EmptyRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		return balanceJSON{UnlockedBalance: resp.UnlockedBalance, LockedBalance: resp.LockedBalance}, nil
+	})
+}
+
+// AddressAction prints the address of the wallet.
+func AddressAction(ctx *cli.Context) error {
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.WalletClient.GetAddress(c, &node.EmptyRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		return addressJSON{Address: string(resp.Key.PublicKey)}, nil
+	})
+}
+
+// HistoryAction prints the transaction history of the wallet.
+func HistoryAction(ctx *cli.Context) error {
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.WalletClient.GetTxHistory(c, &node.EmptyRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		return formatRecords(resp), nil
+	})
+}
+
+func formatRecords(resp *node.TxHistoryResponse) []txRecordJSON {
+	records := make([]txRecordJSON, len(resp.Records))
+
+	for i, record := range resp.Records {
+		records[i] = txRecordJSON{
+			Type:         node.TxType_name[int32(record.Type)],
+			Direction:    strings.ToLower(node.Direction_name[int32(record.Direction)]),
+			Height:       record.Height,
+			Timestamp:    record.Timestamp,
+			Amount:       record.Amount,
+			Fee:          record.Fee,
+			UnlockHeight: record.UnlockHeight,
+			Hash:         hex.EncodeToString(record.Hash),
+			Data:         hex.EncodeToString(record.Data),
+			Obfuscated:   record.Obfuscated,
+		}
+	}
+
+	return records
+}
+
+// TransferAction sends DUSK to an address.
+func TransferAction(ctx *cli.Context) error {
+	address := ctx.String(ToFlag.Name)
+	if address == "" {
+		return fail(ExitUsage, errors.New("the address of the recipient is required"))
+	}
+
+	amount, fee, err := amountAndFee(ctx)
+	if err != nil {
+		return err
+	}
+
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.TransactorClient.Transfer(c, &node.TransferRequest{Amount: amount, Address: []byte(address), Fee: fee})
+		if err != nil {
+			return nil, err
+		}
+
+		return txJSON{Hash: hex.EncodeToString(resp.Hash)}, nil
+	})
+}
+
+// StakeAction stakes DUSK.
+func StakeAction(ctx *cli.Context) error {
+	amount, fee, err := amountAndFee(ctx)
+	if err != nil {
+		return err
+	}
+
+	lockTime, err := lockTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.TransactorClient.Stake(c, &node.StakeRequest{Amount: amount, Fee: fee, Locktime: lockTime})
+		if err != nil {
+			return nil, err
+		}
+
+		return txJSON{Hash: hex.EncodeToString(resp.Hash)}, nil
+	})
+}
+
+// BidAction bids DUSK.
+func BidAction(ctx *cli.Context) error {
+	amount, fee, err := amountAndFee(ctx)
+	if err != nil {
+		return err
+	}
+
+	lockTime, err := lockTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.TransactorClient.Bid(c, &node.BidRequest{Amount: amount, Fee: fee, Locktime: lockTime})
+		if err != nil {
+			return nil, err
+		}
+
+		return txJSON{Hash: hex.EncodeToString(resp.Hash)}, nil
+	})
+}
+
+// AutomateStakesAction makes the node renew its stake automatically.
+func AutomateStakesAction(ctx *cli.Context) error {
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.ProvisionerClient.AutomateStakes(c, &node.EmptyRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		return responseJSON{Response: resp.Response}, nil
+	})
+}
+
+// AutomateBidsAction makes the node renew its bid automatically.
+func AutomateBidsAction(ctx *cli.Context) error {
+	return withClient(ctx, func(c context.Context, client *conf.NodeClient) (interface{}, error) {
+		resp, err := client.BlockGeneratorClient.AutomateBids(c, &node.EmptyRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		return responseJSON{Response: resp.Response}, nil
+	})
+}
+
+func amountAndFee(ctx *cli.Context) (uint64, uint64, error) {
+	amount, err := ParseDUSK(ctx.String(AmountFlag.Name))
+	if err != nil {
+		return 0, 0, fail(ExitUsage, err)
+	}
+
+	if amount == 0 {
+		return 0, 0, fail(ExitUsage, errors.New("the amount must be greater than 0"))
+	}
+
+	fee, err := ParseDUSK(ctx.String(FeeFlag.Name))
+	if err != nil {
+		return 0, 0, fail(ExitUsage, err)
+	}
+
+	return amount, fee, nil
+}
+
+func lockTime(ctx *cli.Context) (uint64, error) {
+	lockTime := ctx.Uint64(LockTimeFlag.Name)
+	if lockTime == 0 {
+		return 0, fail(ExitUsage, errors.New("the lock time must be greater than 0"))
+	}
+
+	return lockTime, nil
+}
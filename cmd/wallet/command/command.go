@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package command implements the non-interactive commands of the wallet,
+// meant to be used from scripts. The commands print their result as JSON on
+// the standard output. On failure, they print a JSON object holding the
+// error on the standard error, and exit with one of the exit codes below.
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/conf"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+)
+
+// Exit codes of the commands.
+const (
+	// ExitError is returned on unexpected failures.
+	ExitError = 1
+	// ExitUsage is returned when the arguments of the command are invalid.
+	ExitUsage = 2
+	// ExitConnection is returned when the node can not be reached.
+	ExitConnection = 3
+	// ExitRequest is returned when the node fails to serve the request.
+	ExitRequest = 4
+)
+
+// requestTimeout bounds the time the node takes to serve a request.
+const requestTimeout = 30 * time.Second
+
+// duskDecimals is the number of decimals of an amount of DUSK.
+const duskDecimals = 10
+
+// ConfigPathFlag sets the directory of the dusk.toml file.
+var ConfigPathFlag = cli.StringFlag{
+	Name:   "configpath",
+	Usage:  "dusk toml path , eg: --configpath=/tmp/localnet-317173610/node-9000",
+	EnvVar: "DUSK_WALLET_CONFIGPATH",
+	Value:  "",
+}
+
+// errorJSON is printed on the standard error when a command fails.
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+// fail wraps err in a cli.ExitCoder, so that the application exits with
+// code after printing the error.
+func fail(code int, err error) error {
+	out, mErr := json.Marshal(errorJSON{Error: err.Error()})
+	if mErr != nil {
+		return cli.NewExitError(err.Error(), code)
+	}
+
+	return cli.NewExitError(string(out), code)
+}
+
+// OnUsageError makes the commands exit with ExitUsage when their flags can
+// not be parsed.
+func OnUsageError(_ *cli.Context, err error, _ bool) error {
+	return fail(ExitUsage, err)
+}
+
+// output prints v as JSON on the standard output.
+func output(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fail(ExitError, err)
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, string(out))
+	return nil
+}
+
+// connect loads the configuration and establishes a gRPC connection with the
+// node.
+func connect(ctx *cli.Context) (*conf.NodeClient, error) {
+	config, err := conf.InitConfig(ctx.GlobalString(ConfigPathFlag.Name))
+	if err != nil {
+		return nil, fail(ExitUsage, err)
+	}
+
+	client := conf.NewNodeClient()
+	if err := client.Connect(config.RPC); err != nil {
+		return nil, fail(ExitConnection, err)
+	}
+
+	return client, nil
+}
+
+// ParseDUSK converts an amount of DUSK, with up to 10 decimals, into atomic
+// units. Unlike a conversion through a float, no precision is lost.
+func ParseDUSK(amount string) (uint64, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	parts := strings.SplitN(amount, ".", 2)
+
+	whole, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	var fraction uint64
+
+	if len(parts) == 2 {
+		decimals := parts[1]
+		if decimals == "" || len(decimals) > duskDecimals {
+			return 0, fmt.Errorf("invalid amount %q, at most %d decimals are allowed", amount, duskDecimals)
+		}
+
+		decimals += strings.Repeat("0", duskDecimals-len(decimals))
+
+		if fraction, err = strconv.ParseUint(decimals, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+
+	if whole > (^uint64(0)-fraction)/wallet.DUSK {
+		return 0, fmt.Errorf("amount %q is too large", amount)
+	}
+
+	return whole*wallet.DUSK + fraction, nil
+}
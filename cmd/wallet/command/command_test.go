@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestParseDUSK(t *testing.T) {
+	assert := require.New(t)
+
+	for amount, expected := range map[string]uint64{
+		"1":            10000000000,
+		"12.5":         125000000000,
+		" 0.00000001 ": 100,
+		"0.0000000001": 1,
+		"1844674407":   18446744070000000000,
+	} {
+		parsed, err := ParseDUSK(amount)
+		assert.NoError(err, amount)
+		assert.Equal(expected, parsed, amount)
+	}
+
+	for _, amount := range []string{"", "-1", "1.", ".5", "1,5", "1e3", "0.00000000001", "1844674408", "abc"} {
+		_, err := ParseDUSK(amount)
+		assert.Error(err, amount)
+	}
+}
+
+func TestFail(t *testing.T) {
+	err := fail(ExitRequest, errors.New(`insufficient "funds"`))
+
+	exitErr, ok := err.(cli.ExitCoder)
+	require.True(t, ok)
+	require.Equal(t, ExitRequest, exitErr.ExitCode())
+	require.JSONEq(t, `{"error": "insufficient \"funds\""}`, exitErr.Error())
+}
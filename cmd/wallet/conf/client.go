@@ -9,6 +9,7 @@ package conf
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"time"
 
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
@@ -59,7 +60,7 @@ func (c *NodeClient) Connect(conf rpcConfiguration) error {
 		if conf.Network != "unix" {
 			// Insecure connection can be suitable only for unix socket
 			// transport where node and wallet-cli are co-deployed
-			panic("insecure transport not allowed over tcp transport")
+			return errors.New("insecure transport not allowed over tcp transport")
 		}
 
 		dialOptions = append(dialOptions, grpc.WithInsecure())
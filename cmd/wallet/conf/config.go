@@ -8,15 +8,15 @@ package conf
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/viper"
 )
 
-// Registry holds General and RPC.
+// Registry holds General, RPC and Wallet.
 type Registry struct {
 	General generalConfiguration
 	RPC     rpcConfiguration
+	Wallet  walletConfiguration
 }
 
 // Node general configs.
@@ -24,6 +24,11 @@ type generalConfiguration struct {
 	Network string
 }
 
+// wallet configs.
+type walletConfiguration struct {
+	File string
+}
+
 // rpc client configs.
 type rpcConfiguration struct {
 	Network string
@@ -36,10 +41,23 @@ type rpcConfiguration struct {
 
 	User string
 	Pass string
+
+	Rusk ruskConfiguration
+}
+
+// rusk client configs.
+type ruskConfiguration struct {
+	Network string
+	Address string
+
+	// timeout for rusk calls, in milliseconds.
+	DefaultTimeout uint
 }
 
 // InitConfig will init the config vars from viper.
-func InitConfig(cfg string) Registry {
+func InitConfig(cfg string) (Registry, error) {
+	var conf Registry
+
 	viper.SetConfigName("dusk")
 
 	if cfg == "" {
@@ -50,17 +68,12 @@ func InitConfig(cfg string) Registry {
 	}
 
 	if err := viper.ReadInConfig(); err != nil {
-		// FIXME: 493 - this is completely outdated. We do not use dusk-wallet-cli
-		// anymore
-		_, _ = fmt.Fprintln(os.Stdout, "Config file not found. Please place dusk-wallet-cli in the same directory as your dusk.toml file.")
-		os.Exit(0)
+		return conf, fmt.Errorf("config file not found, please point --configpath to the directory of your dusk.toml file: %v", err)
 	}
 
-	var conf Registry
 	if err := viper.Unmarshal(&conf); err != nil {
-		_, _ = fmt.Fprintln(os.Stdout, "Could not decode config file "+err.Error())
-		os.Exit(1)
+		return conf, fmt.Errorf("could not decode config file: %v", err)
 	}
 
-	return conf
+	return conf, nil
 }
@@ -13,23 +13,33 @@ import (
 
 	"github.com/urfave/cli"
 
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/command"
 	"github.com/dusk-network/dusk-blockchain/cmd/wallet/conf"
 	"github.com/dusk-network/dusk-blockchain/cmd/wallet/prompt"
 )
 
-var configPathFlag = cli.StringFlag{
-	Name:  "configpath",
-	Usage: "dusk toml path , eg: --configpath=/tmp/localnet-317173610/node-9000",
-	Value: "",
-}
-
 func main() {
 	app := cli.NewApp()
 	app.Usage = "The Dusk Wallet command line interface"
 	app.Action = walletAction
 	app.Flags = []cli.Flag{
-		configPathFlag,
+		command.ConfigPathFlag,
 	}
+	app.Commands = append(command.Commands, []cli.Command{
+		{
+			Name:   "migrate",
+			Usage:  "re-encrypts a legacy wallet file in the current keystore format (node must be stopped)",
+			Flags:  []cli.Flag{walletFileFlag},
+			Action: migrateAction,
+		},
+		{
+			Name:   "restore",
+			Usage:  "restores the wallet file from its mnemonic phrase, or checks the phrase against an existing file (node must be stopped)",
+			Flags:  []cli.Flag{walletFileFlag},
+			Action: restoreAction,
+		},
+	}...)
+	app.Commands = append(app.Commands, watchCommands...)
 
 	if err := app.Run(os.Args); err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
@@ -40,8 +50,13 @@ func main() {
 func walletAction(ctx *cli.Context) error {
 	defer handlePanic()
 
-	configPath := ctx.String(configPathFlag.Name)
-	config := conf.InitConfig(configPath)
+	configPath := ctx.String(command.ConfigPathFlag.Name)
+
+	config, err := conf.InitConfig(configPath)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return err
+	}
 
 	// Establish a gRPC connection with the node.
 	_, _ = fmt.Fprintln(os.Stdout, "Wallet will establish a gRPC connection with the node.", config.RPC.Address)
@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/command"
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/conf"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+)
+
+var walletFileFlag = cli.StringFlag{
+	Name:  "file",
+	Usage: "wallet file, defaults to the [wallet] file of dusk.toml",
+	Value: "",
+}
+
+// migrateAction re-encrypts a legacy wallet file in the current keystore
+// format. The legacy file is kept as a backup next to the wallet file. The
+// node must be stopped while migrating its wallet.
+func migrateAction(ctx *cli.Context) error {
+	file, err := walletFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	legacy, err := wallet.IsLegacyFile(file)
+	if err != nil {
+		return err
+	}
+
+	if !legacy {
+		_, _ = fmt.Fprintln(os.Stdout, "Wallet file", file, "is already up to date.")
+		return nil
+	}
+
+	password, err := readSecret("DUSK_WALLET_PASS", "Password")
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.MigrateFile(password, file); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "Wallet file", file, "migrated. The legacy file is kept in", file+".bak")
+	return nil
+}
+
+// walletFile returns the wallet file set with the --file flag, or the one
+// of the configuration.
+func walletFile(ctx *cli.Context) (string, error) {
+	if file := ctx.String(walletFileFlag.Name); file != "" {
+		return file, nil
+	}
+
+	config, err := conf.InitConfig(ctx.GlobalString(command.ConfigPathFlag.Name))
+	if err != nil {
+		return "", err
+	}
+
+	return config.Wallet.File, nil
+}
+
+// readSecret reads a secret from the environment variable, or prompts the
+// user for it if the variable is not set.
+func readSecret(env, label string) (string, error) {
+	if secret := os.Getenv(env); secret != "" {
+		return secret, nil
+	}
+
+	prompt := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+	}
+
+	return prompt.Run()
+}
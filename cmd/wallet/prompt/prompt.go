@@ -40,7 +40,7 @@ func WalletMenu(client *conf.NodeClient) error {
 
 		_, result, err := prompt.Run()
 		if err != nil {
-			panic(err)
+			return err
 		}
 
 		var res string
@@ -10,13 +10,16 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/command"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"github.com/manifoldco/promptui"
 )
 
 func transferDusk(client node.TransactorClient) (*node.TransactionResponse, error) {
-	amount := getAmount()
+	amount, err := getAmount()
+	if err != nil {
+		return nil, err
+	}
 
 	// FIXME: 493 - there should be syntax-validation of address
 	validateAddress := func(input string) error {
@@ -43,26 +46,39 @@ func transferDusk(client node.TransactorClient) (*node.TransactionResponse, erro
 }
 
 func bidDusk(client node.TransactorClient) (*node.TransactionResponse, error) {
-	amount := getAmount()
-	lockTime := getLockTime()
+	amount, err := getAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	lockTime, err := getLockTime()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: parameterize fee
 	return client.Bid(context.Background(), &node.BidRequest{Amount: amount, Fee: 100, Locktime: lockTime})
 }
 
 func stakeDusk(client node.TransactorClient) (*node.TransactionResponse, error) {
-	amount := getAmount()
-	lockTime := getLockTime()
+	amount, err := getAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	lockTime, err := getLockTime()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: parameterize fee
 	return client.Stake(context.Background(), &node.StakeRequest{Amount: amount, Fee: 100, Locktime: lockTime})
 }
 
-func getAmount() uint64 {
+func getAmount() (uint64, error) {
 	validate := func(input string) error {
-		if _, err := strconv.ParseFloat(input, 64); err != nil {
-			return err
-		}
-
-		return nil
+		_, err := command.ParseDUSK(input)
+		return err
 	}
 
 	prompt := promptui.Prompt{
@@ -72,17 +88,15 @@ func getAmount() uint64 {
 
 	amountString, err := prompt.Run()
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 
-	amountFloat, _ := strconv.ParseFloat(amountString, 64)
-	amount := uint64(amountFloat * float64(wallet.DUSK))
-	return amount
+	return command.ParseDUSK(amountString)
 }
 
-func getLockTime() uint64 {
+func getLockTime() (uint64, error) {
 	validate := func(input string) error {
-		if _, err := strconv.Atoi(input); err != nil {
+		if _, err := strconv.ParseUint(input, 10, 64); err != nil {
 			return err
 		}
 
@@ -96,9 +110,8 @@ func getLockTime() uint64 {
 
 	lockTimeString, err := prompt.Run()
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 
-	lockTime, _ := strconv.Atoi(lockTimeString)
-	return uint64(lockTime)
+	return strconv.ParseUint(lockTimeString, 10, 64)
 }
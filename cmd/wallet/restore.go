@@ -0,0 +1,117 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/command"
+	"github.com/dusk-network/dusk-blockchain/cmd/wallet/conf"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+)
+
+const (
+	ruskDialTimeout    = 10 * time.Second
+	defaultRuskTimeout = time.Second
+)
+
+// restoreAction restores the wallet file from its mnemonic phrase. The keys
+// of the funds are generated by Rusk, which must be running. If the wallet
+// file already exists, the restored keys are checked against it instead.
+func restoreAction(ctx *cli.Context) error {
+	config, err := conf.InitConfig(ctx.GlobalString(command.ConfigPathFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	file := ctx.String(walletFileFlag.Name)
+	if file == "" {
+		file = config.Wallet.File
+	}
+
+	mnemonic, err := readSecret("DUSK_WALLET_MNEMONIC", "Mnemonic phrase")
+	if err != nil {
+		return err
+	}
+
+	seed, err := wallet.SeedFromMnemonic(mnemonic)
+	if err != nil {
+		return err
+	}
+
+	password, err := readSecret("DUSK_WALLET_PASS", "Password")
+	if err != nil {
+		return err
+	}
+
+	keyMaster, conn, err := connectRusk(config)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	restored, err := wallet.KeysFromSeed(context.Background(), seed, keyMaster)
+	if err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(file); err == nil {
+		var original wallet.KeysJSON
+
+		original, err = wallet.LoadKeys(password, file)
+		if err != nil {
+			return err
+		}
+
+		if err = wallet.VerifyKeys(original, restored); err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintln(os.Stdout, "The mnemonic phrase restores the keys of wallet file", file)
+		return nil
+	}
+
+	if err = wallet.SaveKeys(password, file, restored); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "Wallet file", file, "restored.")
+	return nil
+}
+
+func connectRusk(config conf.Registry) (transactions.KeyMaster, *grpc.ClientConn, error) {
+	addr := config.RPC.Rusk.Address
+	if config.RPC.Rusk.Network == "unix" {
+		addr = "unix://" + addr
+	}
+
+	dialCtx, cancel := context.WithTimeout(context.Background(), ruskDialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to rusk at %s: %v", addr, err)
+	}
+
+	timeout := time.Duration(config.RPC.Rusk.DefaultTimeout) * time.Millisecond
+	if timeout == 0 {
+		timeout = defaultRuskTimeout
+	}
+
+	return transactions.NewKeyMaster(rusk.NewKeysClient(conn), timeout), conn, nil
+}
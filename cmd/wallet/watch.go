@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+)
+
+var (
+	viewKeyFlag = cli.StringFlag{
+		Name:   "viewkey",
+		Usage:  "view key exported with the export-viewkey command",
+		EnvVar: "DUSK_WALLET_VIEWKEY",
+	}
+	addressFlag = cli.StringFlag{
+		Name:   "address",
+		Usage:  "address of the watched account",
+		EnvVar: "DUSK_WALLET_ADDRESS",
+	}
+)
+
+var watchCommands = []cli.Command{
+	{
+		Name:   "export-viewkey",
+		Usage:  "prints the view key and the address of the wallet, to create a watch-only wallet",
+		Flags:  []cli.Flag{walletFileFlag},
+		Action: exportViewKeyAction,
+	},
+	{
+		Name:   "watch",
+		Usage:  "creates a watch-only wallet file from a view key (node must be stopped)",
+		Flags:  []cli.Flag{walletFileFlag, viewKeyFlag, addressFlag},
+		Action: watchAction,
+	},
+}
+
+// exportViewKeyAction prints the view key and the address of the wallet.
+func exportViewKeyAction(ctx *cli.Context) error {
+	file, err := walletFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	password, err := readSecret("DUSK_WALLET_PASS", "Password")
+	if err != nil {
+		return err
+	}
+
+	keysJSON, err := wallet.LoadKeys(password, file)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := wallet.EncodeViewKey(keysJSON.ViewKey)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "View key:", encoded)
+	_, _ = fmt.Fprintln(os.Stdout, "Address: ", string(keysJSON.PublicKey.ToAddr()))
+	return nil
+}
+
+// watchAction creates a watch-only wallet file. The node loading it reports
+// the balance of the watched account, and refuses to sign transactions.
+func watchAction(ctx *cli.Context) error {
+	vk, err := wallet.DecodeViewKey(ctx.String(viewKeyFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	pk, err := wallet.DecodeAddress(ctx.String(addressFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	file, err := walletFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	password, err := readSecret("DUSK_WALLET_PASS", "Password")
+	if err != nil {
+		return err
+	}
+
+	if err = wallet.SaveWatchOnly(password, file, pk, vk); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "Watch-only wallet file", file, "created.")
+	return nil
+}
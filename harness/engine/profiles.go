@@ -109,6 +109,9 @@ func Profile1(index int, node *DuskNode, walletPath string) {
 	viper.Set("mempool.poolType", "hashmap")
 	viper.Set("mempool.preallocTxs", "100")
 	viper.Set("mempool.maxInvItems", "10000")
+	viper.Set("mempool.txTTL", "3600")
+	viper.Set("mempool.reverifyTxs", "100")
+	viper.Set("mempool.minFeeBump", "10")
 
 	viper.Set("consensus.defaultlocktime", 1000)
 	viper.Set("consensus.defaultoffset", 10)
@@ -7,6 +7,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -22,7 +23,7 @@ import (
 )
 
 func TestConsensusAPISmokeTest(t *testing.T) {
-	apiServer, err := NewHTTPServer(nil, nil)
+	apiServer, err := NewHTTPServer(nil, nil, nil)
 	if err != nil {
 		t.Errorf("API http server error: %v", err)
 	}
@@ -73,7 +74,7 @@ func TestConsensusAPIProvisioners(t *testing.T) {
 	require.Nil(t, err)
 	cfg.Mock(&r)
 
-	apiServer, err := NewHTTPServer(nil, nil)
+	apiServer, err := NewHTTPServer(nil, nil, nil)
 	require.Nil(t, err)
 
 	provisioners, _ := consensus.MockProvisioners(5)
@@ -133,7 +134,7 @@ func TestConsensusAPIRoundInfo(t *testing.T) {
 	require.Nil(t, err)
 	cfg.Mock(&r)
 
-	apiServer, err := NewHTTPServer(nil, nil)
+	apiServer, err := NewHTTPServer(nil, nil, nil)
 	require.Nil(t, err)
 
 	for i := 1; i < 6; i++ {
@@ -180,7 +181,7 @@ func TestConsensusAPIEventStatus(t *testing.T) {
 	require.Nil(t, err)
 	cfg.Mock(&r)
 
-	apiServer, err := NewHTTPServer(nil, nil)
+	apiServer, err := NewHTTPServer(nil, nil, nil)
 	require.Nil(t, err)
 
 	for i := 1; i < 6; i++ {
@@ -215,6 +216,49 @@ func TestConsensusAPIEventStatus(t *testing.T) {
 	})
 }
 
+func TestConsensusAPIEventQueueStats(t *testing.T) {
+	// setup viper timeout
+	cwd, err := os.Getwd()
+	require.Nil(t, err)
+
+	r, err := cfg.LoadFromFile(cwd + "/../../dusk.toml")
+	require.Nil(t, err)
+	cfg.Mock(&r)
+
+	apiServer, err := NewHTTPServer(nil, nil, nil)
+	require.Nil(t, err)
+
+	for _, name := range []string{"event", "round"} {
+		stats := capi.EventQueueStatsJSON{
+			Queue:            name,
+			Round:            1,
+			Queued:           10,
+			DroppedFarFuture: 2,
+			DroppedStepFull:  3,
+			DroppedDuplicate: 4,
+			UpdatedAt:        time.Now(),
+		}
+		err = apiServer.store.Save(&stats)
+		require.Nil(t, err)
+	}
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		response := r.Get("/consensus/eventqueuestats")
+		require.NotNil(t, response)
+		require.NotEmpty(t, response.RawBody)
+
+		var stats []capi.EventQueueStatsJSON
+		require.Nil(t, json.Unmarshal(response.RawBody, &stats))
+		require.Len(t, stats, 2)
+
+		for _, s := range stats {
+			require.Equal(t, uint64(2), s.DroppedFarFuture)
+			require.Equal(t, uint64(3), s.DroppedStepFull)
+			require.Equal(t, uint64(4), s.DroppedDuplicate)
+		}
+	})
+}
+
 func TestP2PLogsReader(t *testing.T) {
 	// setup viper timeout
 	cwd, err := os.Getwd()
@@ -224,7 +268,7 @@ func TestP2PLogsReader(t *testing.T) {
 	require.Nil(t, err)
 	cfg.Mock(&r)
 
-	apiServer, err := NewHTTPServer(nil, nil)
+	apiServer, err := NewHTTPServer(nil, nil, nil)
 	require.Nil(t, err)
 
 	// steps array
@@ -266,7 +310,7 @@ func TestP2PLogsWriter(t *testing.T) {
 	require.Nil(t, err)
 	cfg.Mock(&r)
 
-	apiServer, err := NewHTTPServer(nil, nil)
+	apiServer, err := NewHTTPServer(nil, nil, nil)
 	require.Nil(t, err)
 
 	// steps array
@@ -298,3 +342,31 @@ func TestP2PLogsWriter(t *testing.T) {
 		require.True(t, len(body) > 100)
 	})
 }
+
+func TestP2PBans(t *testing.T) {
+	apiServer, err := NewHTTPServer(nil, nil, nil)
+	require.Nil(t, err)
+
+	now := time.Now()
+	bans := []capi.BanJSON{
+		{Host: "10.0.0.1", Reason: "active", BannedAt: now, Until: now.Add(time.Hour)},
+		{Host: "10.0.0.2", Reason: "expired", BannedAt: now.Add(-2 * time.Hour), Until: now.Add(-time.Hour)},
+	}
+
+	for i := range bans {
+		require.Nil(t, apiServer.store.Save(&bans[i]))
+	}
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		response := r.Get("/p2p/bans")
+		require.Equal(t, 200, response.StatusCode)
+
+		var list []capi.BanJSON
+		require.Nil(t, json.Unmarshal(response.RawBody, &list))
+
+		// Expired bans are not listed
+		require.Len(t, list, 1)
+		require.Equal(t, "10.0.0.1", list[0].Host)
+		require.Equal(t, "active", list[0].Reason)
+	})
+}
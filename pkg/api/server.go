@@ -16,6 +16,7 @@ import (
 
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 
@@ -36,13 +37,15 @@ type Server struct {
 	// Node components.
 	eventBus *eventbus.EventBus
 	rpcBus   *rpcbus.RPCBus
+	db       database.DB
 	store    *capi.StormDBInstance
 
 	Server *http.Server
 }
 
-// NewHTTPServer return pointer to new created server object.
-func NewHTTPServer(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus) (*Server, error) {
+// NewHTTPServer return pointer to new created server object. The blockchain
+// database db serves the consensus participation of the provisioners.
+func NewHTTPServer(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, db database.DB) (*Server, error) {
 	dbFile := cfg.Get().API.DBFile
 	if dbFile == "" {
 		log.Info("Will start monitoring db with in-memory since DBFile cfg is not set")
@@ -65,6 +68,7 @@ func NewHTTPServer(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus) (*Server,
 	srv := Server{
 		eventBus: eventBus,
 		rpcBus:   rpcBus,
+		db:       db,
 		store:    store,
 	}
 
@@ -111,14 +115,17 @@ func (s *Server) InitRouting() *pat.Router {
 	))
 
 	// init consensus API services
-	capi.StartAPI(s.eventBus, s.rpcBus)
+	capi.StartAPI(s.eventBus, s.rpcBus, s.db)
 
 	r.HandleFunc("/consensus/bidders", capi.GetBiddersHandler).Methods("GET")
 	r.HandleFunc("/consensus/provisioners", capi.GetProvisionersHandler).Methods("GET")
 	r.HandleFunc("/consensus/roundinfo", capi.GetRoundInfoHandler).Methods("GET")
 	r.HandleFunc("/consensus/eventqueuestatus", capi.GetEventQueueStatusHandler).Methods("GET")
+	r.HandleFunc("/consensus/eventqueuestats", capi.GetEventQueueStatsHandler).Methods("GET")
+	r.HandleFunc("/consensus/participation", capi.GetParticipationHandler).Methods("GET")
 	r.HandleFunc("/p2p/logs", capi.GetP2PLogsHandler).Methods("GET")
 	r.HandleFunc("/p2p/count", capi.GetP2PCountHandler).Methods("GET")
+	r.HandleFunc("/p2p/bans", capi.GetP2PBansHandler).Methods("GET")
 
 	return r
 }
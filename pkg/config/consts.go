@@ -29,6 +29,10 @@ const (
 	// Maximum number of blocks to be requested/delivered on a single syncing session with a peer.
 	MaxInvBlocks = 500
 
+	// MaxReorgDepth is the maximum number of blocks that can be reverted on a
+	// chain reorganisation.
+	MaxReorgDepth = 50
+
 	// Protocol-based consensus step time.
 	ConsensusTimeOut = 5 * time.Second
 
@@ -59,6 +59,18 @@ type networkConfiguration struct {
 	MaxConnections     int
 
 	ServiceFlag uint8
+
+	// BanScore is the misbehaviour score at which a peer is disconnected and
+	// banned. Zero disables the banning.
+	BanScore uint32
+	// BanDuration is the number of seconds a peer stays banned.
+	BanDuration uint32
+	// BanList is the file where the bans are persisted across restarts.
+	// Empty keeps the bans in memory only.
+	BanList string
+	// AddrBook is the file where the addresses of the known peers are
+	// persisted across restarts. Empty keeps them in memory only.
+	AddrBook string
 }
 
 type kadcastConfiguration struct {
@@ -91,6 +103,18 @@ type seedersConfiguration struct {
 type databaseConfiguration struct {
 	Driver string
 	Dir    string
+
+	// PruneDepth is the number of blocks below the chain tip for which the
+	// transactions are kept. Zero disables the pruning.
+	PruneDepth uint64
+
+	// TxIndex enables the secondary index of the transactions by output
+	// public key and by type.
+	TxIndex bool
+
+	// Durability defines which writes wait for fsync: none, tip (the writes
+	// moving the chain tip) or all.
+	Durability string
 }
 
 // wallet configs.
@@ -176,6 +200,24 @@ type mempoolConfiguration struct {
 	PoolType    string
 	PreallocTxs uint32
 	MaxInvItems uint32
+	// TxTTL is the number of seconds a transaction is kept in the pool. Zero
+	// disables the expiry.
+	TxTTL uint32
+	// ReverifyTxs is the number of oldest transactions re-verified on each
+	// mempool maintenance.
+	ReverifyTxs uint32
+	// JournalPath is the file where the verified transactions are persisted
+	// across restarts. Empty disables the journal.
+	JournalPath string
+	// MinFeeBump is the minimum fee increase, in percent, for a transaction
+	// to replace the ones spending the same inputs.
+	MinFeeBump uint32
+	// PeerTxRate is the number of transactions per second a network peer
+	// can send, on top of PeerTxBurst. Zero disables the rate limiting.
+	PeerTxRate uint32
+	// PeerTxBurst is the number of transactions a network peer can send at
+	// once.
+	PeerTxBurst uint32
 }
 
 type consensusConfiguration struct {
@@ -183,4 +225,12 @@ type consensusConfiguration struct {
 	DefaultAmount   uint64
 	// ConsensusTimeOut is the time out for consensus step timers.
 	ConsensusTimeOut int64
+	// MaxCommitteeSize is the maximum size of the voting committees.
+	MaxCommitteeSize int
+	// MaxQueueLookahead is the amount of rounds ahead of the current one
+	// for which consensus events are queued.
+	MaxQueueLookahead uint64
+	// MaxQueuedStepEvents is the maximum amount of consensus events queued
+	// for a single step.
+	MaxQueuedStepEvents int
 }
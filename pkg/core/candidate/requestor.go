@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -22,6 +23,10 @@ import (
 
 var log = lg.WithField("process", "candidate-requestor")
 
+// invalidCandidateScore is the misbehaviour score of a peer providing a
+// candidate which does not match its hash or tx root.
+const invalidCandidateScore = 50
+
 // Requestor serves to retrieve certain Candidate messages from peers in the
 // network.
 type Requestor struct {
@@ -44,6 +49,7 @@ func NewRequestor(publisher eventbus.Publisher) *Requestor {
 func (r *Requestor) ProcessCandidate(srcPeerID string, msg message.Message) ([]bytes.Buffer, error) {
 	if r.isRequesting() {
 		if err := Validate(msg); err != nil {
+			peer.ReportMisbehaviour(r.publisher, srcPeerID, invalidCandidateScore, err.Error())
 			return nil, err
 		}
 
@@ -15,12 +15,14 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
@@ -32,6 +34,33 @@ import (
 
 var log = logger.WithFields(logger.Fields{"process": "chain"})
 
+const (
+	// dishonestSyncScore is the misbehaviour score of a peer which triggers
+	// the syncing procedure, but fails to deliver the blocks. Honest peers can
+	// be slow too, so that only the peers failing repeatedly get banned.
+	dishonestSyncScore = 5
+
+	// invalidBlockScore is the misbehaviour score of a peer sending a block
+	// which fails the verification or carries an invalid certificate.
+	invalidBlockScore = 25
+)
+
+// invalidBlockError is returned for the blocks failing the verification, as
+// opposed to the failures of the node itself, so that the peer sending them
+// can be reported.
+type invalidBlockError struct {
+	hash []byte
+	err  error
+}
+
+func (e *invalidBlockError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidBlockError) Unwrap() error {
+	return e.err
+}
+
 // TODO: This Verifier/Loader interface needs to be re-evaluated and most likely
 // renamed. They don't make too much sense on their own (the `Loader` also
 // appends blocks, and allows for fetching data from the DB), and potentially
@@ -58,8 +87,13 @@ type Loader interface {
 	Height() (uint64, error)
 	// BlockAt returns the block at a given height.
 	BlockAt(uint64) (block.Block, error)
-	// Append a block on the storage.
-	Append(*block.Block) error
+	// Append a block on the storage. The update function, if any, is run in
+	// the same transaction.
+	Append(blk *block.Block, update func(database.Transaction) error) error
+	// Revert removes the tip from the storage and returns the new tip. The
+	// update function, if any, is run in the same transaction, before the tip
+	// is removed.
+	Revert(update func(database.Transaction) error) (*block.Block, error)
 }
 
 // Ledger is the Chain interface used in tests.
@@ -90,6 +124,19 @@ type Chain struct {
 	// Current set of provisioners.
 	p *user.Provisioners
 
+	// executedHeight is the height of the Rusk state, when it was found above
+	// the chain tip on startup. The blocks up to it are stored without being
+	// executed again. See recoverState.
+	executedHeight uint64
+
+	// Provisioners sets as they were after accepting each of the latest
+	// config.MaxReorgDepth blocks, indexed by height. Used to restore the
+	// set on a chain reorganisation.
+	pHistory map[uint64]*user.Provisioners
+
+	// Blocks from competing branches.
+	forks *forkTracker
+
 	// Consensus loop.
 	loop              *loop.Consensus
 	stopConsensusChan chan struct{}
@@ -116,6 +163,8 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, loade
 		ctx:               ctx,
 		loop:              loop,
 		stopConsensusChan: make(chan struct{}),
+		pHistory:          make(map[uint64]*user.Provisioners),
+		forks:             newForkTracker(),
 	}
 
 	chain.synchronizer = newSynchronizer(db, chain)
@@ -135,6 +184,12 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, loade
 
 	chain.tip = prevBlock
 
+	if err := chain.recoverState(); err != nil {
+		return nil, err
+	}
+
+	chain.recordProvisioners()
+
 	if prevBlock.Header.Height == 0 {
 		// TODO: this is currently mocking bid values, and should be removed when
 		// RUSK integration is finished, and testnet is ready to launch.
@@ -184,23 +239,45 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 		log.WithField("blk_height", blk.Header.Height).Trace("received block")
 	}
 
-	// Is it worth looking at this?
-	if blk.Header.Height <= c.tip.Header.Height {
-		log.Debug("discarded block from the past")
-		return nil, nil
+	// Blocks which do not extend our tip could belong to a competing branch.
+	if blk.Header.Height <= c.tip.Header.Height ||
+		(blk.Header.Height == c.tip.Header.Height+1 && !bytes.Equal(blk.Header.PrevBlockHash, c.tip.Header.Hash)) {
+		err := c.processForkBlock(blk)
+		c.reportInvalidBlock(srcPeerID, blk, err)
+		return nil, err
 	}
 
 	if blk.Header.Height > c.highestSeen {
 		c.highestSeen = blk.Header.Height
 	}
 
-	return c.synchronizer.processBlock(srcPeerID, c.tip.Header.Height, blk, kadcastHeight)
+	res, err := c.synchronizer.processBlock(srcPeerID, c.tip.Header.Height, blk, kadcastHeight)
+	c.reportInvalidBlock(srcPeerID, blk, err)
+	return res, err
+}
+
+// reportInvalidBlock reports the peer which sent blk, if the block failed the
+// verification. The failures of the other blocks of the branch, or of the
+// sequence it completes, are not accounted to the peer.
+func (c *Chain) reportInvalidBlock(srcPeerID string, blk block.Block, err error) {
+	var invalid *invalidBlockError
+	if errors.As(err, &invalid) && bytes.Equal(invalid.hash, blk.Header.Hash) {
+		peer.ReportMisbehaviour(c.eventBus, srcPeerID, invalidBlockScore, "invalid block: "+err.Error())
+	}
 }
 
 // ProduceBlock will start the consensus loop. It can be halted at any point by
 // sending a signal through the `stopConsensus` channel (`StopBlockProduction`
 // as exposed by the `Ledger` interface).
 func (c *Chain) ProduceBlock() error {
+	if c.recovering() {
+		// The consensus can only run on top of the Rusk state
+		log.WithField("tip_height", c.tip.Header.Height).
+			WithField("executed_height", c.executedHeight).
+			Debug("waiting for the blocks executed by the Rusk state")
+		return nil
+	}
+
 	ctx, cancel := context.WithCancel(c.ctx)
 
 	// Start consensus outside of the goroutine first, so that we can ensure
@@ -332,7 +409,7 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 	// 1. Check that stateless and stateful checks pass
 	if err := c.verifier.SanityCheckBlock(*c.tip, blk); err != nil {
 		l.WithError(err).Error("block verification failed")
-		return err
+		return &invalidBlockError{hash: blk.Header.Hash, err: err}
 	}
 
 	// 2. Check the certificate
@@ -343,24 +420,39 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 
 	if err := verifiers.CheckBlockCertificate(*c.p, blk); err != nil {
 		l.WithError(err).Error("certificate verification failed")
-		return err
+		return &invalidBlockError{hash: blk.Header.Hash, err: err}
 	}
 
 	// 3. Call ExecuteStateTransitionFunction
 	prov_num := c.p.Set.Len()
+	certifiers := c.p
 
-	l.WithField("provisioners", prov_num).Info("calling ExecuteStateTransitionFunction")
+	if blk.Header.Height <= c.executedHeight {
+		// The Rusk state already executed the block before a crash lost it
+		l.WithField("executed_height", c.executedHeight).Info("block already executed, storing it")
+	} else {
+		l.WithField("provisioners", prov_num).Info("calling ExecuteStateTransitionFunction")
 
-	// TODO: the context here should maybe used to set a timeout
-	provisioners, err := c.proxy.Executor().ExecuteStateTransition(c.ctx, blk.Txs, blk.Header.Height)
-	if err != nil {
-		l.WithError(err).Error("Error in executing the state transition")
-		return err
+		// TODO: the context here should maybe used to set a timeout
+		provisioners, err := c.proxy.Executor().ExecuteStateTransition(c.ctx, blk.Txs, blk.Header.Height)
+		if err != nil {
+			l.WithError(err).Error("Error in executing the state transition")
+			return err
+		}
+
+		// Record the executed height before storing the block, so that a crash
+		// losing the block is detected on startup. See recoverState
+		if err := c.storeExecutedHeight(blk); err != nil {
+			l.WithError(err).Error("storing executed height failed")
+			return err
+		}
+
+		// Update the provisioners as blk.Txs may bring new provisioners to the current state
+		c.p = &provisioners
 	}
 
-	// Update the provisioners as blk.Txs may bring new provisioners to the current state
-	c.p = &provisioners
 	c.tip = &blk
+	c.recordProvisioners()
 
 	l.WithField("provisioners", c.p.Set.Len()).
 		WithField("added", c.p.Set.Len()-prov_num).
@@ -370,10 +462,13 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 		go c.storeStakesInStormDB(blk.Header.Height)
 	}
 
-	// 4. Store the approved block
+	// 4. Store the approved block, along with the votes of the provisioners
+	// in its certificate
 	l.Trace("storing block in db")
 
-	if err := c.loader.Append(&blk); err != nil {
+	if err := c.loader.Append(&blk, func(t database.Transaction) error {
+		return participation.Record(t, *certifiers, blk)
+	}); err != nil {
 		l.WithError(err).Error("block storing failed")
 		return err
 	}
@@ -385,7 +480,7 @@ func (c *Chain) AcceptBlock(blk block.Block) error {
 		return err
 	}
 
-	// 6. Notify other subsystems for the accepted block
+	// 5. Notify other subsystems for the accepted block
 	// Subsystems listening for this topic:
 	// mempool.Mempool
 	l.Trace("notifying internally")
@@ -467,6 +562,7 @@ func (c *Chain) getRoundUpdate() consensus.RoundUpdate {
 		Seed:            c.tip.Header.Seed,
 		Hash:            c.tip.Header.Hash,
 		LastCertificate: c.tip.Header.Certificate,
+		LastVersion:     c.tip.Header.Version,
 	}
 }
 
@@ -547,6 +643,8 @@ func (c *Chain) ProcessSyncTimerExpired(strPeerAddr string) error {
 	log.WithField("curr", c.tip.Header.Height).
 		WithField("src_addr", strPeerAddr).Warn("sync timer expired")
 
+	peer.ReportMisbehaviour(c.eventBus, strPeerAddr, dishonestSyncScore, "sync timer expired")
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -557,5 +655,6 @@ func (c *Chain) ProcessSyncTimerExpired(strPeerAddr string) error {
 	log.WithField("state", "inSync").Traceln("change sync state")
 
 	c.state = c.inSync
+	c.syncing = false
 	return nil
 }
@@ -9,6 +9,8 @@ package chain
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -16,9 +18,12 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"google.golang.org/grpc"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -176,6 +181,33 @@ func TestAcceptBlock(t *testing.T) {
 	assert.True(decodedBlk.Equals(c.tip))
 }
 
+// Test that the peer sending a block which fails the verification is reported.
+func TestReportInvalidBlock(t *testing.T) {
+	assert := assert.New(t)
+	eb, c := setupChainTest(t, 0)
+
+	reportChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.Misbehaviour, eventbus.NewChanListener(reportChan))
+
+	blk := mockAcceptableBlock(*c.tip)
+	assert.NoError(c.AcceptBlock(*blk))
+
+	// The certificate is only checked from height 2
+	invalid := childBlock(blk, 1)
+
+	_, err := c.ProcessBlockFromNetwork("127.0.0.1:7000", message.New(topics.Block, *invalid))
+	assert.Error(err)
+
+	select {
+	case msg := <-reportChan:
+		r := msg.Payload().(message.Misbehaviour)
+		assert.Equal("127.0.0.1:7000", r.Address)
+		assert.Equal(uint32(invalidBlockScore), r.Score)
+	case <-time.After(time.Second):
+		t.Fatal("peer not reported")
+	}
+}
+
 func createLoader(db database.DB) *DBLoader {
 	genesis := config.DecodeGenesis()
 	// genesis := helper.RandomBlock(0, 12)
@@ -270,3 +302,117 @@ func setupChainTest(t *testing.T, startAtHeight uint64) (*eventbus.EventBus, *Ch
 	c.ProduceBlock()
 	return eb, c
 }
+
+// ruskStateClient behaves as the Rusk State service: its state can only step
+// the block height up, one block at a time.
+type ruskStateClient struct {
+	rusk.StateClient
+	height   uint64
+	executed []uint64
+}
+
+func (r *ruskStateClient) ExecuteStateTransition(ctx context.Context, in *rusk.ExecuteStateTransitionRequest, opts ...grpc.CallOption) (*rusk.ExecuteStateTransitionResponse, error) {
+	if in.Height != r.height+1 {
+		return nil, fmt.Errorf("state at height %d can not execute block %d", r.height, in.Height)
+	}
+
+	r.height = in.Height
+	r.executed = append(r.executed, in.Height)
+	return &rusk.ExecuteStateTransitionResponse{Success: true}, nil
+}
+
+func (r *ruskStateClient) GetProvisioners(ctx context.Context, in *rusk.GetProvisionersRequest, opts ...grpc.CallOption) (*rusk.GetProvisionersResponse, error) {
+	return &rusk.GetProvisionersResponse{}, nil
+}
+
+// Test that the blocks lost by a crash, while Rusk executed them, are stored
+// again without being executed, against the Rusk executor.
+func TestRecoverState(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+	c.StopBlockProduction()
+
+	// A crash lost block 1, after Rusk executed it
+	state := &ruskStateClient{height: 1}
+	c.proxy = transactions.NewProxy(state, nil, nil, nil, nil, nil, nil, nil, time.Second, time.Second)
+
+	assert.NoError(c.db.Update(func(t database.Transaction) error {
+		return t.StoreExecutedHeight(1)
+	}))
+
+	assert.NoError(c.recoverState())
+	assert.True(c.recovering())
+
+	// The consensus waits for the lost block
+	assert.NoError(c.ProduceBlock())
+
+	blk := mockAcceptableBlock(*c.tip)
+	assert.NoError(c.AcceptBlock(*blk))
+
+	assert.Empty(state.executed)
+	assert.False(c.recovering())
+	assert.Equal(blk.Header.Hash, c.tip.Header.Hash)
+
+	assert.NoError(c.db.View(func(t database.Transaction) error {
+		height, err := t.FetchExecutedHeight()
+		assert.NoError(err)
+		assert.Equal(uint64(1), height)
+		return nil
+	}))
+
+	// Nothing left to recover on the next startup
+	c.executedHeight = 0
+	assert.NoError(c.recoverState())
+	assert.False(c.recovering())
+}
+
+// failingExecutor fails the execution of any state transition.
+type failingExecutor struct {
+	*transactions.PermissiveExecutor
+}
+
+func (failingExecutor) ExecuteStateTransition(context.Context, []transactions.ContractCall, uint64) (user.Provisioners, error) {
+	return user.Provisioners{}, errors.New("state transition failed")
+}
+
+// Test that the executed height is not moved by a failed state transition.
+func TestExecutedHeightOnFailure(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	c.proxy = &transactions.MockProxy{E: failingExecutor{PermissiveExecutor: transactions.MockExecutor(0)}}
+
+	blk := mockAcceptableBlock(*c.tip)
+	assert.Error(c.AcceptBlock(*blk))
+
+	assert.NoError(c.db.View(func(t database.Transaction) error {
+		_, err := t.FetchExecutedHeight()
+		assert.Equal(database.ErrStateNotFound, err)
+		return nil
+	}))
+
+	assert.NoError(c.recoverState())
+}
+
+func TestLoadTipAboveState(t *testing.T) {
+	assert := assert.New(t)
+	_, db := heavy.CreateDBConnection()
+	loader := createLoader(db)
+
+	genesis, err := loader.LoadTip()
+	assert.NoError(err)
+
+	blk := mockAcceptableBlock(*genesis)
+	assert.NoError(loader.Append(blk, nil))
+
+	// A crash lost the chain state update of the last block
+	assert.NoError(loader.Append(genesis, nil))
+
+	tip, err := loader.LoadTip()
+	assert.NoError(err)
+	assert.Equal(blk.Header.Hash, tip.Header.Hash)
+
+	height, err := loader.Height()
+	assert.NoError(err)
+	assert.Equal(uint64(1), height)
+}
@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+)
+
+var errMissingAncestor = errors.New("fork ancestor not found")
+
+// The forkTracker keeps the blocks which do not extend the current chain tip,
+// so that competing branches can be evaluated against the canonical chain.
+// Blocks on the canonical chain live in the database, while the ones on side
+// branches are only kept in memory, up to config.MaxReorgDepth blocks below
+// the tip.
+// NOTE: as for the sequencer, the Chain mutex is expected to guard the
+// tracker during block acceptance.
+type forkTracker struct {
+	lock   sync.RWMutex
+	blocks map[string]block.Block
+}
+
+func newForkTracker() *forkTracker {
+	return &forkTracker{blocks: make(map[string]block.Block)}
+}
+
+// add a block to the tracker. It returns false if the block was already known.
+func (f *forkTracker) add(blk block.Block) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	k := hex.EncodeToString(blk.Header.Hash)
+	if _, ok := f.blocks[k]; ok {
+		return false
+	}
+
+	f.blocks[k] = blk
+	return true
+}
+
+func (f *forkTracker) get(hash []byte) (block.Block, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	blk, ok := f.blocks[hex.EncodeToString(hash)]
+	return blk, ok
+}
+
+func (f *forkTracker) remove(hash []byte) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.blocks, hex.EncodeToString(hash))
+}
+
+// cleanup removes all blocks which are too deep below the chain tip to ever
+// trigger a reorganisation.
+func (f *forkTracker) cleanup(tipHeight uint64) {
+	if tipHeight < config.MaxReorgDepth {
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for k, blk := range f.blocks {
+		if blk.Header.Height <= tipHeight-config.MaxReorgDepth {
+			delete(f.blocks, k)
+		}
+	}
+}
+
+// branch reconstructs the side branch which includes blk. It walks back from
+// blk until an ancestor for which isCanonical returns true is found, and then
+// forward through any tracked descendants. The blocks are returned in
+// ascending height order, together with the hash of the fork point.
+func (f *forkTracker) branch(blk block.Block, isCanonical func(hash []byte) bool) ([]block.Block, []byte, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	blks := []block.Block{blk}
+
+	// Walk back to the fork point
+	for !isCanonical(blks[0].Header.PrevBlockHash) {
+		parent, ok := f.blocks[hex.EncodeToString(blks[0].Header.PrevBlockHash)]
+		if !ok || len(blks) > config.MaxReorgDepth {
+			return nil, nil, errMissingAncestor
+		}
+
+		blks = append([]block.Block{parent}, blks...)
+	}
+
+	// Walk forward, following the preferred child at each height
+	for {
+		var next *block.Block
+
+		for _, child := range f.blocks {
+			child := child
+			if !bytes.Equal(child.Header.PrevBlockHash, blks[len(blks)-1].Header.Hash) {
+				continue
+			}
+
+			if next == nil || preferBlock(child, *next) {
+				next = &child
+			}
+		}
+
+		if next == nil {
+			break
+		}
+
+		blks = append(blks, *next)
+	}
+
+	return blks, blks[0].Header.PrevBlockHash, nil
+}
+
+// preferBranch implements the fork choice rule. Both branches are expected to
+// build on the same fork point, and to be sorted by ascending height. It
+// reports whether the side branch should replace the canonical one.
+//
+// The branch reaching the highest tip is preferred. If both reach the same
+// height, the decision is left to the first block of each branch, as decided
+// by preferBlock.
+func preferBranch(branch, canonical []block.Block) bool {
+	if len(branch) == 0 {
+		return false
+	}
+
+	if len(canonical) == 0 {
+		return true
+	}
+
+	branchTip := branch[len(branch)-1].Header.Height
+	canonicalTip := canonical[len(canonical)-1].Header.Height
+
+	if branchTip != canonicalTip {
+		return branchTip > canonicalTip
+	}
+
+	return preferBlock(branch[0], canonical[0])
+}
+
+// preferBlock reports whether blk a should be preferred over a competing block
+// b at the same height. A block which reached agreement at an earlier step
+// carries more weight, as it required fewer consensus iterations to collect
+// a quorum. Ties are broken by the lowest block hash, so that every node
+// settles on the same block.
+func preferBlock(a, b block.Block) bool {
+	if a.Header.Certificate.Step != b.Header.Certificate.Step {
+		return a.Header.Certificate.Step < b.Header.Certificate.Step
+	}
+
+	return bytes.Compare(a.Header.Hash, b.Header.Hash) < 0
+}
@@ -0,0 +1,232 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	assert "github.com/stretchr/testify/require"
+)
+
+// Test that the fork tracker reconstructs a side branch from any of its
+// blocks, following the preferred child when the branch splits.
+func TestForkTrackerBranch(t *testing.T) {
+	assert := assert.New(t)
+	f := newForkTracker()
+
+	forkPoint := helper.RandomBlock(10, 1)
+	b11 := childBlock(forkPoint, 3)
+	b12 := childBlock(b11, 3)
+	b12bis := childBlock(b11, 1)
+	b13 := childBlock(b12bis, 2)
+
+	for _, blk := range []*block.Block{b11, b12, b12bis, b13} {
+		assert.True(f.add(*blk))
+	}
+
+	assert.False(f.add(*b11))
+
+	isCanonical := func(hash []byte) bool {
+		return bytes.Equal(hash, forkPoint.Header.Hash)
+	}
+
+	branch, forkHash, err := f.branch(*b11, isCanonical)
+	assert.NoError(err)
+	assert.Equal(forkPoint.Header.Hash, forkHash)
+	assert.Len(branch, 3)
+	assert.Equal(b11.Header.Hash, branch[0].Header.Hash)
+	assert.Equal(b12bis.Header.Hash, branch[1].Header.Hash)
+	assert.Equal(b13.Header.Hash, branch[2].Header.Hash)
+
+	// A block whose ancestors are unknown can not be linked
+	orphan := childBlock(helper.RandomBlock(10, 1), 1)
+	assert.True(f.add(*orphan))
+
+	_, _, err = f.branch(*orphan, isCanonical)
+	assert.Equal(errMissingAncestor, err)
+
+	f.remove(orphan.Header.Hash)
+
+	_, ok := f.get(orphan.Header.Hash)
+	assert.False(ok)
+}
+
+// Test the fork choice rule.
+func TestPreferBranch(t *testing.T) {
+	assert := assert.New(t)
+
+	forkPoint := helper.RandomBlock(10, 1)
+	a := childBlock(forkPoint, 3)
+	b := childBlock(forkPoint, 1)
+
+	// Same height, lower step wins
+	assert.True(preferBranch([]block.Block{*b}, []block.Block{*a}))
+	assert.False(preferBranch([]block.Block{*a}, []block.Block{*b}))
+
+	// A longer branch wins regardless of the certificate
+	a2 := childBlock(a, 5)
+	assert.True(preferBranch([]block.Block{*a, *a2}, []block.Block{*b}))
+
+	// Same height and step, lowest hash wins
+	c := childBlock(forkPoint, 1)
+	c.Header.Hash = make([]byte, 32)
+	assert.True(preferBranch([]block.Block{*c}, []block.Block{*b}))
+	assert.False(preferBranch([]block.Block{*b}, []block.Block{*c}))
+
+	// An empty branch never wins
+	assert.False(preferBranch(nil, []block.Block{*a}))
+}
+
+func TestLocatorHeights(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]uint64{0}, locatorHeights(0))
+	assert.Equal([]uint64{2, 1, 0}, locatorHeights(2))
+	assert.Equal([]uint64{10, 9, 8, 6, 2, 0}, locatorHeights(10))
+}
+
+// Test that the chain switches to a competing block which is preferred by the
+// fork choice rule, and ignores the ones which are not.
+func TestReorganisation(t *testing.T) {
+	assert := assert.New(t)
+	eb, c := setupChainTest(t, 0)
+
+	revertedChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.RevertedBlock, eventbus.NewChanListener(revertedChan))
+
+	genesis := *c.tip
+
+	a := mockAcceptableBlock(genesis)
+	a.Header.Certificate.Step = 3
+	assert.NoError(c.AcceptBlock(*a))
+
+	// A competing block agreed on at a later step should be kept aside
+	late := mockAcceptableBlock(genesis)
+	late.Header.Certificate.Step = 5
+
+	_, err := c.ProcessBlockFromNetwork("", message.New(topics.Block, *late))
+	assert.NoError(err)
+	assert.Equal(a.Header.Hash, c.tip.Header.Hash)
+
+	// While one agreed on at an earlier step should replace the tip
+	early := mockAcceptableBlock(genesis)
+	early.Header.Certificate.Step = 1
+
+	_, err = c.ProcessBlockFromNetwork("", message.New(topics.Block, *early))
+	assert.NoError(err)
+	assert.Equal(early.Header.Hash, c.tip.Header.Hash)
+
+	_, err = c.canonicalHeight(a.Header.Hash)
+	assert.Equal(database.ErrBlockNotFound, err)
+
+	// The reverted block is notified
+	m := <-revertedChan
+	assert.Equal(a.Header.Hash, m.Payload().(block.Block).Header.Hash)
+
+	// ...and kept in the fork tracker, should its branch grow
+	_, ok := c.forks.get(a.Header.Hash)
+	assert.True(ok)
+}
+
+// revertlessExecutor can not revert the global state, as Rusk.
+type revertlessExecutor struct {
+	*transactions.PermissiveExecutor
+}
+
+func (revertlessExecutor) RevertState(context.Context, uint64) error {
+	return transactions.ErrStateRevertUnsupported
+}
+
+// Test that the chain keeps its canonical branch, and reports the refused
+// reorganisation, when the Rusk state can not be reverted.
+func TestReorganisationWithoutStateRevert(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	proxy := c.proxy.(*transactions.MockProxy)
+	proxy.E = revertlessExecutor{PermissiveExecutor: transactions.MockExecutor(0)}
+
+	genesis := *c.tip
+
+	a := mockAcceptableBlock(genesis)
+	a.Header.Certificate.Step = 3
+	assert.NoError(c.AcceptBlock(*a))
+
+	early := mockAcceptableBlock(genesis)
+	early.Header.Certificate.Step = 1
+
+	_, err := c.ProcessBlockFromNetwork("", message.New(topics.Block, *early))
+	assert.True(errors.Is(err, ErrReorgUnsupported))
+	assert.Equal(a.Header.Hash, c.tip.Header.Hash)
+
+	height, err := c.canonicalHeight(a.Header.Hash)
+	assert.NoError(err)
+	assert.Equal(a.Header.Height, height)
+}
+
+func childBlock(parent *block.Block, step uint8) *block.Block {
+	blk := helper.RandomBlock(parent.Header.Height+1, 1)
+	blk.Header.PrevBlockHash = parent.Header.Hash
+	blk.Header.Certificate = block.EmptyCertificate()
+	blk.Header.Certificate.Step = step
+	return blk
+}
+
+// Test that reverting a block subtracts the participation recorded with it,
+// without the provisioners it was counted against.
+func TestRevertParticipation(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 0)
+
+	genesis := *c.tip
+
+	a := mockAcceptableBlock(genesis)
+	assert.NoError(c.AcceptBlock(*a))
+
+	// Bypass the certificate verification, which needs a real committee
+	pk, _ := crypto.RandEntropy(129)
+	recorded := []database.Participation{{PubKeyBLS: pk, Expected: 2, Cast: 1, Missed: 1}}
+
+	b := childBlock(a, 1)
+	assert.NoError(c.loader.Append(b, func(t database.Transaction) error {
+		if err := t.StoreBlockParticipation(b.Header.Hash, recorded); err != nil {
+			return err
+		}
+
+		return t.StoreParticipation(recorded[0])
+	}))
+
+	c.tip = b
+	c.recordProvisioners()
+
+	delete(c.pHistory, a.Header.Height)
+
+	_, err := c.revertTo(genesis.Header.Height)
+	assert.NoError(err)
+	assert.Equal(genesis.Header.Hash, c.tip.Header.Hash)
+
+	assert.NoError(c.db.View(func(t database.Transaction) error {
+		stored, err := t.FetchParticipation(pk)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(database.Participation{PubKeyBLS: pk}, stored)
+		return nil
+	}))
+}
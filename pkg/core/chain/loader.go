@@ -78,13 +78,58 @@ func (l *DBLoader) Height() (uint64, error) {
 	return height, err
 }
 
-// Append stores a block in the DB.
-func (l *DBLoader) Append(blk *block.Block) error {
+// Append stores a block in the DB, and runs update in the same transaction.
+func (l *DBLoader) Append(blk *block.Block, update func(database.Transaction) error) error {
 	return l.db.Update(func(t database.Transaction) error {
-		return t.StoreBlock(blk)
+		if err := t.StoreBlock(blk); err != nil {
+			return err
+		}
+
+		if update == nil {
+			return nil
+		}
+
+		return update(t)
 	})
 }
 
+// Revert runs update and deletes the chain tip from the DB in the same
+// transaction, and returns the block which becomes the new tip. update runs
+// first, so that it can still read the records deleted along with the block.
+func (l *DBLoader) Revert(update func(database.Transaction) error) (*block.Block, error) {
+	err := l.db.Update(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		if err != nil {
+			return err
+		}
+
+		if update != nil {
+			if err := update(t); err != nil {
+				return err
+			}
+		}
+
+		return t.DeleteBlock(s.TipHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tip *block.Block
+
+	err = l.db.View(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		if err != nil {
+			return err
+		}
+
+		tip, err = t.FetchBlock(s.TipHash)
+		return err
+	})
+
+	return tip, err
+}
+
 // BlockAt returns the block stored at a given height.
 func (l *DBLoader) BlockAt(searchingHeight uint64) (block.Block, error) {
 	var blk *block.Block
@@ -225,22 +270,31 @@ func (l *DBLoader) LoadTip() (*block.Block, error) {
 		return nil, err
 	}
 
-	// Verify chain state. There shouldn't be any blocks higher than chainTip
-	err = l.db.View(func(t database.Transaction) error {
-		nextHeight := tip.Header.Height + 1
+	// Verify chain state. There shouldn't be any blocks higher than chainTip,
+	// unless a crash lost the update of the chain state. The chain tip is
+	// then moved onto them, as long as they chain to it.
+	err = l.db.Update(func(t database.Transaction) error {
+		for {
+			nextHeight := tip.Header.Height + 1
 
-		hash, e := t.FetchBlockHashByHeight(nextHeight)
-		// Check if error is nil and the hash is set
-		if e == nil && len(hash) > 0 {
-			return fmt.Errorf("state points at %d height but the tip is higher", tip.Header.Height)
-		}
-		// TODO: this throws ErrBlockNotFound in tests. Should we propagate the
-		// error?
-		//if e != nil {
-		//	return e
-		//}
+			hash, e := t.FetchBlockHashByHeight(nextHeight)
+			if e != nil || len(hash) == 0 {
+				return nil
+			}
 
-		return nil
+			next, e := t.FetchBlock(hash)
+			if e != nil || !bytes.Equal(next.Header.PrevBlockHash, tip.Header.Hash) {
+				return fmt.Errorf("state points at %d height but the tip is higher", tip.Header.Height)
+			}
+
+			if e := t.StoreBlock(next); e != nil {
+				return e
+			}
+
+			log.WithField("height", nextHeight).Warn("chain tip moved onto a block above the chain state")
+
+			tip = next
+		}
 	})
 
 	if err != nil {
@@ -7,7 +7,10 @@
 package chain
 
 import (
+	"errors"
+
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 )
 
 // MockVerifier is a mock for the chain.Verifier interface.
@@ -59,12 +62,24 @@ func (m *MockLoader) Close(driver string) error {
 	return nil
 }
 
-// Append the block to the internal blockchain representation.
-func (m *MockLoader) Append(blk *block.Block) error {
+// Append the block to the internal blockchain representation. The update
+// function is not run, as the mock has no storage.
+func (m *MockLoader) Append(blk *block.Block, _ func(database.Transaction) error) error {
 	m.blockchain = append(m.blockchain, *blk)
 	return nil
 }
 
+// Revert removes the last block from the internal blockchain representation.
+// The update function is not run, as the mock has no storage.
+func (m *MockLoader) Revert(_ func(database.Transaction) error) (*block.Block, error) {
+	if len(m.blockchain) < 2 {
+		return nil, errors.New("cannot revert genesis")
+	}
+
+	m.blockchain = m.blockchain[:len(m.blockchain)-1]
+	return &m.blockchain[len(m.blockchain)-1], nil
+}
+
 // BlockAt the block to the internal blockchain representation.
 func (m *MockLoader) BlockAt(index uint64) (block.Block, error) {
 	return m.blockchain[index], nil
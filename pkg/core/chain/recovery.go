@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	logger "github.com/sirupsen/logrus"
+)
+
+// storeExecutedHeight records the height the Rusk state has been moved to,
+// once the state transition of the block is executed, and before the block is
+// stored.
+func (c *Chain) storeExecutedHeight(blk block.Block) error {
+	return c.db.Update(func(t database.Transaction) error {
+		return t.StoreExecutedHeight(blk.Header.Height)
+	})
+}
+
+// recoverState reconciles the Rusk state with the chain tip on startup. A
+// machine crash can lose the most recently stored blocks, while the Rusk
+// state has already executed them. Rusk can not revert its state, so the lost
+// blocks are synced from the network again, and stored without being executed
+// up to the height of the Rusk state (see AcceptBlock). The consensus only
+// resumes once the chain tip is back at that height. Meanwhile, the
+// certificates of these blocks are verified against the provisioners of the
+// Rusk state, as the sets at their heights are lost with them.
+func (c *Chain) recoverState() error {
+	var executed uint64
+
+	err := c.db.View(func(t database.Transaction) error {
+		var err error
+		executed, err = t.FetchExecutedHeight()
+		return err
+	})
+
+	if err == database.ErrStateNotFound {
+		// Nothing executed yet
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if executed <= c.tip.Header.Height {
+		return nil
+	}
+
+	log.WithFields(logger.Fields{
+		"process":         "recovery",
+		"tip_height":      c.tip.Header.Height,
+		"executed_height": executed,
+	}).Warn("chain tip behind the Rusk state, syncing the blocks already executed")
+
+	c.executedHeight = executed
+	return nil
+}
+
+// recovering returns true while the chain tip is behind the Rusk state found
+// on startup.
+func (c *Chain) recovering() bool {
+	return c.tip.Header.Height < c.executedHeight
+}
@@ -0,0 +1,322 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+	logger "github.com/sirupsen/logrus"
+)
+
+// processForkBlock handles a block which does not extend the current chain
+// tip. The block is kept aside in the fork tracker and, if the branch it
+// belongs to is preferred by the fork choice rule, the chain is reorganised
+// on top of it.
+func (c *Chain) processForkBlock(blk block.Block) error {
+	l := log.WithField("blk_height", blk.Header.Height).
+		WithField("blk_hash", hex.EncodeToString(blk.Header.Hash)).
+		WithField("tip_height", c.tip.Header.Height)
+
+	if blk.Header.Height+config.MaxReorgDepth <= c.tip.Header.Height {
+		l.Debug("discarded block from the past")
+		return nil
+	}
+
+	// Is it a block we already have on the canonical chain?
+	if _, err := c.canonicalHeight(blk.Header.Hash); err == nil {
+		l.Debug("discarded known block")
+		return nil
+	}
+
+	c.forks.cleanup(c.tip.Header.Height)
+
+	if !c.forks.add(blk) {
+		return nil
+	}
+
+	branch, forkHash, err := c.forks.branch(blk, func(hash []byte) bool {
+		_, e := c.canonicalHeight(hash)
+		return e == nil
+	})
+	if err != nil {
+		// Most likely we still miss a parent. The block is kept around, in
+		// case it arrives later on.
+		l.WithError(err).Debug("fork block not linked to the chain")
+		return nil
+	}
+
+	forkHeight, err := c.canonicalHeight(forkHash)
+	if err != nil {
+		return err
+	}
+
+	canonical, err := c.canonicalBranch(forkHeight)
+	if err != nil {
+		return err
+	}
+
+	if !preferBranch(branch, canonical) {
+		l.WithField("fork_height", forkHeight).Debug("keeping canonical branch")
+		return nil
+	}
+
+	return c.reorganise(forkHeight, branch)
+}
+
+// ErrReorgUnsupported is returned when the chain should switch to a
+// preferred fork branch, but the Rusk state can not be reverted to the fork
+// height. Rusk does not support state reverts, so a reorganisation can only
+// succeed against an Executor which does.
+var ErrReorgUnsupported = errors.New("chain reorganisation unsupported by the Rusk state")
+
+// reorganise the chain so that the side branch, built on the block at
+// forkHeight, becomes the canonical one. If any block of the branch turns
+// out to be invalid, the previous canonical blocks are restored.
+//
+// The Rusk state is reverted to forkHeight (see revertTo), and then moved
+// onto the new branch by executing the state transition of each of its
+// blocks. If the Rusk state can not be reverted, the chain is left untouched
+// and ErrReorgUnsupported is returned.
+func (c *Chain) reorganise(forkHeight uint64, branch []block.Block) error {
+	l := log.WithFields(logger.Fields{
+		"process":     "reorg",
+		"fork_height": forkHeight,
+		"tip_height":  c.tip.Header.Height,
+		"new_height":  branch[len(branch)-1].Header.Height,
+	})
+
+	p, ok := c.pHistory[forkHeight]
+	if !ok {
+		return fmt.Errorf("provisioners at height %d are not available", forkHeight)
+	}
+
+	// Avoid reverting the chain for a branch which can not even be accepted
+	if err := verifiers.CheckBlockCertificate(*p, branch[0]); err != nil {
+		l.WithError(err).Warn("fork certificate verification failed")
+		c.forks.remove(branch[0].Header.Hash)
+		return &invalidBlockError{hash: branch[0].Header.Hash, err: err}
+	}
+
+	l.Info("reorganising chain")
+
+	c.StopBlockProduction()
+
+	reverted, err := c.revertTo(forkHeight)
+	if errors.Is(err, transactions.ErrStateRevertUnsupported) {
+		// Rusk only steps its state forward: the node can not follow the
+		// preferred branch and stays on one the network is leaving.
+		l.WithError(err).Error("reorganisation refused, the node needs to be resynced from scratch to follow the network")
+
+		if resumeErr := c.resumeBlockProduction(); resumeErr != nil {
+			return resumeErr
+		}
+
+		return fmt.Errorf("%w: %v", ErrReorgUnsupported, err)
+	}
+
+	if err != nil {
+		l.WithError(err).Error("reverting chain failed")
+		return err
+	}
+
+	var acceptErr error
+
+	for _, blk := range branch {
+		if acceptErr = c.AcceptBlock(blk); acceptErr != nil {
+			c.forks.remove(blk.Header.Hash)
+			break
+		}
+
+		c.forks.remove(blk.Header.Hash)
+	}
+
+	if acceptErr != nil {
+		l.WithError(acceptErr).Warn("fork branch rejected, restoring canonical chain")
+
+		if err = c.restore(forkHeight, reverted); err != nil {
+			l.WithError(err).Error("restoring canonical chain failed")
+			return err
+		}
+	} else {
+		c.notifyReverted(reverted, branch)
+	}
+
+	if err = c.resumeBlockProduction(); err != nil {
+		return err
+	}
+
+	if acceptErr == nil {
+		l.WithField("reverted", len(reverted)).Info("chain reorganised")
+	}
+
+	return acceptErr
+}
+
+// resumeBlockProduction restarts the consensus stopped for a reorganisation,
+// unless the node is syncing.
+func (c *Chain) resumeBlockProduction() error {
+	if c.syncing {
+		return nil
+	}
+
+	return c.ProduceBlock()
+}
+
+// revertTo removes all the blocks above height from the storage and brings
+// the Rusk state, the chain tip and the provisioners set back to the state
+// they had at that height. The Rusk state is reverted first: if it fails,
+// nothing is changed. The reverted blocks are kept in the fork tracker and
+// returned in ascending height order.
+func (c *Chain) revertTo(height uint64) ([]block.Block, error) {
+	p, ok := c.pHistory[height]
+	if !ok {
+		return nil, fmt.Errorf("provisioners at height %d are not available", height)
+	}
+
+	if err := c.proxy.Executor().RevertState(c.ctx, height); err != nil {
+		return nil, fmt.Errorf("reverting the Rusk state to height %d: %w", height, err)
+	}
+
+	// The blocks above height have to be executed again
+	if c.executedHeight > height {
+		c.executedHeight = height
+	}
+
+	reverted := make([]block.Block, 0)
+
+	for c.tip.Header.Height > height {
+		blk := *c.tip
+
+		tip, err := c.loader.Revert(func(t database.Transaction) error {
+			return participation.Revert(t, blk.Header.Hash)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		delete(c.pHistory, blk.Header.Height)
+		c.forks.add(blk)
+		c.tip = tip
+
+		reverted = append([]block.Block{blk}, reverted...)
+	}
+
+	c.p = p
+	return reverted, nil
+}
+
+// restore the canonical blocks reverted on a failed reorganisation.
+func (c *Chain) restore(forkHeight uint64, reverted []block.Block) error {
+	if _, err := c.revertTo(forkHeight); err != nil {
+		return err
+	}
+
+	for _, blk := range reverted {
+		if err := c.AcceptBlock(blk); err != nil {
+			return err
+		}
+
+		c.forks.remove(blk.Header.Hash)
+	}
+
+	return nil
+}
+
+// notifyReverted publishes the reverted blocks, so that their transactions
+// are given back to the mempool. Transactions which are also part of the new
+// canonical branch are left out.
+func (c *Chain) notifyReverted(reverted, branch []block.Block) {
+	included := make(map[string]struct{})
+
+	for _, blk := range branch {
+		for _, tx := range blk.Txs {
+			if txid, err := tx.CalculateHash(); err == nil {
+				included[hex.EncodeToString(txid)] = struct{}{}
+			}
+		}
+	}
+
+	for _, blk := range reverted {
+		b := block.Block{Header: blk.Header}
+
+		for _, tx := range blk.Txs {
+			txid, err := tx.CalculateHash()
+			if err != nil {
+				continue
+			}
+
+			if _, ok := included[hex.EncodeToString(txid)]; !ok {
+				b.Txs = append(b.Txs, tx)
+			}
+		}
+
+		// Subsystems listening for this topic:
+		// mempool.Mempool
+		msg := message.New(topics.RevertedBlock, b)
+		errList := c.eventBus.Publish(topics.RevertedBlock, msg)
+
+		diagnostics.LogPublishErrors("chain/reorg.go, topics.RevertedBlock", errList)
+	}
+}
+
+// canonicalBranch returns the blocks of the canonical chain which follow
+// the block at height.
+func (c *Chain) canonicalBranch(height uint64) ([]block.Block, error) {
+	blks := make([]block.Block, 0, c.tip.Header.Height-height)
+
+	for h := height + 1; h <= c.tip.Header.Height; h++ {
+		blk, err := c.loader.BlockAt(h)
+		if err != nil {
+			return nil, err
+		}
+
+		blks = append(blks, blk)
+	}
+
+	return blks, nil
+}
+
+// canonicalHeight returns the height of a block stored on the canonical chain.
+func (c *Chain) canonicalHeight(hash []byte) (uint64, error) {
+	var height uint64
+
+	err := c.db.View(func(t database.Transaction) error {
+		header, err := t.FetchBlockHeader(hash)
+		if err != nil {
+			return err
+		}
+
+		height = header.Height
+		return nil
+	})
+
+	return height, err
+}
+
+// recordProvisioners stores the current provisioners set at the tip height,
+// and forgets about the sets too old to be used on a reorganisation.
+func (c *Chain) recordProvisioners() {
+	height := c.tip.Header.Height
+	c.pHistory[height] = c.p
+
+	for h := range c.pHistory {
+		if h+config.MaxReorgDepth < height {
+			delete(c.pHistory, h)
+		}
+	}
+}
@@ -41,6 +41,7 @@ func (s *synchronizer) inSync(srcPeerAddr string, currentHeight uint64, blk bloc
 		log.WithField("state", "outSync").Traceln("change sync state")
 
 		s.state = s.outSync
+		s.syncing = true
 		b, err := s.startSync(srcPeerAddr, blk.Header.Height, currentHeight, kadcastHeight)
 		return b, err
 	}
@@ -100,6 +101,7 @@ func (s *synchronizer) outSync(srcPeerAddr string, currentHeight uint64, blk blo
 			log.WithField("state", "inSync").Traceln("change sync state")
 
 			s.state = s.inSync
+			s.syncing = false
 		}
 	}
 
@@ -117,6 +119,9 @@ type synchronizer struct {
 	chain      Ledger
 	syncTarget uint64
 
+	// syncing is true while in outSync state.
+	syncing bool
+
 	timer *outSyncTimer
 }
 
@@ -156,20 +161,52 @@ func (s *synchronizer) startSync(strPeerAddr string, tipHeight, currentHeight ui
 		WithField("src_addr", strPeerAddr).
 		Info("Start syncing")
 
-	var hash []byte
+	var locators [][]byte
 
 	if err := s.db.View(func(t database.Transaction) error {
-		var err error
-		hash, err = t.FetchBlockHashByHeight(currentHeight)
-		return err
+		for _, height := range locatorHeights(currentHeight) {
+			hash, err := t.FetchBlockHashByHeight(height)
+			if err != nil {
+				return err
+			}
+
+			locators = append(locators, hash)
+		}
+
+		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	msgGetBlocks := createGetBlocksMsg(hash)
+	msgGetBlocks := createGetBlocksMsg(locators)
 	return marshalGetBlocks(msgGetBlocks)
 }
 
+// locatorHeights returns the heights of the blocks to be used as locators,
+// starting from currentHeight. The distance between them doubles at each
+// step, so that a peer on a different branch can find our most recent common
+// block within a few locators. The genesis block is always the last one.
+func locatorHeights(currentHeight uint64) []uint64 {
+	heights := make([]uint64, 0)
+	step := uint64(1)
+
+	for height := currentHeight; height > 0; {
+		heights = append(heights, height)
+
+		if len(heights) > 2 {
+			step *= 2
+		}
+
+		if height < step {
+			break
+		}
+
+		height -= step
+	}
+
+	return append(heights, 0)
+}
+
 func (s *synchronizer) setSyncTarget(tipHeight, maxHeight uint64) {
 	s.syncTarget = tipHeight
 	if tipHeight > maxHeight {
@@ -177,9 +214,9 @@ func (s *synchronizer) setSyncTarget(tipHeight, maxHeight uint64) {
 	}
 }
 
-func createGetBlocksMsg(latestHash []byte) *message.GetBlocks {
+func createGetBlocksMsg(locators [][]byte) *message.GetBlocks {
 	msg := &message.GetBlocks{}
-	msg.Locators = append(msg.Locators, latestHash)
+	msg.Locators = append(msg.Locators, locators...)
 	return msg
 }
 
@@ -20,7 +20,10 @@ type Accumulator struct {
 	verificationChan   chan message.Agreement
 	eventChan          chan message.Agreement
 	CollectedVotesChan chan []message.Agreement
-	store              *store
+	// EvidenceChan carries the Evidence of the equivocations detected among
+	// the verified Agreements.
+	EvidenceChan chan message.Evidence
+	store        *store
 
 	workersQuitChan chan struct{}
 }
@@ -33,6 +36,7 @@ func newAccumulator(handler Handler, workerAmount int) *Accumulator {
 		verificationChan:   make(chan message.Agreement, 100),
 		eventChan:          make(chan message.Agreement, 100),
 		CollectedVotesChan: make(chan []message.Agreement, 1),
+		EvidenceChan:       make(chan message.Evidence, 10),
 		store:              newStore(),
 		workersQuitChan:    make(chan struct{}),
 	}
@@ -63,6 +67,19 @@ func (a *Accumulator) Accumulate() {
 		// FIXME: republish here to avoid race conditions for slower but safer
 		// re-propagation
 		hdr := ev.State()
+
+		// An Agreement conflicting with a previous Agreement of the same
+		// provisioner is reported for slashing, and not accumulated
+		if e := a.handler.Equivocation(ev); e != nil {
+			select {
+			case a.EvidenceChan <- *e:
+			default:
+				lg.Warnln("accumulator skipped sending evidence")
+			}
+
+			continue
+		}
+
 		collected := a.store.Get(hdr.Step)
 		weight := a.handler.VotesFor(hdr.PubKeyBLS, hdr.Round, hdr.Step)
 
@@ -57,6 +57,10 @@ func (m *MockHandler) Verify(ev message.Agreement) error {
 	return nil
 }
 
+func (m *MockHandler) Equivocation(message.Agreement) *message.Evidence {
+	return nil
+}
+
 func TestAccumulatorStop(t *testing.T) {
 	hdlr := &MockHandler{true, true, user.VotingCommittee{}, 2, true}
 
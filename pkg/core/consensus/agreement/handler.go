@@ -16,16 +16,13 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-crypto/bls"
 )
 
-// MaxCommitteeSize represents the maximum size of the committee for an
-// Agreement quorum.
-const MaxCommitteeSize = 64
-
 // Handler interface is handy for tests.
 type Handler interface {
 	AmMember(uint64, uint8) bool
@@ -34,45 +31,48 @@ type Handler interface {
 	Quorum(uint64) int
 	VotesFor([]byte, uint64, uint8) int
 	Verify(message.Agreement) error
+	Equivocation(message.Agreement) *message.Evidence
 }
 
 type handler struct {
 	*committee.Handler
+	detector *slashing.Detector
 }
 
 // NewHandler returns an initialized handler.
 //nolint:golint
 func NewHandler(keys key.Keys, p user.Provisioners) *handler {
 	return &handler{
-		Handler: committee.NewHandler(keys, p),
+		Handler:  committee.NewHandler(keys, p),
+		detector: slashing.NewDetector(),
 	}
 }
 
 // AmMember checks if we are part of the committee.
 func (a *handler) AmMember(round uint64, step uint8) bool {
-	return a.Handler.AmMember(round, step, MaxCommitteeSize)
+	return a.Handler.AmMember(round, step, committee.MaxSize())
 }
 
 // IsMember delegates the committee.Handler to check if a Provisioner is in the
 // committee for a specified round and step.
 func (a *handler) IsMember(pubKeyBLS []byte, round uint64, step uint8) bool {
-	return a.Handler.IsMember(pubKeyBLS, round, step, MaxCommitteeSize)
+	return a.Handler.IsMember(pubKeyBLS, round, step, committee.MaxSize())
 }
 
 // Committee returns a VotingCommittee for a given round and step.
 func (a *handler) Committee(round uint64, step uint8) user.VotingCommittee {
-	return a.Handler.Committee(round, step, MaxCommitteeSize)
+	return a.Handler.Committee(round, step, committee.MaxSize())
 }
 
 // VotesFor delegates embedded committee.Handler to accumulate a vote for a
 // given round.
 func (a *handler) VotesFor(pubKeyBLS []byte, round uint64, step uint8) int {
-	return a.Handler.VotesFor(pubKeyBLS, round, step, MaxCommitteeSize)
+	return a.Handler.VotesFor(pubKeyBLS, round, step, committee.MaxSize())
 }
 
 // Quorum returns the amount of committee members necessary to reach a quorum.
 func (a *handler) Quorum(round uint64) int {
-	return int(math.Ceil(float64(a.CommitteeSize(round, MaxCommitteeSize)) * 0.75))
+	return int(math.Ceil(float64(a.CommitteeSize(round, committee.MaxSize())) * 0.75))
 }
 
 // Verify checks the signature of the set.
@@ -121,6 +121,20 @@ func (a *handler) Verify(ev message.Agreement) error {
 	return nil
 }
 
+// Equivocation checks a verified Agreement against the Agreements previously
+// checked by the handler. It returns the Evidence of an equivocation if the
+// sender already sent an Agreement for another block hash in the same round
+// and step.
+func (a *handler) Equivocation(ev message.Agreement) *message.Evidence {
+	e, err := a.detector.CheckAgreement(ev)
+	if err != nil {
+		lg.WithError(err).Error("could not check agreement for equivocation")
+		return nil
+	}
+
+	return e
+}
+
 func (a *handler) getVoterKeys(ev message.Agreement) ([][]byte, error) {
 	hdr := ev.State()
 	keys := make([][]byte, 0)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -72,6 +73,8 @@ func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementCh
 				msg := m.Payload().(message.Agreement)
 				go collectEvent(h, acc, msg, s.Emitter)
 			}
+		case e := <-acc.EvidenceChan:
+			slashing.Report(s.EventBus, e)
 		case evs := <-acc.CollectedVotesChan:
 			lg.
 				WithField("round", r.Round).
@@ -112,7 +115,17 @@ func (s *Loop) shouldCollectNow(a message.Message, round uint64, queue *consensu
 				"coordinator_round": round,
 			}).
 			Debugln("storing future round for later")
-		queue.PutEvent(hdr.Round, hdr.Step, a)
+
+		if !queue.PutEvent(hdr.Round, hdr.Step, a) {
+			lg.
+				WithFields(log.Fields{
+					"topic": "Agreement",
+					"round": hdr.Round,
+					"step":  hdr.Step,
+				}).
+				Debugln("future agreement dropped by the queue")
+		}
+
 		return false
 	}
 
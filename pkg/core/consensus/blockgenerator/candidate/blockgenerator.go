@@ -13,7 +13,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 
@@ -52,8 +52,8 @@ func New(e *consensus.Emitter, genPubKey *keys.PublicKey) Generator {
 
 func (bg *generator) regenerateCommittee(r consensus.RoundUpdate) [][]byte {
 	size := r.P.SubsetSizeAt(r.Round - 1)
-	if size > agreement.MaxCommitteeSize {
-		size = agreement.MaxCommitteeSize
+	if maxSize := committee.MaxSizeFor(r.LastVersion); size > maxSize {
+		size = maxSize
 	}
 
 	return r.P.CreateVotingCommittee(r.Round-1, r.LastCertificate.Step, size).MemberKeys()
@@ -98,7 +98,7 @@ func (bg *generator) GenerateBlock(round uint64, seed, proof, score, prevBlockHa
 
 	// Construct header
 	h := &block.Header{
-		Version:       0,
+		Version:       committee.BlockVersion(),
 		Timestamp:     time.Now().Unix(),
 		Height:        round,
 		PrevBlockHash: prevBlockHash,
@@ -7,12 +7,15 @@
 package capi
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/asdine/storm/v3/q"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/sirupsen/logrus"
@@ -21,13 +24,15 @@ import (
 var (
 	eventBus *eventbus.EventBus
 	rpcBus   *rpcbus.RPCBus
+	db       database.DB
 	log      = logrus.WithField("package", "capi")
 )
 
 // StartAPI init consensus API pointers.
-func StartAPI(eb *eventbus.EventBus, rb *rpcbus.RPCBus) {
+func StartAPI(eb *eventbus.EventBus, rb *rpcbus.RPCBus, d database.DB) {
 	eventBus = eb
 	rpcBus = rb
+	db = d
 
 	log.
 		WithField("eventBus", eventBus).
@@ -181,6 +186,94 @@ func GetEventQueueStatusHandler(res http.ResponseWriter, req *http.Request) {
 	_, _ = res.Write(b)
 }
 
+// GetEventQueueStatsHandler will return EventQueueStatsJSON json array, with
+// the amount of events queued and dropped by each consensus queue.
+func GetEventQueueStatsHandler(res http.ResponseWriter, req *http.Request) {
+	var stats []EventQueueStatsJSON
+
+	err := GetStormDBInstance().DB.All(&stats)
+	if err != nil {
+		log.WithError(err).Debug("failed to fetch event queue stats")
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var b []byte
+
+	b, err = json.Marshal(stats)
+	if err != nil {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetParticipationHandler will return ParticipationJSON json array. The
+// optional bls_key parameter, hex encoded, selects a single provisioner.
+func GetParticipationHandler(res http.ResponseWriter, req *http.Request) {
+	if db == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var pubKeyBLS []byte
+
+	if keyStr := req.URL.Query().Get("bls_key"); keyStr != "" {
+		var err error
+
+		pubKeyBLS, err = hex.DecodeString(keyStr)
+		if err != nil {
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	log.WithField("bls_key", hex.EncodeToString(pubKeyBLS)).Debug("GetParticipationHandler")
+
+	var all []database.Participation
+
+	err := db.View(func(t database.Transaction) error {
+		if pubKeyBLS == nil {
+			var err error
+			all, err = t.FetchAllParticipation()
+			return err
+		}
+
+		p, err := t.FetchParticipation(pubKeyBLS)
+		all = []database.Participation{p}
+		return err
+	})
+	if err == database.ErrParticipationNotFound {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		log.WithError(err).Error("could not fetch participation")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	participation := make([]ParticipationJSON, len(all))
+	for i, p := range all {
+		participation[i] = ParticipationJSON{
+			PublicKeyBLS: p.PubKeyBLS,
+			Expected:     p.Expected,
+			Cast:         p.Cast,
+			Missed:       p.Missed,
+		}
+	}
+
+	b, err := json.Marshal(participation)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
 // GetP2PLogsHandler will return PeerJSON json.
 func GetP2PLogsHandler(res http.ResponseWriter, req *http.Request) {
 	typeStr := req.URL.Query().Get("type")
@@ -233,3 +326,35 @@ func GetP2PCountHandler(res http.ResponseWriter, req *http.Request) {
 
 	_, _ = res.Write(b)
 }
+
+// GetP2PBansHandler will return the list of the banned peers.
+func GetP2PBansHandler(res http.ResponseWriter, req *http.Request) {
+	var all []BanJSON
+
+	err := GetStormDBInstance().DB.All(&all)
+	if err != nil {
+		log.WithError(err).Debug("failed to fetch bans")
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Expired bans are left in the db until the peer is seen again
+	now := time.Now()
+	bans := make([]BanJSON, 0, len(all))
+
+	for _, ban := range all {
+		if ban.Until.After(now) {
+			bans = append(bans, ban)
+		}
+	}
+
+	var b []byte
+
+	b, err = json.Marshal(bans)
+	if err != nil {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
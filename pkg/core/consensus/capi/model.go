@@ -23,6 +23,18 @@ type EventQueueJSON struct {
 	UpdatedAt time.Time        `json:"updated_at"`
 }
 
+// EventQueueStatsJSON is used as JSON wrapper for the counters of a consensus
+// event queue. There is one entry per queue, updated at the end of each round.
+type EventQueueStatsJSON struct {
+	Queue            string    `storm:"id" json:"queue"`
+	Round            uint64    `json:"round"`
+	Queued           uint64    `json:"queued"`
+	DroppedFarFuture uint64    `json:"dropped_far_future"`
+	DroppedStepFull  uint64    `json:"dropped_step_full"`
+	DroppedDuplicate uint64    `json:"dropped_duplicate"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
 // RoundInfoJSON is used as JSON wrapper for round info fields.
 type RoundInfoJSON struct {
 	ID        int       `storm:"id,increment" json:"id"`
@@ -42,6 +54,14 @@ type PeerJSON struct {
 	LastSeen time.Time `storm:"index" json:"last_seen"`
 }
 
+// BanJSON is used as JSON wrapper for a banned peer.
+type BanJSON struct {
+	Host     string    `storm:"id" json:"host"`
+	Reason   string    `json:"reason"`
+	BannedAt time.Time `json:"banned_at"`
+	Until    time.Time `storm:"index" json:"until"`
+}
+
 // Count is the struct used to return a count for a API service.
 type Count struct {
 	Count int `json:"count"`
@@ -72,3 +92,13 @@ type ProvisionerJSON struct {
 	Set     sortedset.Set `json:"set"`
 	Members []*Member     `json:"members"`
 }
+
+// ParticipationJSON represents the consensus participation of a Provisioner:
+// the seats it had in the reduction committees of the certified blocks, and
+// how many of them voted.
+type ParticipationJSON struct {
+	PublicKeyBLS []byte `json:"bls_key"`
+	Expected     uint64 `json:"expected"`
+	Cast         uint64 `json:"cast"`
+	Missed       uint64 `json:"missed"`
+}
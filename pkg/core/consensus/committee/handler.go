@@ -10,13 +10,52 @@ import (
 	"math"
 	"sync"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 )
 
 // PregenerationAmount is the size of a pregenerated committee.
 var PregenerationAmount uint8 = 8
 
+// DefaultMaxSize is the default maximum size of the voting committees.
+const DefaultMaxSize = 64
+
+// MaxSize returns the maximum size of the voting committees of the Reduction
+// and Agreement phases, set with consensus.maxcommitteesize. All the nodes of
+// a network must use the same value.
+func MaxSize() int {
+	if size := cfg.Get().Consensus.MaxCommitteeSize; size > 0 {
+		return size
+	}
+
+	return DefaultMaxSize
+}
+
+// MaxSizeFor returns the maximum size of the committees certifying a block of
+// the given version. Legacy blocks hold the committee bitsets in 64 bits, so
+// their committees never exceed DefaultMaxSize, whatever the configuration.
+func MaxSizeFor(version uint8) int {
+	if version == block.LegacyVersion && MaxSize() > DefaultMaxSize {
+		return DefaultMaxSize
+	}
+
+	return MaxSize()
+}
+
+// BlockVersion returns the version of the blocks certified by committees of
+// the configured maximum size. Legacy blocks are produced as long as the
+// committee bitsets fit 64 bits, so that raising consensus.maxcommitteesize
+// above DefaultMaxSize is what activates the later versions.
+func BlockVersion() uint8 {
+	if MaxSize() > DefaultMaxSize {
+		return block.CurrentVersion
+	}
+
+	return block.LegacyVersion
+}
+
 // Handler is injected in the consensus components that work with the various
 // committee. It generates and maintains a list of active and valid committee members and
 // handle the votes.
@@ -9,8 +9,10 @@ package committee_test
 import (
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,3 +25,24 @@ func TestStep0Committee(t *testing.T) {
 		h.AmMember(1, 0, 10)
 	})
 }
+
+// Test that legacy blocks keep committees of 64 members at most, and that they
+// are produced until the configured committees outgrow 64 bits.
+func TestMaxSizeForVersion(t *testing.T) {
+	r := config.Get()
+	defer config.Mock(&r)
+
+	c := r
+	c.Consensus.MaxCommitteeSize = 128
+	config.Mock(&c)
+
+	assert.Equal(t, committee.DefaultMaxSize, committee.MaxSizeFor(block.LegacyVersion))
+	assert.Equal(t, 128, committee.MaxSizeFor(block.CurrentVersion))
+	assert.Equal(t, block.CurrentVersion, committee.BlockVersion())
+
+	c.Consensus.MaxCommitteeSize = 32
+	config.Mock(&c)
+
+	assert.Equal(t, 32, committee.MaxSizeFor(block.LegacyVersion))
+	assert.Equal(t, block.LegacyVersion, committee.BlockVersion())
+}
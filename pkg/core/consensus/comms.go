@@ -55,6 +55,8 @@ type (
 		Seed            []byte
 		Hash            []byte
 		LastCertificate *block.Certificate
+		// LastVersion is the version of the block at the previous round.
+		LastVersion uint8
 	}
 
 	// InternalPacket is a specialization of the Payload of message.Message. It is used to
@@ -99,6 +101,17 @@ func InitAcceptedBlockUpdate(subscriber eventbus.Subscriber) (chan block.Block,
 	return acceptedBlockChan, id
 }
 
+// InitRevertedBlockUpdate init listener to get updates about blocks removed
+// from the chain on a reorganisation.
+func InitRevertedBlockUpdate(subscriber eventbus.Subscriber) (chan block.Block, uint32) {
+	revertedBlockChan := make(chan block.Block, cfg.MaxReorgDepth)
+	collector := &acceptedBlockCollector{revertedBlockChan}
+	collectListener := eventbus.NewSafeCallbackListener(collector.Collect)
+	id := subscriber.Subscribe(topics.RevertedBlock, collectListener)
+
+	return revertedBlockChan, id
+}
+
 // Collect as defined in the EventCollector interface. It reconstructs the bidList and notifies about it.
 func (c *acceptedBlockCollector) Collect(m message.Message) {
 	c.blockChan <- m.Payload().(block.Block)
@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package participation
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+)
+
+// Count decodes the certificate of a block against the committees of its two
+// reduction steps, and returns the seats each provisioner had in them along
+// with the votes it cast. The Provisioners must be the set the certificate
+// is verified against, i.e. the set before the block is accepted.
+//
+// Blocks whose certificate is not verified (see
+// verifiers.CheckBlockCertificate) are not counted.
+func Count(p user.Provisioners, blk block.Block) []database.Participation {
+	if blk.Header.Height < 2 || blk.Header.Certificate == nil {
+		return nil
+	}
+
+	cert := blk.Header.Certificate
+	round := blk.Header.Height
+
+	size := p.SubsetSizeAt(round)
+	if maxSize := committee.MaxSizeFor(blk.Header.Version); size > maxSize {
+		size = maxSize
+	}
+
+	counts := make(map[string]*database.Participation)
+	order := make([]string, 0)
+
+	for _, s := range []struct {
+		step   uint8
+		bitSet sortedset.BitSet
+	}{
+		{cert.Step - 1, cert.StepOneCommittee},
+		{cert.Step, cert.StepTwoCommittee},
+	} {
+		c := p.CreateVotingCommittee(round, s.step, size)
+		voters := c.Intersect(s.bitSet)
+
+		for _, member := range c.Set {
+			pubKeyBLS := member.Bytes()
+			seats := uint64(c.OccurrencesOf(pubKeyBLS))
+
+			k := string(pubKeyBLS)
+			if _, ok := counts[k]; !ok {
+				counts[k] = &database.Participation{PubKeyBLS: pubKeyBLS}
+				order = append(order, k)
+			}
+
+			counts[k].Expected += seats
+
+			if _, voted := voters.IndexOf(pubKeyBLS); voted {
+				counts[k].Cast += seats
+			} else {
+				counts[k].Missed += seats
+			}
+		}
+	}
+
+	participation := make([]database.Participation, len(order))
+	for i, k := range order {
+		participation[i] = *counts[k]
+	}
+
+	return participation
+}
+
+// Record adds the participation in the certificate of an accepted block to
+// the counters stored in the database. It is meant to run in the transaction
+// storing the block, so that the counters never drift from the chain.
+//
+// The participation of the block is stored along with it, as the
+// provisioners it was counted against may not be available when the block
+// is reverted (e.g. by the revert command).
+func Record(t database.Transaction, p user.Provisioners, blk block.Block) error {
+	participation := Count(p, blk)
+
+	if err := t.StoreBlockParticipation(blk.Header.Hash, participation); err != nil {
+		return err
+	}
+
+	return update(t, participation, add)
+}
+
+// Revert subtracts the participation recorded for the block with the given
+// hash from the counters stored in the database. It is meant to run in the
+// transaction deleting the block, before DeleteBlock removes the recorded
+// participation.
+func Revert(t database.Transaction, hash []byte) error {
+	participation, err := t.FetchBlockParticipation(hash)
+	if err == database.ErrParticipationNotFound {
+		// Block accepted by a release not tracking the participation
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return update(t, participation, sub)
+}
+
+func update(t database.Transaction, participation []database.Participation, op func(a, b uint64) uint64) error {
+	for _, delta := range participation {
+		stored, err := t.FetchParticipation(delta.PubKeyBLS)
+		if err == database.ErrParticipationNotFound {
+			stored = database.Participation{PubKeyBLS: delta.PubKeyBLS}
+		} else if err != nil {
+			return err
+		}
+
+		stored.Expected = op(stored.Expected, delta.Expected)
+		stored.Cast = op(stored.Cast, delta.Cast)
+		stored.Missed = op(stored.Missed, delta.Missed)
+
+		if err := t.StoreParticipation(stored); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func add(a, b uint64) uint64 {
+	return a + b
+}
+
+// sub never wraps around, in case the counters were not recorded from the
+// genesis, e.g. for blocks accepted by a release not tracking them.
+func sub(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+
+	return a - b
+}
@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package participation_test
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	height = 5
+	step   = 3
+)
+
+// mockCertifiedBlock returns a block whose certificate holds the votes of
+// the first voters members of the committees of both reduction steps.
+func mockCertifiedBlock(p user.Provisioners, voters int) (block.Block, map[string]bool) {
+	blk := helper.RandomBlock(height, 1)
+	blk.Header.Certificate.Step = step
+
+	voted := make(map[string]bool)
+	bitSets := make([]sortedset.BitSet, 0, 2)
+
+	for _, s := range []uint8{step - 1, step} {
+		c := p.CreateVotingCommittee(height, s, p.SubsetSizeAt(height))
+
+		subset := sortedset.New()
+		for _, member := range c.Set[:voters] {
+			subset.Insert(member.Bytes())
+			voted[string(member.Bytes())] = true
+		}
+
+		bitSets = append(bitSets, c.Bits(subset))
+	}
+
+	blk.Header.Certificate.StepOneCommittee = bitSets[0]
+	blk.Header.Certificate.StepTwoCommittee = bitSets[1]
+
+	return *blk, voted
+}
+
+func TestCount(t *testing.T) {
+	assert := require.New(t)
+
+	p, _ := consensus.MockProvisioners(10)
+	blk, voted := mockCertifiedBlock(*p, 3)
+
+	var expected, cast uint64
+
+	for _, pa := range participation.Count(*p, blk) {
+		assert.Equal(pa.Expected, pa.Cast+pa.Missed)
+
+		if voted[string(pa.PubKeyBLS)] {
+			assert.NotZero(pa.Cast)
+		}
+
+		expected += pa.Expected
+		cast += pa.Cast
+	}
+
+	// Every seat of both committees is accounted for
+	assert.Equal(uint64(2*p.SubsetSizeAt(height)), expected)
+	assert.NotZero(cast)
+	assert.Less(cast, expected)
+
+	// The blocks below height 2 carry no verified certificate
+	blk.Header.Height = 1
+	assert.Empty(participation.Count(*p, blk))
+}
+
+func TestRecordRevert(t *testing.T) {
+	assert := require.New(t)
+
+	_, db := lite.CreateDBConnection()
+	defer func() {
+		_ = db.Close()
+	}()
+
+	p, _ := consensus.MockProvisioners(10)
+	blk, _ := mockCertifiedBlock(*p, 5)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return participation.Record(t, *p, blk)
+		}))
+	}
+
+	counted := participation.Count(*p, blk)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		for _, pa := range counted {
+			stored, err := t.FetchParticipation(pa.PubKeyBLS)
+			if err != nil {
+				return err
+			}
+
+			assert.Equal(2*pa.Expected, stored.Expected)
+			assert.Equal(2*pa.Cast, stored.Cast)
+			assert.Equal(2*pa.Missed, stored.Missed)
+		}
+
+		return nil
+	}))
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return participation.Revert(t, blk.Header.Hash)
+	}))
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		all, err := t.FetchAllParticipation()
+		if err != nil {
+			return err
+		}
+
+		assert.ElementsMatch(counted, all)
+		return nil
+	}))
+}
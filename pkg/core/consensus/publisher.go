@@ -9,10 +9,19 @@ package consensus
 import (
 	"bytes"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 )
 
+// invalidSignatureScore is the misbehaviour score of a peer sending a
+// consensus message whose sender signature is invalid. Such messages are
+// never republished by honest nodes.
+const invalidSignatureScore = 50
+
 // Publisher is used to direct consensus messages from the peer.MessageProcessor
 // to the consensus components.
 type Publisher struct {
@@ -24,9 +33,62 @@ func NewPublisher(publisher eventbus.Publisher) *Publisher {
 	return &Publisher{publisher}
 }
 
-// Process incoming consensus messages.
+// Process incoming consensus messages. Messages whose sender signature is
+// invalid are discarded, and the peer is reported.
 // Satisfies the peer.ProcessorFunc interface.
-func (p *Publisher) Process(srcPeerID string, msg message.Message) ([]bytes.Buffer, error) {
-	p.publisher.Publish(msg.Category(), msg)
+func (p *Publisher) Process(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
+	if err := verifySender(m); err != nil {
+		p.reportMisbehaviour(srcPeerID, "invalid "+m.Category().String()+" signature")
+		return nil, err
+	}
+
+	p.publisher.Publish(m.Category(), m)
 	return nil, nil
 }
+
+// reportMisbehaviour notifies the peer.BanManager about the peer sending an
+// invalid message, as peer.ReportMisbehaviour does. The peer package can not
+// be imported here, as its tests depend on this package.
+func (p *Publisher) reportMisbehaviour(srcPeerID, reason string) {
+	if srcPeerID == "" {
+		return
+	}
+
+	// Subsystems listening for this topic:
+	// peer.Connector
+	r := message.Misbehaviour{Address: srcPeerID, Score: invalidSignatureScore, Reason: reason}
+	errList := p.publisher.Publish(topics.Misbehaviour, message.New(topics.Misbehaviour, r))
+
+	diagnostics.LogPublishErrors("consensus/publisher.go, topics.Misbehaviour", errList)
+}
+
+// verifySender checks the signature of the sender of Reduction and Agreement
+// messages. It does not depend on the committee, which is checked by the
+// consensus components later on.
+func verifySender(m message.Message) error {
+	var (
+		hdr header.Header
+		sig []byte
+	)
+
+	switch ev := m.Payload().(type) {
+	case message.Reduction:
+		hdr, sig = ev.State(), ev.SignedHash
+	case message.Agreement:
+		hdr, sig = ev.State(), ev.SignedVotes()
+	default:
+		return nil
+	}
+
+	r := new(bytes.Buffer)
+	if err := header.MarshalSignableVote(r, hdr); err != nil {
+		return err
+	}
+
+	// The crypto package mutates the signature when decompressing it
+	// see https://github.com/dusk-network/dusk-crypto/issues/16
+	cpy := make([]byte, len(sig))
+	copy(cpy, sig)
+
+	return msg.VerifyBLSSignature(hdr.PubKeyBLS, r.Bytes(), cpy)
+}
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that consensus messages with an invalid sender signature are discarded,
+// and that the peer sending them is reported.
+func TestPublisherInvalidSignature(t *testing.T) {
+	assert := require.New(t)
+	eb := eventbus.New()
+	p := consensus.NewPublisher(eb)
+
+	redChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.Reduction, eventbus.NewChanListener(redChan))
+
+	reportChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.Misbehaviour, eventbus.NewChanListener(reportChan))
+
+	k, err := key.NewRandKeys()
+	assert.NoError(err)
+
+	hash := make([]byte, 32)
+	red := message.MockReduction(hash, 1, 1, []key.Keys{k})
+
+	_, err = p.Process("127.0.0.1:7000", message.New(topics.Reduction, red))
+	assert.NoError(err)
+
+	select {
+	case <-redChan:
+	case <-time.After(time.Second):
+		t.Fatal("valid reduction not published")
+	}
+
+	// A signature for another step does not match the header
+	forged := message.MockReduction(hash, 1, 2, []key.Keys{k})
+	forged.SignedHash = red.SignedHash
+
+	_, err = p.Process("127.0.0.1:7000", message.New(topics.Reduction, forged))
+	assert.Error(err)
+
+	select {
+	case msg := <-reportChan:
+		assert.Equal("127.0.0.1:7000", msg.Payload().(message.Misbehaviour).Address)
+	case <-time.After(time.Second):
+		t.Fatal("peer not reported")
+	}
+
+	select {
+	case <-redChan:
+		t.Fatal("forged reduction published")
+	default:
+	}
+}
@@ -9,15 +9,61 @@ package consensus
 import (
 	"sync"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
 
+const (
+	// DefaultMaxQueueLookahead is the default amount of rounds ahead of the
+	// current one for which events are queued.
+	DefaultMaxQueueLookahead = 10
+	// DefaultMaxQueuedStepEvents is the default maximum amount of events
+	// queued for a single step.
+	DefaultMaxQueuedStepEvents = 1024
+)
+
+// QueueStats counts the events stored and dropped by a Queue.
+type QueueStats struct {
+	// Queued is the amount of events stored.
+	Queued uint64
+	// DroppedFarFuture is the amount of events dropped as their round is
+	// beyond the lookahead window.
+	DroppedFarFuture uint64
+	// DroppedStepFull is the amount of events dropped as their step already
+	// holds the maximum amount of events.
+	DroppedStepFull uint64
+	// DroppedDuplicate is the amount of events dropped as their signer
+	// already has an event of the same kind queued for the step.
+	DroppedDuplicate uint64
+}
+
+// Dropped returns the total amount of dropped events.
+func (s QueueStats) Dropped() uint64 {
+	return s.DroppedFarFuture + s.DroppedStepFull + s.DroppedDuplicate
+}
+
 // Queue is a Queue of Events grouped by rounds and steps. It is thread-safe
 // through a sync.RWMutex.
+//
+// The Queue is bounded, so that peers can not exhaust the memory of the node
+// with events of far future rounds. Events are dropped if their round is
+// beyond the lookahead window (consensus.maxqueuelookahead), if their step
+// is full (consensus.maxqueuedstepevents), or if their signer already has an
+// event of the same kind queued for the step.
+//
+// The Queue follows the consensus round through the calls of GetEvents, Flush
+// and Clear. Until one of them is called, the lookahead window is not
+// enforced.
 // TODO: entries should become buntdb instead.
 type Queue struct {
 	lock    sync.RWMutex
 	entries map[uint64]map[uint8][]message.Message
+	// signers holds the signers of the queued events of each round.
+	signers map[uint64]map[string]struct{}
+	// round is the current consensus round, if known.
+	round      uint64
+	roundKnown bool
+	stats      QueueStats
 }
 
 // NewQueue creates a new Queue. It is primarily used by Collectors to
@@ -26,6 +72,34 @@ func NewQueue() *Queue {
 	entries := make(map[uint64]map[uint8][]message.Message)
 	return &Queue{
 		entries: entries,
+		signers: make(map[uint64]map[string]struct{}),
+	}
+}
+
+// maxQueueLookahead returns the configured lookahead window, in rounds.
+func maxQueueLookahead() uint64 {
+	if l := cfg.Get().Consensus.MaxQueueLookahead; l > 0 {
+		return l
+	}
+
+	return DefaultMaxQueueLookahead
+}
+
+// maxQueuedStepEvents returns the configured maximum amount of events of a
+// step.
+func maxQueuedStepEvents() int {
+	if n := cfg.Get().Consensus.MaxQueuedStepEvents; n > 0 {
+		return n
+	}
+
+	return DefaultMaxQueuedStepEvents
+}
+
+// setRound moves the current round of the Queue forward.
+func (eq *Queue) setRound(round uint64) {
+	if !eq.roundKnown || round > eq.round {
+		eq.round = round
+		eq.roundKnown = true
 	}
 }
 
@@ -34,6 +108,8 @@ func (eq *Queue) GetEvents(round uint64, step uint8) []message.Message {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
+	eq.setRound(round)
+
 	if eq.entries[round][step] != nil {
 		messages := eq.entries[round][step]
 		eq.entries[round][step] = nil
@@ -43,11 +119,35 @@ func (eq *Queue) GetEvents(round uint64, step uint8) []message.Message {
 	return nil
 }
 
-// PutEvent stores an Event at a given round and step.
-func (eq *Queue) PutEvent(round uint64, step uint8, m message.Message) {
+// PutEvent stores an Event at a given round and step. It returns false if the
+// Event was dropped.
+func (eq *Queue) PutEvent(round uint64, step uint8, m message.Message) bool {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
+	if eq.roundKnown && round > eq.round+maxQueueLookahead() {
+		eq.stats.DroppedFarFuture++
+		return false
+	}
+
+	if len(eq.entries[round][step]) >= maxQueuedStepEvents() {
+		eq.stats.DroppedStepFull++
+		return false
+	}
+
+	if id, ok := signerID(step, m); ok {
+		if _, dup := eq.signers[round][id]; dup {
+			eq.stats.DroppedDuplicate++
+			return false
+		}
+
+		if eq.signers[round] == nil {
+			eq.signers[round] = make(map[string]struct{})
+		}
+
+		eq.signers[round][id] = struct{}{}
+	}
+
 	// Initialize the map on this round if it was not yet created
 	if eq.entries[round] == nil {
 		eq.entries[round] = make(map[uint8][]message.Message)
@@ -59,6 +159,27 @@ func (eq *Queue) PutEvent(round uint64, step uint8, m message.Message) {
 	}
 
 	eq.entries[round][step] = append(eq.entries[round][step], m)
+	eq.stats.Queued++
+	return true
+}
+
+// signerID identifies the signer of a consensus event, along with its step
+// and topic. Messages which are not consensus events have no signer.
+func signerID(step uint8, m message.Message) (string, bool) {
+	p, ok := m.Payload().(InternalPacket)
+	if !ok {
+		return "", false
+	}
+
+	return string([]byte{step, byte(m.Category())}) + string(p.State().PubKeyBLS), true
+}
+
+// Stats returns the counters of the stored and dropped events.
+func (eq *Queue) Stats() QueueStats {
+	eq.lock.RLock()
+	defer eq.lock.RUnlock()
+
+	return eq.stats
 }
 
 // Clear the queue. This method swaps the internal `entries` map, to avoid
@@ -67,7 +188,10 @@ func (eq *Queue) Clear(round uint64) {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
+	eq.setRound(round + 1)
+
 	newEntries := make(map[uint64]map[uint8][]message.Message)
+	newSigners := make(map[uint64]map[string]struct{})
 
 	for r := range eq.entries {
 		if r > round {
@@ -83,7 +207,14 @@ func (eq *Queue) Clear(round uint64) {
 		}
 	}
 
+	for r, s := range eq.signers {
+		if r > round {
+			newSigners[r] = s
+		}
+	}
+
 	eq.entries = newEntries
+	eq.signers = newSigners
 }
 
 // Flush all events stored for a specific round from the queue, and return them.
@@ -91,6 +222,8 @@ func (eq *Queue) Flush(round uint64) []message.Message {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
+	eq.setRound(round)
+
 	if eq.entries[round] != nil {
 		events := make([]message.Message, 0)
 		for step, evs := range eq.entries[round] {
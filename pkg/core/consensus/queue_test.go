@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus_test
+
+import (
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/stretchr/testify/require"
+)
+
+func mockReduction(round uint64, step uint8, signer byte) message.Message {
+	hdr := header.New()
+	hdr.Round = round
+	hdr.Step = step
+	hdr.PubKeyBLS[0] = signer
+
+	return message.New(topics.Reduction, *message.NewReduction(hdr))
+}
+
+func TestQueueLookahead(t *testing.T) {
+	assert := require.New(t)
+	q := consensus.NewQueue()
+
+	// The lookahead is not enforced until the queue knows the round
+	assert.True(q.PutEvent(1000, 1, mockReduction(1000, 1, 1)))
+
+	assert.Empty(q.GetEvents(10, 1))
+	assert.True(q.PutEvent(10+consensus.DefaultMaxQueueLookahead, 1, mockReduction(20, 1, 1)))
+	assert.False(q.PutEvent(11+consensus.DefaultMaxQueueLookahead, 1, mockReduction(21, 1, 1)))
+
+	// Clearing the round moves the window forward
+	q.Clear(10)
+	assert.True(q.PutEvent(11+consensus.DefaultMaxQueueLookahead, 1, mockReduction(21, 1, 1)))
+
+	stats := q.Stats()
+	assert.Equal(uint64(3), stats.Queued)
+	assert.Equal(uint64(1), stats.DroppedFarFuture)
+}
+
+func TestQueueDuplicates(t *testing.T) {
+	assert := require.New(t)
+	q := consensus.NewQueue()
+
+	assert.True(q.PutEvent(2, 3, mockReduction(2, 3, 1)))
+	assert.False(q.PutEvent(2, 3, mockReduction(2, 3, 1)))
+
+	// The same signer can vote in other steps and rounds
+	assert.True(q.PutEvent(2, 4, mockReduction(2, 4, 1)))
+	assert.True(q.PutEvent(3, 3, mockReduction(3, 3, 1)))
+
+	// Messages which are not consensus events are never duplicates
+	assert.True(q.PutEvent(2, 3, message.New(topics.Addr, message.Addr{})))
+	assert.True(q.PutEvent(2, 3, message.New(topics.Addr, message.Addr{})))
+
+	assert.Len(q.GetEvents(2, 3), 3)
+	assert.Equal(uint64(1), q.Stats().DroppedDuplicate)
+
+	// Clearing the round forgets its signers
+	q.Clear(1)
+	assert.False(q.PutEvent(3, 3, mockReduction(3, 3, 1)))
+	q.Clear(3)
+	assert.True(q.PutEvent(4, 3, mockReduction(4, 3, 1)))
+}
+
+func TestQueueStepCap(t *testing.T) {
+	assert := require.New(t)
+
+	r := cfg.Get()
+	r.Consensus.MaxQueuedStepEvents = 4
+	cfg.Mock(&r)
+
+	defer func() {
+		r.Consensus.MaxQueuedStepEvents = 0
+		cfg.Mock(&r)
+	}()
+
+	q := consensus.NewQueue()
+
+	for i := byte(0); i < 4; i++ {
+		assert.True(q.PutEvent(2, 3, mockReduction(2, 3, i)))
+	}
+
+	assert.False(q.PutEvent(2, 3, mockReduction(2, 3, 4)))
+	assert.True(q.PutEvent(2, 4, mockReduction(2, 4, 4)))
+
+	stats := q.Stats()
+	assert.Equal(uint64(1), stats.DroppedStepFull)
+	assert.Equal(uint64(1), stats.Dropped())
+	assert.Len(q.Flush(2), 5)
+}
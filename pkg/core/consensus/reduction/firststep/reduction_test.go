@@ -144,3 +144,48 @@ func TestFirstStepReduction(t *testing.T) {
 		})
 	}
 }
+
+// Test that a vote conflicting with a previous vote of the same provisioner is
+// reported, and neither republished nor collected.
+func TestEquivocationNotRepublished(t *testing.T) {
+	round := uint64(1)
+	step := uint8(2)
+	hlp := reduction.NewHelper(50, time.Second)
+
+	gossipChan := make(chan message.Message, 2)
+	hlp.Emitter.EventBus.Subscribe(topics.Gossip, eventbus.NewChanListener(gossipChan))
+
+	evidenceChan := make(chan message.Message, 1)
+	hlp.Emitter.EventBus.Subscribe(topics.SlashingEvidence, eventbus.NewChanListener(evidenceChan))
+
+	_, db := lite.CreateDBConnection()
+	p := New(nil, hlp.Emitter, hlp.ProcessCandidateVerificationRequest, time.Second, db, nil)
+	p.handler = reduction.NewHandler(hlp.Emitter.Keys, *hlp.P)
+	p.aggregator = reduction.NewAggregator(p.handler)
+
+	// find a committee member other than this node
+	idx := 1
+	for !p.handler.IsMember(hlp.ProvisionersKeys[idx].BLSPubKeyBytes, round, step) {
+		idx++
+	}
+
+	hash, err := crypto.RandEntropy(32)
+	require.NoError(t, err)
+
+	conflicting, err := crypto.RandEntropy(32)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.Nil(t, p.collectReduction(ctx, message.MockReduction(hash, round, step, hlp.ProvisionersKeys, idx), round, step))
+	require.Nil(t, p.collectReduction(ctx, message.MockReduction(conflicting, round, step, hlp.ProvisionersKeys, idx), round, step))
+
+	// only the first vote is republished
+	<-gossipChan
+	select {
+	case <-gossipChan:
+		t.Fatal("conflicting vote was republished")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	<-evidenceChan
+}
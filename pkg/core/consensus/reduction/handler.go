@@ -14,17 +14,17 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
 
-const maxCommitteeSize = 64
-
 type (
 	// Handler is responsible for performing operations that need to know
 	// about specific event fields.
 	Handler struct {
 		*committee.Handler
+		detector *slashing.Detector
 	}
 )
 
@@ -32,25 +32,26 @@ type (
 // and an unmarshaller which uses the injected validation function.
 func NewHandler(keys key.Keys, p user.Provisioners) *Handler {
 	return &Handler{
-		Handler: committee.NewHandler(keys, p),
+		Handler:  committee.NewHandler(keys, p),
+		detector: slashing.NewDetector(),
 	}
 }
 
 // AmMember checks if we are part of the committee.
 func (b *Handler) AmMember(round uint64, step uint8) bool {
-	return b.Handler.AmMember(round, step, maxCommitteeSize)
+	return b.Handler.AmMember(round, step, committee.MaxSize())
 }
 
 // IsMember delegates the committee.Handler to check if a BLS public key belongs
 // to a committee for the specified round and step.
 func (b *Handler) IsMember(pubKeyBLS []byte, round uint64, step uint8) bool {
-	return b.Handler.IsMember(pubKeyBLS, round, step, maxCommitteeSize)
+	return b.Handler.IsMember(pubKeyBLS, round, step, committee.MaxSize())
 }
 
 // VotesFor delegates the committee.Handler to accumulate Votes for the
 // specified BLS public key identifying a Provisioner.
 func (b *Handler) VotesFor(pubKeyBLS []byte, round uint64, step uint8) int {
-	return b.Handler.VotesFor(pubKeyBLS, round, step, maxCommitteeSize)
+	return b.Handler.VotesFor(pubKeyBLS, round, step, committee.MaxSize())
 }
 
 // VerifySignature verifies the BLS signature of the Reduction event. Since the
@@ -71,12 +72,25 @@ func (b *Handler) VerifySignature(red message.Reduction) error {
 	return msg.VerifyBLSSignature(hdr.PubKeyBLS, packet.Bytes(), sig)
 }
 
+// Equivocation checks a verified Reduction against the votes previously
+// checked by the Handler. It returns the Evidence of an equivocation if the
+// sender already voted for another block hash in the same round and step.
+func (b *Handler) Equivocation(red message.Reduction) *message.Evidence {
+	e, err := b.detector.CheckReduction(red)
+	if err != nil {
+		lg.WithError(err).Error("could not check reduction for equivocation")
+		return nil
+	}
+
+	return e
+}
+
 // Quorum returns the amount of committee votes to reach a quorum.
 func (b *Handler) Quorum(round uint64) int {
-	return int(math.Ceil(float64(b.CommitteeSize(round, maxCommitteeSize)) * 0.75))
+	return int(math.Ceil(float64(b.CommitteeSize(round, committee.MaxSize())) * 0.75))
 }
 
 // Committee returns a VotingCommittee for a given round and step.
 func (b *Handler) Committee(round uint64, step uint8) user.VotingCommittee {
-	return b.Handler.Committee(round, step, maxCommitteeSize)
+	return b.Handler.Committee(round, step, committee.MaxSize())
 }
@@ -115,7 +115,16 @@ func ShouldProcess(m message.Message, round uint64, step uint8, queue *consensus
 				"expected round": round,
 			}).
 			Debugln("storing future event for later")
-		queue.PutEvent(hdr.Round, hdr.Step, m)
+
+		if !queue.PutEvent(hdr.Round, hdr.Step, m) {
+			lg.
+				WithFields(log.Fields{
+					"topic": m.Category(),
+					"round": hdr.Round,
+					"step":  hdr.Step,
+				}).
+				Debugln("future event dropped by the queue")
+		}
 		return false
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/slashing"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
@@ -168,7 +169,15 @@ func (p *Phase) collectReduction(r message.Reduction, round uint64, step uint8)
 		return nil
 	}
 
-	// Once the event is verified, we can republish it.
+	// A vote conflicting with a previous vote of the same provisioner is
+	// reported for slashing, and neither republished nor collected
+	if e := p.handler.Equivocation(r); e != nil {
+		slashing.Report(p.Emitter.EventBus, *e)
+		return nil
+	}
+
+	// Once the event is verified and consistent with the previous votes of its
+	// sender, we can republish it.
 	if err := p.Emitter.Gossip(message.New(topics.Reduction, r)); err != nil {
 		lg.WithError(err).Error("could not republish reduction event")
 	}
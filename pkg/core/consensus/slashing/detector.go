@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package slashing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+)
+
+// vote is the first vote of a provisioner in a round and step.
+type vote struct {
+	hash   []byte
+	signed []byte
+	// reported is set once the equivocation of the provisioner is reported,
+	// so that it is reported only once.
+	reported bool
+}
+
+// Detector detects the provisioners signing two different block hashes for
+// the same round and step. It keeps the first vote of each provisioner, and
+// should therefore be instantiated by the components collecting the votes
+// of a single round or step. It is safe for concurrent use.
+//
+// The votes passed to the Detector must have been verified, so that the
+// Evidence it returns proves the equivocation.
+type Detector struct {
+	lock  sync.Mutex
+	votes map[string]*vote
+}
+
+// NewDetector returns an empty Detector.
+func NewDetector() *Detector {
+	return &Detector{votes: make(map[string]*vote)}
+}
+
+// CheckReduction records a verified Reduction. It returns the Evidence of an
+// equivocation if the signer already voted for a different block hash in the
+// same round and step.
+func (d *Detector) CheckReduction(r message.Reduction) (*message.Evidence, error) {
+	buf := new(bytes.Buffer)
+	if err := message.MarshalReduction(buf, r); err != nil {
+		return nil, err
+	}
+
+	return d.check(topics.Reduction, r.State(), buf.Bytes()), nil
+}
+
+// CheckAgreement records a verified Agreement. It returns the Evidence of an
+// equivocation if the signer already sent an Agreement for a different block
+// hash in the same round and step.
+func (d *Detector) CheckAgreement(a message.Agreement) (*message.Evidence, error) {
+	buf := new(bytes.Buffer)
+	if err := message.MarshalAgreement(buf, a); err != nil {
+		return nil, err
+	}
+
+	return d.check(topics.Agreement, a.State(), buf.Bytes()), nil
+}
+
+func (d *Detector) check(topic topics.Topic, hdr header.Header, signed []byte) *message.Evidence {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	key := voteKey(topic, hdr)
+
+	first, ok := d.votes[key]
+	if !ok {
+		d.votes[key] = &vote{hash: hdr.BlockHash, signed: signed}
+		return nil
+	}
+
+	if first.reported || bytes.Equal(first.hash, hdr.BlockHash) {
+		return nil
+	}
+
+	first.reported = true
+
+	return &message.Evidence{
+		Topic:     topic,
+		Round:     hdr.Round,
+		Step:      hdr.Step,
+		PubKeyBLS: append([]byte{}, hdr.PubKeyBLS...),
+		First:     first.signed,
+		Second:    signed,
+	}
+}
+
+// voteKey identifies the vote of a provisioner in a round and step.
+func voteKey(topic topics.Topic, hdr header.Header) string {
+	var key [10]byte
+
+	key[0] = byte(topic)
+	key[1] = hdr.Step
+	binary.LittleEndian.PutUint64(key[2:], hdr.Round)
+
+	return string(key[:]) + string(hdr.PubKeyBLS)
+}
+
+// Report publishes the Evidence of an equivocation on
+// topics.SlashingEvidence.
+func Report(publisher eventbus.Publisher, e message.Evidence) {
+	// Subsystems listening for this topic:
+	// slashing.Reporter
+	errList := publisher.Publish(topics.SlashingEvidence, message.New(topics.SlashingEvidence, e))
+
+	diagnostics.LogPublishErrors("slashing/detector.go, topics.SlashingEvidence", errList)
+}
@@ -0,0 +1,105 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package slashing_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/slashing"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectReductionEquivocation(t *testing.T) {
+	assert := require.New(t)
+
+	k, err := key.NewRandKeys()
+	assert.NoError(err)
+
+	keys := []key.Keys{k}
+	hash1, _ := crypto.RandEntropy(32)
+	hash2, _ := crypto.RandEntropy(32)
+
+	d := slashing.NewDetector()
+
+	first := message.MockReduction(hash1, 1, 2, keys)
+	e, err := d.CheckReduction(first)
+	assert.NoError(err)
+	assert.Nil(e)
+
+	// Voting again for the same hash is not an equivocation
+	e, err = d.CheckReduction(first)
+	assert.NoError(err)
+	assert.Nil(e)
+
+	// Voting for the same hash in other steps is not an equivocation
+	e, err = d.CheckReduction(message.MockReduction(hash2, 1, 3, keys))
+	assert.NoError(err)
+	assert.Nil(e)
+
+	second := message.MockReduction(hash2, 1, 2, keys)
+	e, err = d.CheckReduction(second)
+	assert.NoError(err)
+	assert.NotNil(e)
+
+	assert.Equal(topics.Reduction, e.Topic)
+	assert.Equal(uint64(1), e.Round)
+	assert.Equal(uint8(2), e.Step)
+	assert.Equal(k.BLSPubKeyBytes, e.PubKeyBLS)
+
+	// The Evidence holds both signed votes
+	for _, signed := range []struct {
+		buf []byte
+		red message.Reduction
+	}{{e.First, first}, {e.Second, second}} {
+		r := message.NewReduction(first.State())
+		assert.NoError(message.UnmarshalReduction(bytes.NewBuffer(signed.buf), r))
+		assert.Equal(signed.red, *r)
+	}
+
+	// The equivocation is reported only once
+	e, err = d.CheckReduction(message.MockReduction(hash2, 1, 2, keys))
+	assert.NoError(err)
+	assert.Nil(e)
+}
+
+func TestEvidenceUnMarshal(t *testing.T) {
+	assert := require.New(t)
+
+	pk, _ := crypto.RandEntropy(96)
+	first, _ := crypto.RandEntropy(64)
+	second, _ := crypto.RandEntropy(64)
+
+	e := message.Evidence{
+		Topic:     topics.Agreement,
+		Round:     5,
+		Step:      3,
+		PubKeyBLS: pk,
+		First:     first,
+		Second:    second,
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalEvidence(buf, e))
+
+	var e2 message.Evidence
+	assert.NoError(message.UnmarshalEvidence(buf, &e2))
+	assert.Equal(e, e2)
+
+	// The ID does not depend on the votes
+	e2.First, e2.Second = e2.Second, e2.First
+
+	id1, err := e.ID()
+	assert.NoError(err)
+	id2, err := e2.ID()
+	assert.NoError(err)
+	assert.Equal(id1, id2)
+}
@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package slashing
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "slashing")
+
+const (
+	// EvidenceTTL is the amount of rounds after which the evidence of an
+	// equivocation is dropped, if its Slash could not be submitted.
+	EvidenceTTL = 1000
+
+	// evidenceBufferSize is the amount of evidence waiting to be persisted.
+	evidenceBufferSize = 100
+
+	// sendSlashTimeout is the timeout of the Slash submission to the
+	// mempool.
+	sendSlashTimeout = 5 * time.Second
+)
+
+// Reporter punishes the provisioners caught equivocating. It persists the
+// Evidence published on topics.SlashingEvidence, and submits a Slash contract
+// call proving each equivocation to the mempool.
+//
+// The Evidence is deleted once its Slash is submitted. Otherwise, the
+// submission is retried on every accepted block, for EvidenceTTL rounds.
+type Reporter struct {
+	db       database.DB
+	provider transactions.Provider
+	rpcBus   *rpcbus.RPCBus
+
+	evidenceChan chan message.Evidence
+	blockChan    <-chan block.Block
+}
+
+// NewReporter creates a Reporter, listening for Evidence and accepted blocks
+// on the event bus.
+func NewReporter(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, db database.DB, provider transactions.Provider) *Reporter {
+	r := &Reporter{
+		db:           db,
+		provider:     provider,
+		rpcBus:       rpcBus,
+		evidenceChan: make(chan message.Evidence, evidenceBufferSize),
+	}
+
+	eventBus.Subscribe(topics.SlashingEvidence, eventbus.NewCallbackListener(r.collect))
+	r.blockChan, _ = consensus.InitAcceptedBlockUpdate(eventBus)
+
+	return r
+}
+
+func (r *Reporter) collect(m message.Message) {
+	e := m.Payload().(message.Evidence)
+
+	select {
+	case r.evidenceChan <- e:
+	default:
+		lg.WithField("round", e.Round).Warnln("evidence buffer full, evidence dropped")
+	}
+}
+
+// Listen persists the received Evidence and submits the Slash contract
+// calls, until the context is canceled.
+func (r *Reporter) Listen(ctx context.Context) {
+	for {
+		select {
+		case e := <-r.evidenceChan:
+			if err := r.db.Update(func(t database.Transaction) error {
+				return t.StoreEvidence(e)
+			}); err != nil {
+				lg.WithError(err).Error("could not store evidence")
+			}
+
+			r.slash(ctx, e)
+		case blk := <-r.blockChan:
+			r.slashPending(ctx, blk.Header.Height)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// slashPending retries the submission of the persisted Evidence, and drops
+// the Evidence older than EvidenceTTL rounds.
+func (r *Reporter) slashPending(ctx context.Context, height uint64) {
+	var pending []message.Evidence
+
+	err := r.db.View(func(t database.Transaction) error {
+		var err error
+		pending, err = t.FetchEvidence()
+		return err
+	})
+	if err != nil {
+		lg.WithError(err).Error("could not fetch evidence")
+		return
+	}
+
+	for _, e := range pending {
+		if e.Round+EvidenceTTL < height {
+			lg.WithField("round", e.Round).Warnln("evidence expired before its slash could be submitted")
+			r.delete(e)
+			continue
+		}
+
+		r.slash(ctx, e)
+	}
+}
+
+// slash submits the Slash contract call proving an equivocation, and deletes
+// the Evidence on success.
+func (r *Reporter) slash(ctx context.Context, e message.Evidence) {
+	l := lg.WithFields(log.Fields{
+		"topic":  e.Topic,
+		"round":  e.Round,
+		"step":   e.Step,
+		"sender": hex.EncodeToString(e.PubKeyBLS),
+	})
+
+	tx, err := r.provider.NewSlash(ctx, e.PubKeyBLS, e.Round, e.Step, e.First, e.Second)
+	if err != nil {
+		l.WithError(err).Error("could not create slash")
+		return
+	}
+
+	if _, err := r.rpcBus.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), sendSlashTimeout); err != nil {
+		l.WithError(err).Error("could not submit slash")
+		return
+	}
+
+	l.Infoln("slash submitted")
+	r.delete(e)
+}
+
+func (r *Reporter) delete(e message.Evidence) {
+	if err := r.db.Update(func(t database.Transaction) error {
+		return t.DeleteEvidence(e)
+	}); err != nil {
+		lg.WithError(err).Error("could not delete evidence")
+	}
+}
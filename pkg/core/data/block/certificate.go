@@ -15,8 +15,8 @@ type Certificate struct {
 	StepOneBatchedSig []byte `json:"step-one-batched-sig"` // Batched BLS signature of the block reduction phase (33 bytes)
 	StepTwoBatchedSig []byte `json:"step-two-batched-sig"`
 	Step              uint8  `json:"step"`               // Step the agreement terminated at (1 byte)
-	StepOneCommittee  uint64 `json:"step-one-committee"` // Binary representation of the committee members who voted in favor of this block (8 bytes)
-	StepTwoCommittee  uint64 `json:"step-two-committee"`
+	StepOneCommittee  []byte `json:"step-one-committee"` // Bitset of the committee members who voted in favor of this block. See sortedset.BitSet
+	StepTwoCommittee  []byte `json:"step-two-committee"`
 }
 
 // Copy complies with message.Safe interface. It returns a deep copy of
@@ -33,8 +33,16 @@ func (c *Certificate) Copy() *Certificate {
 	}
 
 	cert.Step = c.Step
-	cert.StepOneCommittee = c.StepOneCommittee
-	cert.StepTwoCommittee = c.StepTwoCommittee
+
+	if c.StepOneCommittee != nil {
+		cert.StepOneCommittee = make([]byte, len(c.StepOneCommittee))
+		copy(cert.StepOneCommittee, c.StepOneCommittee)
+	}
+
+	if c.StepTwoCommittee != nil {
+		cert.StepTwoCommittee = make([]byte, len(c.StepTwoCommittee))
+		copy(cert.StepTwoCommittee, c.StepTwoCommittee)
+	}
 
 	return cert
 }
@@ -45,8 +53,6 @@ func EmptyCertificate() *Certificate {
 		StepOneBatchedSig: make([]byte, 33),
 		StepTwoBatchedSig: make([]byte, 33),
 		Step:              0,
-		StepOneCommittee:  0,
-		StepTwoCommittee:  0,
 	}
 }
 
@@ -68,11 +74,11 @@ func (c *Certificate) Equals(other *Certificate) bool {
 		return false
 	}
 
-	if c.StepOneCommittee != other.StepOneCommittee {
+	if !bytes.Equal(c.StepOneCommittee, other.StepOneCommittee) {
 		return false
 	}
 
-	if c.StepTwoCommittee != other.StepTwoCommittee {
+	if !bytes.Equal(c.StepTwoCommittee, other.StepTwoCommittee) {
 		return false
 	}
 
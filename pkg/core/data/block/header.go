@@ -18,6 +18,14 @@ const (
 	HeaderHashSize = 32
 	// HeightSize size of a block height field in bytes.
 	HeightSize = 8
+
+	// LegacyVersion is the version of the blocks whose certificate holds the
+	// committee bitsets as 64 bit integers.
+	LegacyVersion uint8 = 0
+	// CurrentVersion is the version of the blocks certified by committees of
+	// more than 64 members. Their certificate holds variable-length committee
+	// bitsets.
+	CurrentVersion uint8 = 1
 )
 
 // Header defines a block header on a Dusk block.
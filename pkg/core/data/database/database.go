@@ -8,6 +8,7 @@ package database
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -22,7 +23,14 @@ type DB struct {
 	storage *leveldb.DB
 }
 
-var txRecordPrefix = []byte{0x00} // writeOptions = &opt.WriteOptions{NoWriteMerge: false, Sync: true}
+var (
+	txRecordPrefix        = []byte{0x00} // writeOptions = &opt.WriteOptions{NoWriteMerge: false, Sync: true}
+	accountTxRecordPrefix = []byte{0x01}
+)
+
+// defaultAccount is the wallet account whose records are stored under
+// txRecordPrefix, as they were before the wallet had accounts.
+const defaultAccount = uint32(0)
 
 // New creates an instance of DB.
 func New(path string) (*DB, error) {
@@ -54,16 +62,32 @@ func (db *DB) Close() error {
 	return db.storage.Close()
 }
 
-// FetchTxRecords transaction records.
-func (db *DB) FetchTxRecords() ([]txrecords.TxRecord, error) {
+// txRecordKeyPrefix returns the prefix of the transaction records of a
+// wallet account.
+func txRecordKeyPrefix(account uint32) []byte {
+	if account == defaultAccount {
+		return txRecordPrefix
+	}
+
+	prefix := make([]byte, len(accountTxRecordPrefix)+4)
+	copy(prefix, accountTxRecordPrefix)
+	binary.BigEndian.PutUint32(prefix[len(accountTxRecordPrefix):], account)
+
+	return prefix
+}
+
+// FetchTxRecords transaction records of a wallet account.
+func (db *DB) FetchTxRecords(account uint32) ([]txrecords.TxRecord, error) {
 	records := make([]txrecords.TxRecord, 0)
 
-	iter := db.storage.NewIterator(util.BytesPrefix(txRecordPrefix), nil)
+	prefix := txRecordKeyPrefix(account)
+
+	iter := db.storage.NewIterator(util.BytesPrefix(prefix), nil)
 	defer iter.Release()
 
 	for iter.Next() {
 		// record is the key without the prefix
-		val := iter.Key()[1:]
+		val := iter.Key()[len(prefix):]
 
 		bs := make([]byte, len(val))
 		copy(bs[:], val)
@@ -80,11 +104,12 @@ func (db *DB) FetchTxRecords() ([]txrecords.TxRecord, error) {
 	return records, iter.Error()
 }
 
-// PutTxRecord saves the transaction record on the DB.
-func (db *DB) PutTxRecord(tx transactions.ContractCall, height uint64, direction txrecords.Direction) error {
+// PutTxRecord saves the transaction record of a wallet account on the DB.
+func (db *DB) PutTxRecord(account uint32, tx transactions.ContractCall, height uint64, direction txrecords.Direction) error {
 	// Schema
 	//
-	// key: txRecordPrefix + record
+	// key: txRecordPrefix + record (default account)
+	// key: accountTxRecordPrefix + account + record (other accounts)
 	// value: 0
 	buf := new(bytes.Buffer)
 
@@ -94,7 +119,7 @@ func (db *DB) PutTxRecord(tx transactions.ContractCall, height uint64, direction
 	}
 
 	key := make([]byte, 0)
-	key = append(key, txRecordPrefix...)
+	key = append(key, txRecordKeyPrefix(account)...)
 	key = append(key, buf.Bytes()...)
 	value := make([]byte, 1)
 
@@ -11,8 +11,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txrecords"
 	assert "github.com/stretchr/testify/require"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 const path = "mainnet"
@@ -56,7 +59,57 @@ func TestPutGet(t *testing.T) {
 }
 
 func TestPutFetchTxRecord(t *testing.T) {
-	// FIXME: 459
+	assert := assert.New(t)
+
+	db, err := New(path)
+	assert.NoError(err)
+
+	defer os.RemoveAll(path)
+	defer db.Close()
+
+	tx := transactions.RandTx()
+	assert.NoError(db.PutTxRecord(1, tx, 10, txrecords.Out))
+
+	records, err := db.FetchTxRecords(1)
+	assert.NoError(err)
+	assert.Len(records, 1)
+	assert.Equal(txrecords.Out, records[0].Direction)
+	assert.Equal(uint64(10), records[0].Height)
+
+	hash, err := tx.CalculateHash()
+	assert.NoError(err)
+	assert.Equal(hash, records[0].View().Hash)
+}
+
+func TestTxRecordAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := New(path)
+	assert.NoError(err)
+
+	defer os.RemoveAll(path)
+	defer db.Close()
+
+	assert.NoError(db.PutTxRecord(0, transactions.RandTx(), 10, txrecords.In))
+	assert.NoError(db.PutTxRecord(1, transactions.RandTx(), 11, txrecords.Out))
+	assert.NoError(db.PutTxRecord(1, transactions.RandTx(), 12, txrecords.In))
+
+	// Every account only sees its own records
+	count := func(account uint32) int {
+		iter := db.storage.NewIterator(util.BytesPrefix(txRecordKeyPrefix(account)), nil)
+		defer iter.Release()
+
+		n := 0
+		for iter.Next() {
+			n++
+		}
+
+		return n
+	}
+
+	assert.Equal(1, count(0))
+	assert.Equal(2, count(1))
+	assert.Equal(0, count(2))
 }
 
 func TestPutTxRecord(t *testing.T) {
@@ -56,6 +56,12 @@ func (p *PermissiveExecutor) GetProvisioners(ctx context.Context) (user.Provisio
 	return *p.P, nil
 }
 
+// RevertState always succeeds.
+func (p *PermissiveExecutor) RevertState(ctx context.Context, height uint64) error {
+	p.height = height
+	return nil
+}
+
 // PermissiveProvisioner mocks verification of scores.
 type PermissiveProvisioner struct{}
 
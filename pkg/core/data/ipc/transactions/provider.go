@@ -15,9 +15,17 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/blindbid"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 )
 
+// SlashOpCode is the op code of the Slash call of the stake contract.
+const SlashOpCode = uint32(Slash)
+
+// ErrStateRevertUnsupported is returned by the Executors which can not bring
+// the global state back to a previous height.
+var ErrStateRevertUnsupported = errors.New("the global state can not be reverted")
+
 // TxRequest is a convenient struct to group all parameters needed to create a
 // transaction.
 type TxRequest struct {
@@ -66,6 +74,11 @@ type Provider interface {
 	// It accepts the PublicKey of the recipient, a value, a fee and whether
 	// the transaction should be obfuscated or otherwise.
 	NewTransfer(context.Context, uint64, *keys.StealthAddress) (*Transaction, error)
+
+	// NewSlash creates a Slash contract call punishing a provisioner for
+	// an equivocation. It accepts the provisioner BLS public key, the round
+	// and step of the equivocation, and the two conflicting signed votes.
+	NewSlash(context.Context, []byte, uint64, uint8, []byte, []byte) (*Transaction, error)
 }
 
 // KeyMaster Encapsulates the Key creation and retrieval operations.
@@ -89,6 +102,11 @@ type Executor interface {
 
 	// GetProvisioners returns the current set of provisioners.
 	GetProvisioners(ctx context.Context) (user.Provisioners, error)
+
+	// RevertState brings the global state back to the one following the
+	// execution of the block at the given height. It returns
+	// ErrStateRevertUnsupported if the state can not be reverted.
+	RevertState(ctx context.Context, height uint64) error
 }
 
 // Provisioner encapsulates the operations common to a Provisioner during the
@@ -122,6 +140,7 @@ type proxy struct {
 	transferClient rusk.TransferClient
 	stakeClient    rusk.StakeServiceClient
 	walletClient   rusk.WalletClient
+	txClient       rusk.TransactionServiceClient
 	txTimeout      time.Duration
 	timeout        time.Duration
 }
@@ -129,7 +148,7 @@ type proxy struct {
 // NewProxy creates a new Proxy.
 func NewProxy(stateClient rusk.StateClient, keysClient rusk.KeysClient, blindbidClient rusk.BlindBidServiceClient,
 	bidClient rusk.BidServiceClient, transferClient rusk.TransferClient, stakeClient rusk.StakeServiceClient, walletClient rusk.WalletClient,
-	txTimeout, defaultTimeout time.Duration) Proxy {
+	txClient rusk.TransactionServiceClient, txTimeout, defaultTimeout time.Duration) Proxy {
 	return &proxy{
 		stateClient:    stateClient,
 		keysClient:     keysClient,
@@ -138,6 +157,7 @@ func NewProxy(stateClient rusk.StateClient, keysClient rusk.KeysClient, blindbid
 		transferClient: transferClient,
 		stakeClient:    stakeClient,
 		walletClient:   walletClient,
+		txClient:       txClient,
 		txTimeout:      txTimeout,
 		timeout:        defaultTimeout,
 	}
@@ -153,6 +173,12 @@ func (p *proxy) KeyMaster() KeyMaster {
 	return &keymaster{p}
 }
 
+// NewKeyMaster creates a KeyMaster on its own, for the processes which only
+// need to generate keys (e.g. the wallet restoring its file).
+func NewKeyMaster(keysClient rusk.KeysClient, timeout time.Duration) KeyMaster {
+	return &keymaster{&proxy{keysClient: keysClient, timeout: timeout}}
+}
+
 // Executor returned by the Proxy.
 func (p *proxy) Executor() Executor {
 	return &executor{p}
@@ -302,6 +328,55 @@ func (p *provider) NewTransfer(ctx context.Context, value uint64, sa *keys.Steal
 	return trans, err
 }
 
+// NewSlash creates a Slash contract call through the generic transaction
+// service of Rusk. The arguments of the call are the provisioner BLS public
+// key, the round and step of the equivocation, and the two signed votes.
+func (p *provider) NewSlash(ctx context.Context, pubKeyBLS []byte, round uint64, step uint8, first, second []byte) (*Transaction, error) {
+	args := new(bytes.Buffer)
+	if err := encoding.WriteVarBytes(args, pubKeyBLS); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteUint64LE(args, round); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteUint8(args, step); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteVarBytes(args, first); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteVarBytes(args, second); err != nil {
+		return nil, err
+	}
+
+	tr := new(rusk.TransactionRequest)
+	tr.OpCode = SlashOpCode
+	tr.Arguments = args.Bytes()
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(p.txTimeout))
+	defer cancel()
+
+	res, err := p.txClient.NewTransaction(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	trans := NewTransaction()
+	if err := UTransaction(res, trans); err != nil {
+		return nil, err
+	}
+
+	if trans.TxType != Slash {
+		return nil, errors.New("rusk returned a transaction which is not a Slash")
+	}
+
+	return trans, nil
+}
+
 type keymaster struct {
 	*proxy
 }
@@ -435,6 +510,12 @@ func (e *executor) GetProvisioners(ctx context.Context) (user.Provisioners, erro
 	return *provisioners, nil
 }
 
+// RevertState is not supported by Rusk, whose State service can only step
+// the block-height up.
+func (e *executor) RevertState(ctx context.Context, height uint64) error {
+	return ErrStateRevertUnsupported
+}
+
 type provisioner struct {
 	*proxy
 }
@@ -131,11 +131,11 @@ func Decode(b *bytes.Buffer, t *TxRecord) error {
 		return err
 	}
 
-	call := new(transactions.Transaction)
+	call := transactions.NewTransaction()
 	if err := transactions.Unmarshal(b, call); err != nil {
 		return err
 	}
 
-	t.Transaction = *call
+	t.Transaction = call
 	return nil
 }
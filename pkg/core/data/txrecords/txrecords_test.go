@@ -7,39 +7,31 @@
 package txrecords_test
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txrecords"
+	"github.com/stretchr/testify/assert"
 )
 
 // Ensure integrity of data between encoding and decoding.
 func TestEncodeDecodeTxRecord(t *testing.T) {
-	// FIXME: 459 - rework for RUSK integration
-	/*
-		r := &txrecords.TxRecord{
-			Direction:    txrecords.In,
-			Timestamp:    time.Now().Unix(),
-			Height:       500,
-			TxType:       transactions.Bid,
-			Amount:       7172727182793,
-			UnlockHeight: 300000,
-			Recipient:    "pippo",
-		}
+	r := txrecords.New(transactions.RandTx(), 500, txrecords.In)
 
-		buf := new(bytes.Buffer)
-		if err := txrecords.Encode(buf, r); err != nil {
-			t.Fatal(err)
-		}
+	buf := new(bytes.Buffer)
+	if err := txrecords.Encode(buf, r); err != nil {
+		t.Fatal(err)
+	}
 
-		decoded := &txrecords.TxRecord{}
-		if err := txrecords.Decode(buf, decoded); err != nil {
-			t.Fatal(err)
-		}
+	decoded := &txrecords.TxRecord{}
+	if err := txrecords.Decode(buf, decoded); err != nil {
+		t.Fatal(err)
+	}
 
-		assert.Equal(t, r.Direction, decoded.Direction)
-		assert.Equal(t, r.Timestamp, decoded.Timestamp)
-		assert.Equal(t, r.Height, decoded.Height)
-		assert.Equal(t, r.TxType, decoded.TxType)
-		assert.Equal(t, r.Amount, decoded.Amount)
-		assert.Equal(t, r.UnlockHeight, decoded.UnlockHeight)
-		assert.Equal(t, r.Recipient, decoded.Recipient)
-	*/
+	assert.Equal(t, r.Direction, decoded.Direction)
+	assert.Equal(t, r.Timestamp, decoded.Timestamp)
+	assert.Equal(t, r.Height, decoded.Height)
+	assert.True(t, transactions.Equal(r.Transaction, decoded.Transaction))
+	assert.Equal(t, r.View(), decoded.View())
 }
@@ -0,0 +1,136 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+)
+
+// DefaultAccount is the index of the account created along with the wallet.
+const DefaultAccount = uint32(0)
+
+// AccountMetadataKey is the gRPC metadata key selecting the account a
+// Wallet or Transactor request applies to. Requests without it use the
+// DefaultAccount.
+const AccountMetadataKey = "dusk-account"
+
+// accountDerivationKey separates the account seeds from any other use of
+// the wallet seed.
+var accountDerivationKey = []byte("dusk account")
+
+// ErrUnknownAccount is returned when selecting an account the wallet does
+// not hold.
+var ErrUnknownAccount = errors.New("unknown wallet account")
+
+// Account holds the keys of a funds account of the wallet. The accounts of a
+// wallet share its staking identity (see Wallet.Keys).
+type Account struct {
+	Index     uint32
+	SecretKey keys.SecretKey
+	PublicKey keys.PublicKey
+	ViewKey   keys.ViewKey
+}
+
+// AccountJSON is the encrypted form of an Account, stored in KeysJSON.
+type AccountJSON struct {
+	Index     uint32         `json:"index"`
+	SecretKey []byte         `json:"secret_key"`
+	PublicKey keys.PublicKey `json:"public_key"`
+	ViewKey   keys.ViewKey   `json:"view_key"`
+}
+
+func (a AccountJSON) account() (Account, error) {
+	sk := keys.NewSecretKey()
+	if err := keys.UnmarshalSecretKey(bytes.NewBuffer(a.SecretKey), sk); err != nil {
+		return Account{}, err
+	}
+
+	return Account{
+		Index:     a.Index,
+		SecretKey: *sk,
+		PublicKey: a.PublicKey,
+		ViewKey:   a.ViewKey,
+	}, nil
+}
+
+// DeriveAccountSeed derives the seed of an account from the wallet seed, as
+// HMAC-SHA512(seed, "dusk account" || index). The DefaultAccount uses the
+// wallet seed itself, so that the wallets created before accounts keep their
+// keys.
+func DeriveAccountSeed(seed []byte, index uint32) []byte {
+	if index == DefaultAccount {
+		return seed
+	}
+
+	mac := hmac.New(sha512.New, seed)
+	_, _ = mac.Write(accountDerivationKey)
+
+	var idx [4]byte
+
+	binary.BigEndian.PutUint32(idx[:], index)
+	_, _ = mac.Write(idx[:])
+
+	return mac.Sum(nil)
+}
+
+// AddAccount derives the keys of the next account of the wallet, and adds
+// them to keysJSON. The file holding the keys must then be updated with
+// UpdateKeys. The node does not serve the derived accounts yet, as Rusk can
+// not spend their funds.
+func AddAccount(ctx context.Context, keysJSON *KeysJSON, keyMaster transactions.KeyMaster) (AccountJSON, error) {
+	if keysJSON.WatchOnly {
+		return AccountJSON{}, ErrWatchOnly
+	}
+
+	index := uint32(len(keysJSON.Accounts) + 1)
+
+	sk, pk, vk, err := keyMaster.GenerateKeys(ctx, DeriveAccountSeed(keysJSON.Seed, index))
+	if err != nil {
+		return AccountJSON{}, err
+	}
+
+	skBuf := new(bytes.Buffer)
+	if err = keys.MarshalSecretKey(skBuf, &sk); err != nil {
+		return AccountJSON{}, err
+	}
+
+	account := AccountJSON{
+		Index:     index,
+		SecretKey: skBuf.Bytes(),
+		PublicKey: pk,
+		ViewKey:   vk,
+	}
+
+	keysJSON.Accounts = append(keysJSON.Accounts, account)
+	return account, nil
+}
+
+// Account returns the account at index.
+func (w *Wallet) Account(index uint32) (Account, error) {
+	if int(index) >= len(w.accounts) {
+		return Account{}, fmt.Errorf("%w: %d", ErrUnknownAccount, index)
+	}
+
+	return w.accounts[index], nil
+}
+
+// Accounts returns all the accounts of the wallet, ordered by index.
+func (w *Wallet) Accounts() []Account {
+	accounts := make([]Account, len(w.accounts))
+	copy(accounts, w.accounts)
+
+	return accounts
+}
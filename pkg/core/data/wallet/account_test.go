@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txrecords"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestDeriveAccountSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seed, err := GenerateNewSeed(nil)
+	assert.NoError(err)
+
+	// The default account keeps the keys of the wallet seed
+	assert.Equal(seed, DeriveAccountSeed(seed, DefaultAccount))
+
+	first := DeriveAccountSeed(seed, 1)
+	assert.Len(first, 64)
+	assert.Equal(first, DeriveAccountSeed(seed, 1))
+	assert.NotEqual(first, DeriveAccountSeed(seed, 2))
+	assert.NotEqual(seed, first)
+}
+
+func TestAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "accounts")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "wallet.dat")
+	ctx := context.Background()
+
+	mnemonic, err := NewMnemonic(nil)
+	assert.NoError(err)
+
+	seed, err := SeedFromMnemonic(mnemonic)
+	assert.NoError(err)
+
+	keysJSON, err := KeysFromSeed(ctx, seed, seedKeyMaster{})
+	assert.NoError(err)
+	assert.NoError(SaveKeys("password", file, keysJSON))
+
+	for i := uint32(1); i <= 2; i++ {
+		account, err := AddAccount(ctx, &keysJSON, seedKeyMaster{})
+		assert.NoError(err)
+		assert.Equal(i, account.Index)
+	}
+
+	// The file is only updated with the right password
+	assert.Error(UpdateKeys("wrong", file, keysJSON))
+	assert.NoError(UpdateKeys("password", file, keysJSON))
+
+	w, err := LoadFromFile(byte(1), nil, "password", file)
+	assert.NoError(err)
+
+	accounts := w.Accounts()
+	assert.Len(accounts, 3)
+	assert.Equal(w.PublicKey, accounts[DefaultAccount].PublicKey)
+
+	for i, account := range accounts {
+		assert.Equal(uint32(i), account.Index)
+
+		for _, other := range accounts[i+1:] {
+			assert.NotEqual(account.PublicKey.ToAddr(), other.PublicKey.ToAddr())
+		}
+	}
+
+	account, err := w.Account(2)
+	assert.NoError(err)
+	assert.Equal(accounts[2], account)
+
+	_, err = w.Account(3)
+	assert.True(errors.Is(err, ErrUnknownAccount))
+
+	// The accounts share the staking identity of the wallet seed
+	consensusKeys, err := generateKeys(seed)
+	assert.NoError(err)
+	assert.Equal(consensusKeys.BLSPubKeyBytes, w.Keys().BLSPubKeyBytes)
+}
+
+func TestAccountHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "history")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	db, err := database.New(filepath.Join(dir, "db"))
+	assert.NoError(err)
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	seed, err := GenerateNewSeed(nil)
+	assert.NoError(err)
+
+	keysJSON, err := KeysFromSeed(context.Background(), seed, seedKeyMaster{})
+	assert.NoError(err)
+
+	w, err := LoadFromSeed(byte(1), db, "password", filepath.Join(dir, "wallet.dat"), keysJSON)
+	assert.NoError(err)
+
+	tx := transactions.RandTx()
+	assert.NoError(w.StoreTxRecord(DefaultAccount, tx, 10, txrecords.Out))
+
+	// Each account has its own history
+	records, err := w.FetchTxHistory(DefaultAccount)
+	assert.NoError(err)
+	assert.Len(records, 1)
+	assert.Equal(txrecords.Out, records[0].Direction)
+	assert.Equal(uint64(10), records[0].Height)
+
+	records, err = w.FetchTxHistory(1)
+	assert.NoError(err)
+	assert.Empty(records)
+}
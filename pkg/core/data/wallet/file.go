@@ -7,16 +7,153 @@
 package wallet
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"os"
 
+	"golang.org/x/crypto/scrypt"
 	"golang.org/x/crypto/sha3"
 )
 
+// The keystore file starts with a header holding the format version and the
+// parameters of the key derivation, followed by the AES-GCM nonce and the
+// sealed KeysJSON. The header is authenticated as additional data.
+//
+// Legacy files carry no header: they are made of the nonce and the sealed
+// KeysJSON only, encrypted with the SHA3-256 digest of the password.
+const (
+	// KeystoreVersion is the version of the keystore format written by the
+	// wallet.
+	KeystoreVersion = uint8(1)
+
+	kdfScrypt = uint8(1)
+	saltSize  = 32
+	keySize   = 32
+)
+
+// keystoreMagic identifies a versioned keystore file.
+var keystoreMagic = []byte("DUSKWLT")
+
+var (
+	// ErrUnknownKeystoreVersion is returned when loading a keystore written
+	// with a newer format.
+	ErrUnknownKeystoreVersion = errors.New("unknown wallet keystore version")
+
+	// ErrAlreadyMigrated is returned when migrating a keystore which is
+	// already in the current format.
+	ErrAlreadyMigrated = errors.New("wallet keystore is already up to date")
+
+	// ErrBackupExists is returned if the backup of a migrated keystore would
+	// overwrite an existing file.
+	ErrBackupExists = errors.New("wallet keystore backup already exists")
+
+	errMalformedKeystore = errors.New("malformed wallet keystore")
+)
+
+// kdfParams are the scrypt parameters used to derive the encryption key
+// from the password.
+type kdfParams struct {
+	N uint32
+	R uint32
+	P uint32
+}
+
+// defaultKDFParams are the scrypt parameters recommended for interactive
+// logins (about 32MB of memory).
+var defaultKDFParams = kdfParams{N: 1 << 15, R: 8, P: 1}
+
+// maxKDFParams bound the scrypt parameters read from a keystore file. The
+// header is only authenticated once the key is derived, so a forged header
+// could otherwise exhaust the memory or the CPU of the node.
+var maxKDFParams = kdfParams{N: 1 << 20, R: 32, P: 16}
+
+// valid returns true if the parameters are accepted by scrypt and within
+// maxKDFParams.
+func (p kdfParams) valid() bool {
+	// N must be a power of two greater than 1
+	if p.N <= 1 || p.N&(p.N-1) != 0 || p.N > maxKDFParams.N {
+		return false
+	}
+
+	return p.R > 0 && p.R <= maxKDFParams.R && p.P > 0 && p.P <= maxKDFParams.P
+}
+
+// header of a versioned keystore file.
+type header struct {
+	version uint8
+	kdf     uint8
+	params  kdfParams
+	salt    []byte
+}
+
+func (h header) marshal() []byte {
+	buf := new(bytes.Buffer)
+	_, _ = buf.Write(keystoreMagic)
+	_ = buf.WriteByte(h.version)
+	_ = buf.WriteByte(h.kdf)
+	_ = binary.Write(buf, binary.LittleEndian, h.params)
+	_ = buf.WriteByte(uint8(len(h.salt)))
+	_, _ = buf.Write(h.salt)
+
+	return buf.Bytes()
+}
+
+// unmarshalHeader reads the header of a keystore file, returning the header
+// and the rest of the file. The bool is false for legacy files.
+func unmarshalHeader(data []byte) (header, []byte, bool, error) {
+	var h header
+
+	if !bytes.HasPrefix(data, keystoreMagic) {
+		return h, data, false, nil
+	}
+
+	r := bytes.NewReader(data[len(keystoreMagic):])
+
+	var err error
+	if h.version, err = r.ReadByte(); err != nil {
+		return h, nil, true, errMalformedKeystore
+	}
+
+	if h.version != KeystoreVersion {
+		return h, nil, true, ErrUnknownKeystoreVersion
+	}
+
+	if h.kdf, err = r.ReadByte(); err != nil || h.kdf != kdfScrypt {
+		return h, nil, true, errMalformedKeystore
+	}
+
+	if err = binary.Read(r, binary.LittleEndian, &h.params); err != nil || !h.params.valid() {
+		return h, nil, true, errMalformedKeystore
+	}
+
+	saltLen, err := r.ReadByte()
+	if err != nil {
+		return h, nil, true, errMalformedKeystore
+	}
+
+	h.salt = make([]byte, saltLen)
+	if _, err = io.ReadFull(r, h.salt); err != nil {
+		return h, nil, true, errMalformedKeystore
+	}
+
+	return h, data[len(data)-r.Len():], true, nil
+}
+
+func (h header) deriveKey(password string) ([]byte, error) {
+	if !h.params.valid() {
+		return nil, errMalformedKeystore
+	}
+
+	return scrypt.Key([]byte(password), h.salt, int(h.params.N), int(h.params.R), int(h.params.P), keySize)
+}
+
 // saveEncrypted saves a []byte to a .dat file.
 func saveEncrypted(text []byte, password string, file string) error {
 	// Overwriting a seed file may cause loss of funds
@@ -24,50 +161,167 @@ func saveEncrypted(text []byte, password string, file string) error {
 		return ErrSeedFileExists
 	}
 
-	digest := sha3.Sum256([]byte(password))
-
-	c, err := aes.NewCipher(digest[:])
+	data, err := encrypt(text, password)
 	if err != nil {
 		return err
 	}
 
-	gcm, err := cipher.NewGCM(c)
+	return ioutil.WriteFile(file, data, 0o600)
+}
+
+// encrypt seals text in a keystore of the current version.
+func encrypt(text []byte, password string) ([]byte, error) {
+	h := header{
+		version: KeystoreVersion,
+		kdf:     kdfScrypt,
+		params:  defaultKDFParams,
+		salt:    make([]byte, saltSize),
+	}
+
+	if _, err := io.ReadFull(rand.Reader, h.salt); err != nil {
+		return nil, err
+	}
+
+	key, err := h.deriveKey(password)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
 	}
 
 	nonce := make([]byte, gcm.NonceSize())
 	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
-		return err
+		return nil, err
 	}
 
-	return ioutil.WriteFile(file, gcm.Seal(nonce, nonce, text, nil), 0o600)
+	hdr := h.marshal()
+
+	data := make([]byte, 0, len(hdr)+len(nonce)+len(text)+gcm.Overhead())
+	data = append(data, hdr...)
+	data = append(data, nonce...)
+
+	return gcm.Seal(data, nonce, text, hdr), nil
 }
 
 // fetchEncrypted load encrypted from file.
 func fetchEncrypted(password string, file string) ([]byte, error) {
-	digest := sha3.Sum256([]byte(password))
-
-	ciphertext, err := ioutil.ReadFile(file) //nolint
+	data, err := ioutil.ReadFile(file) //nolint
 	if err != nil {
 		return nil, err
 	}
 
-	c, err := aes.NewCipher(digest[:])
+	text, _, err := decrypt(data, password)
+	return text, err
+}
+
+// decrypt opens a keystore, either versioned or legacy. The bool is true if
+// the keystore is in the legacy format.
+func decrypt(data []byte, password string) ([]byte, bool, error) {
+	h, ciphertext, versioned, err := unmarshalHeader(data)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	gcm, err := cipher.NewGCM(c)
+	var key, hdr []byte
+
+	if versioned {
+		hdr = data[:len(data)-len(ciphertext)]
+
+		if key, err = h.deriveKey(password); err != nil {
+			return nil, false, err
+		}
+	} else {
+		digest := sha3.Sum256([]byte(password))
+		key = digest[:]
+	}
+
+	gcm, err := newGCM(key)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	nonceSize := gcm.NonceSize()
 	if len(ciphertext) < nonceSize {
-		return nil, err
+		return nil, false, errMalformedKeystore
 	}
 
 	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
-	return gcm.Open(nil, nonce, ciphertext, nil)
+
+	text, err := gcm.Open(nil, nonce, ciphertext, hdr)
+	return text, !versioned, err
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(c)
+}
+
+// IsLegacyFile returns true if the wallet file is in the legacy keystore
+// format, and should be migrated with MigrateFile.
+func IsLegacyFile(file string) (bool, error) {
+	data, err := ioutil.ReadFile(file) //nolint
+	if err != nil {
+		return false, err
+	}
+
+	_, _, versioned, err := unmarshalHeader(data)
+	return !versioned, err
+}
+
+// MigrateFile re-encrypts a legacy wallet file in the current keystore
+// format. The original file is kept next to it, with the .bak extension,
+// and is only written if the password opens the legacy keystore.
+func MigrateFile(password, file string) error {
+	data, err := ioutil.ReadFile(file) //nolint
+	if err != nil {
+		return err
+	}
+
+	text, legacy, err := decrypt(data, password)
+	if err != nil {
+		return err
+	}
+
+	if !legacy {
+		return ErrAlreadyMigrated
+	}
+
+	// Make sure the keys can still be loaded once migrated
+	if err = json.Unmarshal(text, new(KeysJSON)); err != nil {
+		return err
+	}
+
+	migrated, err := encrypt(text, password)
+	if err != nil {
+		return err
+	}
+
+	backup := file + ".bak"
+	if _, err = os.Stat(backup); err == nil {
+		return ErrBackupExists
+	}
+
+	if err = ioutil.WriteFile(backup, data, 0o600); err != nil {
+		return err
+	}
+
+	return replaceFile(file, migrated)
+}
+
+// replaceFile overwrites a keystore file. The new keystore is written aside
+// first, so that the wallet file is never left half written.
+func replaceFile(file string, data []byte) error {
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
 }
@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// saveLegacy writes text in the keystore format used before versioning.
+func saveLegacy(t *testing.T, text []byte, password, file string) {
+	digest := sha3.Sum256([]byte(password))
+
+	gcm, err := newGCM(digest[:])
+	assert.NoError(t, err)
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(file, gcm.Seal(nonce, nonce, text, nil), 0o600))
+}
+
+func TestSaveFetchEncrypted(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "keystore")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "wallet.dat")
+	text := []byte("dusk keystore")
+
+	assert.NoError(saveEncrypted(text, "password", file))
+	assert.Equal(ErrSeedFileExists, saveEncrypted(text, "password", file))
+
+	legacy, err := IsLegacyFile(file)
+	assert.NoError(err)
+	assert.False(legacy)
+
+	fetched, err := fetchEncrypted("password", file)
+	assert.NoError(err)
+	assert.Equal(text, fetched)
+
+	_, err = fetchEncrypted("wrong password", file)
+	assert.Error(err)
+
+	// Tampering with the header is detected
+	data, err := ioutil.ReadFile(file)
+	assert.NoError(err)
+
+	data[len(keystoreMagic)+2]++
+	assert.NoError(ioutil.WriteFile(file, data, 0o600))
+
+	_, err = fetchEncrypted("password", file)
+	assert.Error(err)
+}
+
+// Test that the scrypt parameters of a keystore are bounded before the key is
+// derived.
+func TestKDFParamsBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := encrypt([]byte("dusk keystore"), "password")
+	assert.NoError(err)
+
+	for _, params := range []kdfParams{
+		{N: 1 << 21, R: 8, P: 1},
+		{N: 1<<15 + 1, R: 8, P: 1},
+		{N: 1 << 15, R: 33, P: 1},
+		{N: 1 << 15, R: 8, P: 17},
+		{N: 1 << 15, R: 0, P: 1},
+	} {
+		h, _, _, err := unmarshalHeader(data)
+		assert.NoError(err)
+
+		h.params = params
+
+		_, err = h.deriveKey("password")
+		assert.Equal(errMalformedKeystore, err)
+
+		forged := append(h.marshal(), data[len(h.marshal()):]...)
+
+		_, _, err = decrypt(forged, "password")
+		assert.Equal(errMalformedKeystore, err)
+	}
+
+	_, _, err = decrypt(data, "password")
+	assert.NoError(err)
+}
+
+func TestMigrateFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "keystore")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "wallet.dat")
+
+	text, err := json.Marshal(KeysJSON{Seed: []byte{1, 2, 3}})
+	assert.NoError(err)
+
+	saveLegacy(t, text, "password", file)
+
+	original, err := ioutil.ReadFile(file)
+	assert.NoError(err)
+
+	// Legacy files are still loaded
+	legacy, err := IsLegacyFile(file)
+	assert.NoError(err)
+	assert.True(legacy)
+
+	fetched, err := fetchEncrypted("password", file)
+	assert.NoError(err)
+	assert.Equal(text, fetched)
+
+	// A wrong password leaves the file untouched
+	assert.Error(MigrateFile("wrong password", file))
+
+	_, err = os.Stat(file + ".bak")
+	assert.True(os.IsNotExist(err))
+
+	assert.NoError(MigrateFile("password", file))
+
+	legacy, err = IsLegacyFile(file)
+	assert.NoError(err)
+	assert.False(legacy)
+
+	fetched, err = fetchEncrypted("password", file)
+	assert.NoError(err)
+	assert.Equal(text, fetched)
+
+	backup, err := ioutil.ReadFile(file + ".bak")
+	assert.NoError(err)
+	assert.Equal(original, backup)
+
+	assert.Equal(ErrAlreadyMigrated, MigrateFile("password", file))
+}
@@ -0,0 +1,135 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// mnemonicEntropySize is the size of the entropy encoded by a mnemonic
+// phrase, which makes a phrase of 24 words.
+const mnemonicEntropySize = 32
+
+var (
+	// ErrInvalidMnemonic is returned when a mnemonic phrase is not a valid
+	// BIP-39 phrase.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic phrase")
+
+	// ErrConsensusKeysMismatch is returned if the consensus keys restored
+	// from a seed differ from the original ones.
+	ErrConsensusKeysMismatch = errors.New("restored consensus keys do not match")
+
+	// ErrRuskKeysMismatch is returned if the keys generated by Rusk for a
+	// restored seed differ from the original ones.
+	ErrRuskKeysMismatch = errors.New("restored rusk keys do not match")
+)
+
+// NewMnemonic creates a BIP-39 mnemonic phrase, from which the wallet seed
+// is derived with SeedFromMnemonic. As in GenerateNewSeed, phrases whose seed
+// can not be used for generating a BLS keypair are discarded.
+func NewMnemonic(Read func(buf []byte) (n int, err error)) (string, error) {
+	if Read == nil {
+		Read = rand.Read
+	}
+
+	for {
+		entropy := make([]byte, mnemonicEntropySize)
+		if _, err := Read(entropy); err != nil {
+			return "", err
+		}
+
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		if err != nil {
+			return "", err
+		}
+
+		seed, err := SeedFromMnemonic(mnemonic)
+		if err != nil {
+			return "", err
+		}
+
+		// Ensure the seed can be used for generating a BLS keypair.
+		// If not, we retry.
+		_, err = generateKeys(seed)
+		if err == nil {
+			return mnemonic, nil
+		}
+
+		if err != io.EOF {
+			return "", err
+		}
+	}
+}
+
+// SeedFromMnemonic validates a mnemonic phrase and returns the 64 bytes seed
+// it stands for.
+func SeedFromMnemonic(mnemonic string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return bip39.NewSeed(mnemonic, ""), nil
+}
+
+// KeysFromSeed derives the keys of a wallet from its seed. The consensus keys
+// are derived when the wallet is loaded, while the keys of the funds are
+// generated by Rusk.
+func KeysFromSeed(ctx context.Context, seed []byte, keyMaster transactions.KeyMaster) (KeysJSON, error) {
+	sk, pk, vk, err := keyMaster.GenerateKeys(ctx, seed)
+	if err != nil {
+		return KeysJSON{}, err
+	}
+
+	skBuf := new(bytes.Buffer)
+	if err = keys.MarshalSecretKey(skBuf, &sk); err != nil {
+		return KeysJSON{}, err
+	}
+
+	return KeysJSON{
+		Seed:      seed,
+		SecretKey: skBuf.Bytes(),
+		PublicKey: pk,
+		ViewKey:   vk,
+	}, nil
+}
+
+// VerifyKeys checks that the keys restored from a seed match the original
+// ones. The public key is derived from the secret key, and thus only the
+// secret and view keys are compared.
+func VerifyKeys(original, restored KeysJSON) error {
+	originalKeys, err := generateKeys(original.Seed)
+	if err != nil {
+		return err
+	}
+
+	restoredKeys, err := generateKeys(restored.Seed)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(originalKeys.BLSPubKeyBytes, restoredKeys.BLSPubKeyBytes) {
+		return ErrConsensusKeysMismatch
+	}
+
+	if !bytes.Equal(original.SecretKey, restored.SecretKey) ||
+		!bytes.Equal(original.ViewKey.A, restored.ViewKey.A) ||
+		!bytes.Equal(original.ViewKey.BG, restored.ViewKey.BG) {
+		return ErrRuskKeysMismatch
+	}
+
+	return nil
+}
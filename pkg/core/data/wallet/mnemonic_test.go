@@ -0,0 +1,135 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"context"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	assert "github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// seedKeyMaster derives the Rusk keys from the seed, as Rusk is expected to.
+type seedKeyMaster struct{}
+
+func (seedKeyMaster) GenerateKeys(_ context.Context, seed []byte) (keys.SecretKey, keys.PublicKey, keys.ViewKey, error) {
+	digest := sha3.Sum512(seed)
+
+	sk := keys.SecretKey{A: digest[:32], B: digest[32:]}
+	vk := keys.ViewKey{A: digest[:32], BG: digest[32:]}
+	pk := keys.PublicKey{AG: digest[32:], BG: digest[:32]}
+
+	return sk, pk, vk, nil
+}
+
+// randomKeyMaster generates new Rusk keys on every call.
+type randomKeyMaster struct{}
+
+func (randomKeyMaster) GenerateKeys(ctx context.Context, _ []byte) (keys.SecretKey, keys.PublicKey, keys.ViewKey, error) {
+	seed := make([]byte, 64)
+	_, _ = rand.Read(seed)
+
+	return seedKeyMaster{}.GenerateKeys(ctx, seed)
+}
+
+func TestMnemonic(t *testing.T) {
+	assert := assert.New(t)
+
+	// All-zero entropy makes the first BIP-39 test vector
+	zeroes := func(buf []byte) (int, error) {
+		for i := range buf {
+			buf[i] = 0
+		}
+
+		return len(buf), nil
+	}
+
+	mnemonic, err := NewMnemonic(zeroes)
+	assert.NoError(err)
+	assert.Equal(strings.Repeat("abandon ", 23)+"art", mnemonic)
+
+	mnemonic, err = NewMnemonic(nil)
+	assert.NoError(err)
+	assert.Len(strings.Fields(mnemonic), 24)
+
+	seed, err := SeedFromMnemonic(mnemonic)
+	assert.NoError(err)
+	assert.Len(seed, 64)
+
+	// Surrounding and repeated spaces are ignored
+	spaced, err := SeedFromMnemonic("  " + strings.Replace(mnemonic, " ", "   ", -1) + "\n")
+	assert.NoError(err)
+	assert.Equal(seed, spaced)
+
+	words := strings.Fields(mnemonic)
+	words[0], words[1] = "dusk", "network"
+
+	_, err = SeedFromMnemonic(strings.Join(words, " "))
+	assert.Equal(ErrInvalidMnemonic, err)
+}
+
+func TestRestoreFromMnemonic(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "mnemonic")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "wallet.dat")
+	ctx := context.Background()
+
+	mnemonic, err := NewMnemonic(nil)
+	assert.NoError(err)
+
+	seed, err := SeedFromMnemonic(mnemonic)
+	assert.NoError(err)
+
+	original, err := KeysFromSeed(ctx, seed, seedKeyMaster{})
+	assert.NoError(err)
+	assert.NoError(SaveKeys("password", file, original))
+
+	// Restore the keys from the phrase, and check them against the file
+	seed, err = SeedFromMnemonic(mnemonic)
+	assert.NoError(err)
+
+	restored, err := KeysFromSeed(ctx, seed, seedKeyMaster{})
+	assert.NoError(err)
+
+	stored, err := LoadKeys("password", file)
+	assert.NoError(err)
+	assert.NoError(VerifyKeys(stored, restored))
+
+	storedKeys, err := generateKeys(stored.Seed)
+	assert.NoError(err)
+
+	restoredKeys, err := generateKeys(restored.Seed)
+	assert.NoError(err)
+	assert.Equal(storedKeys.BLSPubKeyBytes, restoredKeys.BLSPubKeyBytes)
+
+	// Another phrase restores other consensus keys
+	otherMnemonic, err := NewMnemonic(nil)
+	assert.NoError(err)
+
+	otherSeed, err := SeedFromMnemonic(otherMnemonic)
+	assert.NoError(err)
+
+	restored, err = KeysFromSeed(ctx, otherSeed, seedKeyMaster{})
+	assert.NoError(err)
+	assert.Equal(ErrConsensusKeysMismatch, VerifyKeys(stored, restored))
+
+	// Rusk keys which are not derived from the seed can not be restored
+	restored, err = KeysFromSeed(ctx, seed, randomKeyMaster{})
+	assert.NoError(err)
+	assert.Equal(ErrRuskKeysMismatch, VerifyKeys(stored, restored))
+}
@@ -17,6 +17,7 @@ import (
 	consensuskey "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txrecords"
 	"golang.org/x/crypto/sha3"
@@ -34,19 +35,34 @@ type Wallet struct {
 	netPrefix byte
 
 	// keyPair       *key.Key
+	// consensusKeys are the staking identity of the wallet, derived from
+	// the root seed and shared by all the accounts.
 	consensusKeys *consensuskey.Keys
 
+	// accounts holds the funds accounts, the first one being the default
+	// account whose keys are the ones below.
+	accounts []Account
+
+	// watchOnly wallets hold no secret key (see SaveWatchOnly).
+	watchOnly bool
+
 	PublicKey keys.PublicKey
 	ViewKey   keys.ViewKey
 	SecretKey keys.SecretKey
 }
 
 // KeysJSON is a struct used to marshal / unmarshal fields to a encrypted file.
+// The top level keys are the ones of the default account. The file of a
+// watch-only wallet holds the public and the view keys only.
 type KeysJSON struct {
+	WatchOnly bool `json:"watch_only,omitempty"`
+
 	Seed      []byte         `json:"seed"`
 	SecretKey []byte         `json:"secret_key"`
 	PublicKey keys.PublicKey `json:"public_key"`
 	ViewKey   keys.ViewKey   `json:"view_key"`
+
+	Accounts []AccountJSON `json:"accounts,omitempty"`
 }
 
 // New creates a wallet instance.
@@ -82,8 +98,7 @@ func LoadFromSeed(netPrefix byte, db *database.DB, password, seedFile string, ke
 
 	secretKey := keys.NewSecretKey()
 
-	err := keys.UnmarshalSecretKey(bytes.NewBuffer(keysJSON.SecretKey), secretKey)
-	if err != nil {
+	if err := keys.UnmarshalSecretKey(bytes.NewBuffer(keysJSON.SecretKey), secretKey); err != nil {
 		return nil, err
 	}
 
@@ -91,49 +106,79 @@ func LoadFromSeed(netPrefix byte, db *database.DB, password, seedFile string, ke
 		return nil, errors.New("secretKey must be valid")
 	}
 
-	consensusKeys, err := generateKeys(keysJSON.Seed)
+	w, err := newWallet(netPrefix, db, keysJSON)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := SaveKeys(password, seedFile, keysJSON); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// SaveKeys stores the keys in an encrypted .dat file.
+func SaveKeys(password, seedFile string, keysJSON KeysJSON) error {
 	// transform keysJSON to []byte
 	data, err := json.Marshal(keysJSON)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// store it in a encrypted file
-	if err := saveEncrypted(data, password, seedFile); err != nil {
-		return nil, err
+	return saveEncrypted(data, password, seedFile)
+}
+
+// UpdateKeys replaces the keys stored in an existing .dat file, e.g. when an
+// account is added. The password must open the file.
+func UpdateKeys(password, seedFile string, keysJSON KeysJSON) error {
+	if _, err := fetchEncrypted(password, seedFile); err != nil {
+		return err
 	}
 
-	w := &Wallet{
-		db:            db,
-		netPrefix:     netPrefix,
-		consensusKeys: &consensusKeys,
-		SecretKey:     *secretKey,
-		PublicKey:     keysJSON.PublicKey,
-		ViewKey:       keysJSON.ViewKey,
+	data, err := json.Marshal(keysJSON)
+	if err != nil {
+		return err
 	}
 
-	return w, nil
+	encrypted, err := encrypt(data, password)
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(seedFile, encrypted)
 }
 
-// LoadFromFile loads a wallet from a .dat file.
-func LoadFromFile(netPrefix byte, db *database.DB, password string, seedFile string) (*Wallet, error) {
+// LoadKeys loads the keys stored in an encrypted .dat file.
+func LoadKeys(password, seedFile string) (KeysJSON, error) {
+	var keysJSON KeysJSON
+
 	keysJSONArr, err := fetchEncrypted(password, seedFile)
 	if err != nil {
-		return nil, err
+		return keysJSON, err
 	}
 
 	// transform []byte to keysJSON
-	keysJSON := new(KeysJSON)
+	err = json.Unmarshal(keysJSONArr, &keysJSON)
+	return keysJSON, err
+}
 
-	err = json.Unmarshal(keysJSONArr, keysJSON)
+// LoadFromFile loads a wallet from a .dat file.
+func LoadFromFile(netPrefix byte, db *database.DB, password string, seedFile string) (*Wallet, error) {
+	keysJSON, err := LoadKeys(password, seedFile)
 	if err != nil {
 		return nil, err
 	}
 
+	return newWallet(netPrefix, db, keysJSON)
+}
+
+func newWallet(netPrefix byte, db *database.DB, keysJSON KeysJSON) (*Wallet, error) {
+	if keysJSON.WatchOnly {
+		return newWatchOnly(netPrefix, db, keysJSON)
+	}
+
 	consensusKeys, err := generateKeys(keysJSON.Seed)
 	if err != nil {
 		return nil, err
@@ -147,23 +192,80 @@ func LoadFromFile(netPrefix byte, db *database.DB, password string, seedFile str
 		return nil, err
 	}
 
+	accounts := make([]Account, 0, len(keysJSON.Accounts)+1)
+	accounts = append(accounts, Account{
+		Index:     DefaultAccount,
+		SecretKey: *secretKey,
+		PublicKey: keysJSON.PublicKey,
+		ViewKey:   keysJSON.ViewKey,
+	})
+
+	for _, a := range keysJSON.Accounts {
+		account, err := a.account()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, account)
+	}
+
 	return &Wallet{
 		db:            db,
 		netPrefix:     netPrefix,
 		PublicKey:     keysJSON.PublicKey,
 		ViewKey:       keysJSON.ViewKey,
 		consensusKeys: &consensusKeys,
+		accounts:      accounts,
 		SecretKey:     *secretKey,
 	}, nil
 }
 
+// newWatchOnly loads a watch-only wallet. Such a wallet has no staking
+// identity: the node takes part in the consensus with ephemeral keys, which
+// can not hold any stake. They are generated anew on every load, so that the
+// node can still run the consensus; callers should let the user know.
+func newWatchOnly(netPrefix byte, db *database.DB, keysJSON KeysJSON) (*Wallet, error) {
+	consensusKeys, err := consensuskey.NewRandKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Wallet{
+		db:            db,
+		netPrefix:     netPrefix,
+		PublicKey:     keysJSON.PublicKey,
+		ViewKey:       keysJSON.ViewKey,
+		consensusKeys: &consensusKeys,
+		accounts: []Account{{
+			Index:     DefaultAccount,
+			PublicKey: keysJSON.PublicKey,
+			ViewKey:   keysJSON.ViewKey,
+		}},
+		watchOnly: true,
+	}, nil
+}
+
 // FetchTxHistory will return a slice containing information about all
-// transactions made and received with this wallet.
-func (w *Wallet) FetchTxHistory() ([]txrecords.TxRecord, error) {
-	return w.db.FetchTxRecords()
+// transactions made and received with an account of this wallet. A
+// watch-only wallet has no history (see ErrWatchOnlyHistory): the wallet
+// database may still hold the records sent by a full wallet previously
+// loaded by the node, which are not reported.
+func (w *Wallet) FetchTxHistory(account uint32) ([]txrecords.TxRecord, error) {
+	if w.watchOnly {
+		return nil, ErrWatchOnlyHistory
+	}
+
+	return w.db.FetchTxRecords(account)
+}
+
+// StoreTxRecord adds a transaction sent or received by an account of this
+// wallet to its history.
+func (w *Wallet) StoreTxRecord(account uint32, tx transactions.ContractCall, height uint64, direction txrecords.Direction) error {
+	return w.db.PutTxRecord(account, tx, height, direction)
 }
 
-// Keys returns the BLS keys.
+// Keys returns the BLS keys, i.e. the staking identity of the wallet. They do
+// not depend on the account used to pay for a stake.
 func (w *Wallet) Keys() consensuskey.Keys {
 	return *w.consensusKeys
 }
@@ -38,6 +38,9 @@ func TestMain(m *testing.M) {
 	// start rusk mock rpc server
 	tests.StartMockServer(address)
 
+	// Keep the key derivation cheap, as TestCatchEOF creates 1000 wallets
+	defaultKDFParams = kdfParams{N: 1 << 10, R: 8, P: 1}
+
 	// Start all tests
 	code := m.Run()
 
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+)
+
+// ErrWatchOnly is returned when a watch-only wallet is asked to sign.
+var ErrWatchOnly = errors.New("watch-only wallet can not sign transactions")
+
+// ErrWatchOnlyHistory is returned when fetching the history of a watch-only
+// wallet. Only the incoming transactions could be told by its view key, and
+// Rusk does not report the transactions received by a view key.
+var ErrWatchOnlyHistory = errors.New("watch-only wallet history unsupported, Rusk does not report incoming transactions")
+
+// keyPartSize is the size of every point making up a view or a public key.
+const keyPartSize = 32
+
+// EncodeViewKey encodes a view key as an hex string, to be exported to a
+// watch-only wallet.
+func EncodeViewKey(vk keys.ViewKey) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := keys.MarshalViewKey(buf, &vk); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeViewKey decodes a view key exported with EncodeViewKey.
+func DecodeViewKey(s string) (keys.ViewKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 2*keyPartSize {
+		return keys.ViewKey{}, fmt.Errorf("invalid view key %q", s)
+	}
+
+	vk := keys.NewViewKey()
+	if err := keys.UnmarshalViewKey(bytes.NewBuffer(b), vk); err != nil {
+		return keys.ViewKey{}, err
+	}
+
+	return *vk, nil
+}
+
+// DecodeAddress decodes the address of a wallet, as returned by
+// keys.PublicKey.ToAddr.
+func DecodeAddress(s string) (keys.PublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 2*keyPartSize {
+		return keys.PublicKey{}, fmt.Errorf("invalid address %q", s)
+	}
+
+	return keys.PublicKey{AG: b[:keyPartSize], BG: b[keyPartSize:]}, nil
+}
+
+// SaveWatchOnly stores the keys of a watch-only wallet in an encrypted .dat
+// file. A watch-only wallet only holds the address and the view key of an
+// account: it reports the balance and the incoming transactions of the
+// account, but can not spend its funds.
+func SaveWatchOnly(password, seedFile string, pk keys.PublicKey, vk keys.ViewKey) error {
+	return SaveKeys(password, seedFile, KeysJSON{
+		WatchOnly: true,
+		PublicKey: pk,
+		ViewKey:   vk,
+	})
+}
+
+// IsWatchOnly returns true if the wallet was created from a view key, and
+// thus can not sign transactions.
+func (w *Wallet) IsWatchOnly() bool {
+	return w.watchOnly
+}
@@ -0,0 +1,130 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package wallet
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txrecords"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestWatchOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+
+	mnemonic, err := NewMnemonic(nil)
+	assert.NoError(err)
+
+	seed, err := SeedFromMnemonic(mnemonic)
+	assert.NoError(err)
+
+	keysJSON, err := KeysFromSeed(ctx, seed, seedKeyMaster{})
+	assert.NoError(err)
+
+	// Export the view key and the address of the wallet
+	encoded, err := EncodeViewKey(keysJSON.ViewKey)
+	assert.NoError(err)
+
+	vk, err := DecodeViewKey(encoded)
+	assert.NoError(err)
+	assert.Equal(keysJSON.ViewKey, vk)
+
+	pk, err := DecodeAddress(string(keysJSON.PublicKey.ToAddr()))
+	assert.NoError(err)
+	assert.Equal(keysJSON.PublicKey, pk)
+
+	_, err = DecodeViewKey(encoded[2:])
+	assert.Error(err)
+
+	_, err = DecodeAddress("dusk")
+	assert.Error(err)
+
+	// Load a watch-only wallet from them
+	file := filepath.Join(dir, "watch.dat")
+	assert.NoError(SaveWatchOnly("password", file, pk, vk))
+
+	w, err := LoadFromFile(byte(1), nil, "password", file)
+	assert.NoError(err)
+	assert.True(w.IsWatchOnly())
+	assert.Equal(vk, w.ViewKey)
+
+	account, err := w.Account(DefaultAccount)
+	assert.NoError(err)
+	assert.Equal(pk, account.PublicKey)
+	assert.Equal(vk, account.ViewKey)
+
+	// Nothing is derived without the seed
+	stored, err := LoadKeys("password", file)
+	assert.NoError(err)
+	assert.Empty(stored.Seed)
+	assert.Empty(stored.SecretKey)
+
+	_, err = AddAccount(ctx, &stored, seedKeyMaster{})
+	assert.Equal(ErrWatchOnly, err)
+
+	// A regular wallet is not watch-only
+	file = filepath.Join(dir, "wallet.dat")
+	assert.NoError(SaveKeys("password", file, keysJSON))
+
+	w, err = LoadFromFile(byte(1), nil, "password", file)
+	assert.NoError(err)
+	assert.False(w.IsWatchOnly())
+}
+
+// Test that a watch-only wallet refuses to report a history, even if its
+// database holds the records of a full wallet.
+func TestWatchOnlyHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	db, err := database.New(filepath.Join(dir, "db"))
+	assert.NoError(err)
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	seed, err := GenerateNewSeed(nil)
+	assert.NoError(err)
+
+	keysJSON, err := KeysFromSeed(context.Background(), seed, seedKeyMaster{})
+	assert.NoError(err)
+
+	w, err := LoadFromSeed(byte(1), db, "password", filepath.Join(dir, "wallet.dat"), keysJSON)
+	assert.NoError(err)
+
+	assert.NoError(w.StoreTxRecord(DefaultAccount, transactions.RandTx(), 10, txrecords.Out))
+	assert.NoError(w.StoreTxRecord(DefaultAccount, transactions.RandTx(), 11, txrecords.In))
+
+	records, err := w.FetchTxHistory(DefaultAccount)
+	assert.NoError(err)
+	assert.Len(records, 2)
+
+	file := filepath.Join(dir, "watch.dat")
+	assert.NoError(SaveWatchOnly("password", file, keysJSON.PublicKey, keysJSON.ViewKey))
+
+	watch, err := LoadFromFile(byte(1), db, "password", file)
+	assert.NoError(err)
+
+	_, err = watch.FetchTxHistory(DefaultAccount)
+	assert.Equal(ErrWatchOnlyHistory, err)
+}
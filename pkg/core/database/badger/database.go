@@ -0,0 +1,208 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package badger
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	badgerdb "github.com/dgraph-io/badger/v2"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// gcInterval is the period of the value log garbage collection.
+	gcInterval = 10 * time.Minute
+
+	// gcDiscardRatio is the ratio of stale data above which a value log
+	// file is rewritten.
+	gcDiscardRatio = 0.5
+)
+
+var (
+	// See openStorage for detailed explanation.
+	_storage   *badgerdb.DB
+	_stopGC    chan struct{}
+	_storageMu sync.Mutex
+)
+
+// DB on top of underlying storage dgraph-io/badger.
+type DB struct {
+	// an alias to the global storage var.
+	storage *badgerdb.DB
+
+	// Read-only mode provided at badger.DB level. If true, accepts read-only
+	// Transaction.
+	readOnly bool
+
+	// Durability mode of the writes. See durability.go.
+	durability string
+}
+
+// openStorage is a wrapper around badger.Open to provide a singleton
+// badger.DB instance.
+//
+// As with leveldb, badger acquires a directory lock, so any subsequent attempt
+// to open the same path fails, even in read-only mode.
+func openStorage(path string, durability string) (*badgerdb.DB, error) {
+	_storageMu.Lock()
+	defer _storageMu.Unlock()
+
+	if _storage != nil {
+		return _storage, nil
+	}
+
+	opts := badgerdb.DefaultOptions(path).
+		WithSyncWrites(durability == heavy.DurabilityAll).
+		// Writes are serialized by the chain, and the heavy driver does not
+		// detect conflicts either.
+		WithDetectConflicts(false).
+		WithLogger(logger{log.WithField("process", "database")})
+
+	s, err := badgerdb.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	_storage = s
+	_stopGC = make(chan struct{})
+
+	go runValueLogGC(s, _stopGC)
+
+	return _storage, nil
+}
+
+// logger forwards the badger logs to logrus. The info logs of badger report
+// its compactions, and are logged at debug level.
+type logger struct {
+	*log.Entry
+}
+
+func (l logger) Infof(format string, args ...interface{}) {
+	l.Debugf(format, args...)
+}
+
+// runValueLogGC periodically reclaims the space of the deleted and
+// overwritten values, until stopped.
+func runValueLogGC(s *badgerdb.DB, stop chan struct{}) {
+	ticker := time.NewTicker(gcInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// A successful run may leave more files to rewrite
+			for {
+				if err := s.RunValueLogGC(gcDiscardRatio); err != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// closeStorage should safely close the underlying storage.
+func closeStorage() error {
+	_storageMu.Lock()
+	defer _storageMu.Unlock()
+
+	if _storage != nil {
+		close(_stopGC)
+
+		err := _storage.Close()
+		_storage = nil
+		return err
+	}
+
+	return errors.New("invalid storage")
+}
+
+// NewDatabase create or open backend storage (badger) located at the
+// specified path. Readonly option is pseudo read-only mode implemented by
+// badger.Database, as with the heavy driver.
+func NewDatabase(path string, network protocol.Magic, readonly bool) (database.DB, error) {
+	durability, err := durabilityFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := openStorage(path, durability)
+	if err != nil {
+		return nil, err
+	}
+
+	return DB{storage, readonly, durability}, nil
+}
+
+// Begin builds read-only or read-write Transaction.
+func (db DB) Begin(writable bool) (database.Transaction, error) {
+	// If the database was opened with DB.readonly flag true, we cannot create
+	// a writable transaction
+	if db.readOnly && writable {
+		return nil, errors.New("database is read-only")
+	}
+
+	// Exit if the database is not open yet.
+	if db.storage == nil {
+		return nil, errors.New("database is not open")
+	}
+
+	// Reads of a badger transaction are applied to a snapshot of the
+	// storage, together with the pending writes of the transaction. Writes
+	// are applied atomically on Commit. Mind Transaction.Close() must be
+	// called when Transaction is done
+	t := &transaction{
+		writable: writable,
+		db:       &db,
+		txn:      db.storage.NewTransaction(writable),
+	}
+
+	return t, nil
+}
+
+// Update a record within a transaction.
+func (db DB) Update(fn func(database.Transaction) error) error {
+	// Create a writable transaction for atomic update.
+	t, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+
+	defer t.Close()
+
+	// If an error is returned from the function then rollback and return error.
+	// rollback here simply means to skip the commit step
+	if err := fn(t); err != nil {
+		return err
+	}
+
+	return t.Commit()
+}
+
+// View is the equivalent of a Select SQL statement.
+func (db DB) View(fn func(database.Transaction) error) error {
+	t, err := db.Begin(false)
+	if err != nil {
+		return err
+	}
+
+	defer t.Close()
+	return fn(t)
+}
+
+// Close does not close the underlying storage as we need to reuse it within
+// another DB instances. The storage is closed by the driver.
+func (db DB) Close() error {
+	db.storage = nil
+	return nil
+}
@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package badger
+
+import (
+	"fmt"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+)
+
+// durabilityFromConfig returns the configured durability mode. The modes are
+// the ones of the heavy driver. With heavy.DurabilityAll, badger syncs every
+// write itself. With heavy.DurabilityTip, the storage is synced after the
+// commits moving the chain tip, or the height of the Rusk state.
+func durabilityFromConfig() (string, error) {
+	mode := cfg.Get().Database.Durability
+
+	switch mode {
+	case "":
+		return heavy.DurabilityNone, nil
+	case heavy.DurabilityNone, heavy.DurabilityTip, heavy.DurabilityAll:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown database durability mode %q", mode)
+	}
+}
+
+// syncTip syncs the storage if the committed transaction moved the tip, as
+// per the durability mode.
+func (db DB) syncTip(movesTip bool) error {
+	if db.durability != heavy.DurabilityTip || !movesTip {
+		return nil
+	}
+
+	return db.storage.Sync()
+}
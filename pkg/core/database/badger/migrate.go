@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package badger
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+)
+
+var errNotBadger = errors.New("migration target is not a badger database")
+
+// MigrateFromHeavy copies the records of a heavy storage into an empty badger
+// storage, and returns the number of copied records. As both drivers share
+// the same schema, the records are copied as they are.
+//
+// The chain state is written last, so that an interrupted migration never
+// leaves a partial chain behind. It can then be run again, once the target
+// folder is removed.
+func MigrateFromHeavy(src, dst database.DB) (uint64, error) {
+	bdb, ok := dst.(DB)
+	if !ok {
+		return 0, errNotBadger
+	}
+
+	err := dst.View(func(t database.Transaction) error {
+		_, err := t.FetchState()
+		return err
+	})
+	if err == nil {
+		return 0, heavy.ErrDatabaseNotEmpty
+	}
+
+	if err != database.ErrStateNotFound {
+		return 0, err
+	}
+
+	var (
+		copied uint64
+		state  []byte
+	)
+
+	wb := bdb.storage.NewWriteBatch()
+	defer wb.Cancel()
+
+	err = heavy.ForEachRecord(src, func(key, value []byte) error {
+		if bytes.Equal(key, heavy.StatePrefix) {
+			state = append([]byte{}, value...)
+			return nil
+		}
+
+		copied++
+
+		// The batch keeps the slices until it is flushed
+		return wb.Set(append([]byte{}, key...), append([]byte{}, value...))
+	})
+	if err != nil {
+		return copied, err
+	}
+
+	if err := wb.Flush(); err != nil {
+		return copied, err
+	}
+
+	if state == nil {
+		return copied, database.ErrStateNotFound
+	}
+
+	err = dst.Update(func(t database.Transaction) error {
+		return t.(*transaction).put(heavy.StatePrefix, state)
+	})
+	if err != nil {
+		return copied, err
+	}
+
+	return copied + 1, bdb.storage.Sync()
+}
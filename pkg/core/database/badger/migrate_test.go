@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package badger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+// openTempDB opens a storage of the given driver in a temporary folder.
+func openTempDB(t *testing.T, driverName string) (database.DB, func()) {
+	dir, err := ioutil.TempDir("", driverName+"_migrate_")
+	require.NoError(t, err)
+
+	drvr, err := database.From(driverName)
+	require.NoError(t, err)
+
+	db, err := drvr.Open(dir, protocol.DevNet, false)
+	require.NoError(t, err)
+
+	return db, func() {
+		_ = drvr.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestMigrateFromHeavy(t *testing.T) {
+	assert := require.New(t)
+
+	r := cfg.Get()
+	r.Database.TxIndex = true
+	cfg.Mock(&r)
+
+	defer func() {
+		r.Database.TxIndex = false
+		cfg.Mock(&r)
+	}()
+
+	src, closeSrc := openTempDB(t, heavy.DriverName)
+	defer closeSrc()
+
+	blocks := make([]*block.Block, 4)
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 2)
+	}
+
+	assert.NoError(src.Update(func(t database.Transaction) error {
+		for _, b := range blocks {
+			if err := t.StoreBlock(b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	dst, closeDst := openTempDB(t, DriverName)
+	defer closeDst()
+
+	copied, err := MigrateFromHeavy(src, dst)
+	assert.NoError(err)
+	assert.NotZero(copied)
+
+	var distributes int
+
+	assert.NoError(dst.View(func(t database.Transaction) error {
+		for _, b := range blocks {
+			stored, err := t.FetchBlock(b.Header.Hash)
+			assert.NoError(err)
+			assert.True(b.Equals(stored))
+
+			for _, tx := range b.Txs {
+				if tx.Type() == transactions.Distribute {
+					distributes++
+				}
+			}
+		}
+
+		height, err := t.FetchCurrentHeight()
+		assert.NoError(err)
+		assert.Equal(uint64(3), height)
+
+		// The secondary tx index is copied as well
+		locations, err := t.FetchTxsByType(transactions.Distribute, 0, 100)
+		assert.NoError(err)
+		assert.Len(locations, distributes)
+		return nil
+	}))
+
+	// The target must be empty
+	_, err = MigrateFromHeavy(src, dst)
+	assert.Equal(heavy.ErrDatabaseNotEmpty, err)
+
+	// The migrated chain keeps growing
+	next := helper.RandomBlock(4, 1)
+
+	assert.NoError(dst.Update(func(t database.Transaction) error {
+		return t.StoreBlock(next)
+	}))
+
+	assert.NoError(dst.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(next.Header.Hash)
+	}))
+
+	assert.NoError(dst.View(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		assert.NoError(err)
+		assert.Equal(blocks[3].Header.Hash, s.TipHash)
+		return nil
+	}))
+}
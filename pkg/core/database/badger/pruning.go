@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package badger
+
+import (
+	"bytes"
+
+	badgerdb "github.com/dgraph-io/badger/v2"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+)
+
+// pruneTxs deletes the transactions of the blocks lying more than the pruning
+// depth below the given tip height, as the heavy driver does. See
+// heavy/pruning.go.
+func (t *transaction) pruneTxs(tipHeight uint64) error {
+	depth := heavy.PruneDepth()
+	if depth == 0 || tipHeight <= depth {
+		return nil
+	}
+
+	from, err := t.fetchPrunedHeight()
+	if err != nil {
+		return err
+	}
+
+	to := tipHeight - depth
+	if to > from+heavy.MaxPruneBlocks {
+		to = from + heavy.MaxPruneBlocks
+	}
+
+	height := from
+	for ; height < to; height++ {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err == database.ErrBlockNotFound {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = t.iterate(append(heavy.TxPrefix, hash...), func(key, value []byte) error {
+			return t.delete(key)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if height == from {
+		return nil
+	}
+
+	return t.putPrunedHeight(height)
+}
+
+// isPruned returns true if the transactions of the block at the given height
+// were deleted by the pruning.
+func (t *transaction) isPruned(height uint64) (bool, error) {
+	pruned, err := t.fetchPrunedHeight()
+	if err != nil {
+		return false, err
+	}
+
+	return height < pruned, nil
+}
+
+// checkPruned returns ErrTxPruned if the transactions of the block were
+// deleted by the pruning.
+func (t *transaction) checkPruned(hashHeader []byte) error {
+	header, err := t.FetchBlockHeader(hashHeader)
+	if err != nil {
+		// Unknown blocks have no transactions to prune
+		return nil
+	}
+
+	pruned, err := t.isPruned(header.Height)
+	if err != nil {
+		return err
+	}
+
+	if pruned {
+		return database.ErrTxPruned
+	}
+
+	return nil
+}
+
+// fetchPrunedHeight returns the height below which the transactions are
+// pruned. It is zero if the pruning never ran.
+func (t *transaction) fetchPrunedHeight() (uint64, error) {
+	value, err := t.get(heavy.PrunedPrefix)
+	if err == badgerdb.ErrKeyNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewReader(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+func (t *transaction) putPrunedHeight(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	return t.put(heavy.PrunedPrefix, buf.Bytes())
+}
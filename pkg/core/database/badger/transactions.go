@@ -0,0 +1,880 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package badger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	badgerdb "github.com/dgraph-io/badger/v2"
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	log "github.com/sirupsen/logrus"
+)
+
+// The badger driver stores the chain with the Key-Value schema of the heavy
+// driver, so that a heavy storage is migrated by copying its records. See
+// heavy/transactions.go for the description of the keys.
+
+type transaction struct {
+	writable bool
+	db       *DB
+
+	// Get/Iterate calls see the storage snapshot taken on Begin, together
+	// with the Set/Delete calls of this transaction. The latter are applied
+	// to the storage atomically on Commit.
+	txn *badgerdb.Txn
+
+	// movesTip is set once the chain state or the executed height is put.
+	movesTip bool
+	closed   bool
+}
+
+// StoreBlock stores the entire block data into storage. No validations are
+// applied. Storage state changes only when Commit() is called on Transaction
+// completion.
+func (t *transaction) StoreBlock(b *block.Block) error {
+	if !t.writable {
+		return errors.New("StoreBlock cannot be called on read-only transaction")
+	}
+
+	if len(b.Header.Hash) != block.HeaderHashSize {
+		return fmt.Errorf("header hash size is %d but it must be %d", len(b.Header.Hash), block.HeaderHashSize)
+	}
+
+	// Key = HeaderPrefix + block.header.hash
+	// Value = encoded(block.fields)
+	blockHeaderFields := new(bytes.Buffer)
+	if err := message.MarshalHeader(blockHeaderFields, b.Header); err != nil {
+		return err
+	}
+
+	if err := t.put(append(heavy.HeaderPrefix, b.Header.Hash...), blockHeaderFields.Bytes()); err != nil {
+		return err
+	}
+
+	if uint64(len(b.Txs)) > math.MaxUint32 {
+		return errors.New("too many transactions")
+	}
+
+	for i, tx := range b.Txs {
+		txID, err := tx.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		if len(txID) == 0 {
+			return fmt.Errorf("empty chain tx id")
+		}
+
+		// Key = TxPrefix + block.header.hash + txID
+		// Value = index + block.transaction[index]
+		key := append(heavy.TxPrefix, b.Header.Hash...)
+		key = append(key, txID...)
+
+		entry, err := utils.EncodeBlockTx(tx, uint32(i))
+		if err != nil {
+			return err
+		}
+
+		if err := t.put(key, entry); err != nil {
+			return err
+		}
+
+		// Key = TxIDPrefix + txID
+		// Value = block.header.hash
+		if err := t.put(append(heavy.TxIDPrefix, txID...), b.Header.Hash); err != nil {
+			return err
+		}
+
+		if txIndexEnabled() {
+			for _, key := range heavy.TxIndexKeys(tx, b.Header.Height, uint32(i)) {
+				if err := t.put(key, txID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Key = HeightPrefix + block.header.height
+	// Value = block.header.hash
+	key, err := heightKey(b.Header.Height)
+	if err != nil {
+		return err
+	}
+
+	if err := t.put(key, b.Header.Hash); err != nil {
+		return err
+	}
+
+	// Key = StatePrefix
+	// Value = Hash(chain tip)
+	if err := t.put(heavy.StatePrefix, b.Header.Hash); err != nil {
+		return err
+	}
+
+	// Delete the transactions of the blocks below the pruning depth
+	if err := t.pruneTxs(b.Header.Height); err != nil {
+		return err
+	}
+
+	// Delete expired bid values
+	return t.iterate(heavy.BidValuesPrefix, func(key, value []byte) error {
+		if len(key) != 9 {
+			// Malformed key found, however we should not abort the entire
+			// operation just because of it. Let's remove it though, so that
+			// we don't keep logging errors for the same entry.
+			log.WithFields(log.Fields{
+				"process": "database",
+				"key":     key,
+			}).WithError(errors.New("bid values entry with malformed key found")).Errorln("error when iterating over bid values")
+
+			return t.delete(key)
+		}
+
+		if binary.LittleEndian.Uint64(key[1:]) < b.Header.Height {
+			return t.delete(key)
+		}
+
+		return nil
+	})
+}
+
+// DeleteBlock removes the chain tip from the storage. It reverts all the KV
+// pairs put by StoreBlock and points the chain state back at the block found
+// at the previous height.
+func (t *transaction) DeleteBlock(hash []byte) error {
+	if !t.writable {
+		return errors.New("DeleteBlock cannot be called on read-only transaction")
+	}
+
+	state, err := t.FetchState()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(state.TipHash, hash) {
+		return database.ErrNotChainTip
+	}
+
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	if header.Height == 0 {
+		return database.ErrGenesisDeletion
+	}
+
+	prevHash, err := t.FetchBlockHashByHeight(header.Height - 1)
+	if err != nil {
+		return err
+	}
+
+	// Delete block transactions together with their TxID and secondary index
+	// entries. The index entries are deleted whether the index is enabled or
+	// not, as in the heavy driver.
+	scanFilter := append(heavy.TxPrefix, hash...)
+
+	err = t.iterate(scanFilter, func(key, value []byte) error {
+		tx, txIndex, err := utils.DecodeBlockTx(value, database.AnyTxType)
+		if err != nil {
+			return err
+		}
+
+		for _, indexKey := range heavy.TxIndexKeys(tx, header.Height, txIndex) {
+			if err := t.delete(indexKey); err != nil {
+				return err
+			}
+		}
+
+		if err := t.delete(append(heavy.TxIDPrefix, key[len(scanFilter):]...)); err != nil {
+			return err
+		}
+
+		return t.delete(key)
+	})
+	if err != nil {
+		return err
+	}
+
+	key, err := heightKey(header.Height)
+	if err != nil {
+		return err
+	}
+
+	if err := t.delete(key); err != nil {
+		return err
+	}
+
+	if err := t.delete(append(heavy.HeaderPrefix, hash...)); err != nil {
+		return err
+	}
+
+	if err := t.delete(append(heavy.BlockParticipationPrefix, hash...)); err != nil {
+		return err
+	}
+
+	// A block stored again at this height comes with its transactions
+	pruned, err := t.isPruned(header.Height)
+	if err != nil {
+		return err
+	}
+
+	if pruned {
+		if err := t.putPrunedHeight(header.Height); err != nil {
+			return err
+		}
+	}
+
+	// Move the chain tip back
+	return t.put(heavy.StatePrefix, prevHash)
+}
+
+// Commit applies the writes of the transaction to the storage. Whether the
+// commit waits for fsync depends on the durability mode, see durability.go.
+func (t *transaction) Commit() error {
+	if !t.writable {
+		return errors.New("read-only transaction cannot commit changes")
+	}
+
+	if t.closed {
+		return errors.New("already closed transaction cannot commit changes")
+	}
+
+	if err := t.txn.Commit(); err != nil {
+		return err
+	}
+
+	return t.db.syncTip(t.movesTip)
+}
+
+// Rollback is not used by database layer.
+func (t *transaction) Rollback() error {
+	t.txn.Discard()
+	return nil
+}
+
+// Close discards the transaction. It must be called explicitly when a
+// transaction is run in a unmanaged way.
+func (t *transaction) Close() {
+	t.txn.Discard()
+	t.closed = true
+}
+
+func (t *transaction) FetchBlockExists(hash []byte) (bool, error) {
+	exists, err := t.has(append(heavy.HeaderPrefix, hash...))
+	if !exists && err == nil {
+		err = database.ErrBlockNotFound
+	}
+
+	return exists, err
+}
+
+// FetchOutputExists checks if an output exists in the db.
+func (t *transaction) FetchOutputExists(destkey []byte) (bool, error) {
+	exists, err := t.has(append(heavy.OutputKeyPrefix, destkey...))
+	if !exists && err == nil {
+		err = database.ErrOutputNotFound
+	}
+
+	return exists, err
+}
+
+// FetchOutputUnlockHeight returns the unlockheight of an output.
+func (t *transaction) FetchOutputUnlockHeight(destkey []byte) (uint64, error) {
+	unlockHeightBytes, err := t.get(append(heavy.OutputKeyPrefix, destkey...))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(unlockHeightBytes) != 8 {
+		return 0, errors.New("unlock height malformed")
+	}
+
+	return binary.LittleEndian.Uint64(unlockHeightBytes[0:8]), nil
+}
+
+func (t *transaction) FetchBlockHeader(hash []byte) (*block.Header, error) {
+	value, err := t.get(append(heavy.HeaderPrefix, hash...))
+	if err == badgerdb.ErrKeyNotFound {
+		err = database.ErrBlockNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	header := block.NewHeader()
+	if err := message.UnmarshalHeader(bytes.NewBuffer(value), header); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (t *transaction) FetchBlockTxs(hashHeader []byte) ([]transactions.ContractCall, error) {
+	tempTxs := make(map[uint32]transactions.ContractCall)
+
+	err := t.iterate(append(heavy.TxPrefix, hashHeader...), func(key, value []byte) error {
+		tx, txIndex, err := utils.DecodeBlockTx(value, database.AnyTxType)
+		if err != nil {
+			return err
+		}
+
+		// If we don't fetch the correct indexes (tx positions), merkle tree
+		// changes and as result we've got new block hash
+		if _, ok := tempTxs[txIndex]; ok {
+			return errors.New("duplicated tx index")
+		}
+
+		tempTxs[txIndex] = tx
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every block holds at least the coinbase tx
+	if len(tempTxs) == 0 {
+		if err := t.checkPruned(hashHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reorder Tx slice as per retrieved indexes
+	resultTxs := make([]transactions.ContractCall, len(tempTxs))
+	for k, v := range tempTxs {
+		resultTxs[k] = v
+	}
+
+	// NOTE: coinbase is the last tx in the block
+	if len(resultTxs) > 0 && resultTxs[len(resultTxs)-1].Type() != transactions.Distribute {
+		return resultTxs, errors.New("missing coinbase tx")
+	}
+
+	return resultTxs, nil
+}
+
+func (t *transaction) FetchBlockHashByHeight(height uint64) ([]byte, error) {
+	key, err := heightKey(height)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := t.get(key)
+	if err == badgerdb.ErrKeyNotFound {
+		err = database.ErrBlockNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (t *transaction) FetchBlockTxByHash(txID []byte) (transactions.ContractCall, uint32, []byte, error) {
+	txIndex := uint32(math.MaxUint32)
+
+	// Fetch the block header hash that this Tx belongs to
+	hashHeader, err := t.get(append(heavy.TxIDPrefix, txID...))
+	if err == badgerdb.ErrKeyNotFound {
+		err = database.ErrTxNotFound
+	}
+
+	if err != nil {
+		return nil, txIndex, nil, err
+	}
+
+	// Key = TxPrefix + block.header.hash + txID
+	key := append(heavy.TxPrefix, hashHeader...)
+	key = append(key, txID...)
+
+	value, err := t.get(key)
+	if err == badgerdb.ErrKeyNotFound {
+		if err := t.checkPruned(hashHeader); err != nil {
+			return nil, txIndex, hashHeader, err
+		}
+
+		return nil, txIndex, nil, errors.New("block tx is available but fetching it fails")
+	}
+
+	if err != nil {
+		return nil, txIndex, nil, err
+	}
+
+	tx, idx, err := utils.DecodeBlockTx(value, database.AnyTxType)
+	if err != nil {
+		return nil, idx, hashHeader, err
+	}
+
+	return tx, idx, hashHeader, nil
+}
+
+// FetchKeyImageExists checks if the KeyImage exists. If so, it also returns the
+// hash of its corresponding tx.
+func (t *transaction) FetchKeyImageExists(keyImage []byte) (bool, []byte, error) {
+	txID, err := t.get(append(heavy.KeyImagePrefix, keyImage...))
+	if err == badgerdb.ErrKeyNotFound {
+		err = database.ErrKeyImageNotFound
+	}
+
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, txID, nil
+}
+
+func (t *transaction) FetchBlock(hash []byte) (*block.Block, error) {
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := t.FetchBlockTxs(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.Block{
+		Header: header,
+		Txs:    txs,
+	}, nil
+}
+
+func (t *transaction) FetchState() (*database.State, error) {
+	value, err := t.get(heavy.StatePrefix)
+	if err == badgerdb.ErrKeyNotFound || (err == nil && len(value) == 0) {
+		err = database.ErrStateNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &database.State{TipHash: value}, nil
+}
+
+// StoreExecutedHeight stores the height of the last block executed on the
+// Rusk state.
+func (t *transaction) StoreExecutedHeight(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	return t.put(heavy.ExecutedHeightPrefix, buf.Bytes())
+}
+
+func (t *transaction) FetchExecutedHeight() (uint64, error) {
+	value, err := t.get(heavy.ExecutedHeightPrefix)
+	if err == badgerdb.ErrKeyNotFound {
+		err = database.ErrStateNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewReader(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+func (t *transaction) FetchCurrentHeight() (uint64, error) {
+	state, err := t.FetchState()
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := t.FetchBlockHeader(state.TipHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Height, nil
+}
+
+func (t *transaction) StoreBidValues(d, k []byte, index uint64, lockTime uint64) error {
+	currentHeight, err := t.FetchCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	// NOTE: this expiry height is not accurate, and is just an
+	// approximation, as in the heavy driver.
+	heightBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightBytes, lockTime+currentHeight)
+
+	idxBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(idxBytes, index)
+
+	key := append(heavy.BidValuesPrefix, heightBytes...)
+	return t.put(key, append(d, append(k, idxBytes...)...))
+}
+
+func (t *transaction) FetchBidValues() ([]byte, []byte, uint64, error) {
+	// Let's always return the bid values with the lowest height as
+	// those are most likely to be valid.
+	lowestSeen := uint64(1<<64 - 1)
+
+	var value []byte
+
+	err := t.iterate(heavy.BidValuesPrefix, func(key, v []byte) error {
+		if len(key) != 9 {
+			log.WithFields(log.Fields{
+				"process": "database",
+				"key":     key,
+			}).WithError(errors.New("bid values entry with malformed key found")).Errorln("error when iterating over bid values")
+			return nil
+		}
+
+		if height := binary.LittleEndian.Uint64(key[1:]); height < lowestSeen {
+			lowestSeen = height
+			value = v
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, uint64(0), err
+	}
+
+	if len(value) != heavy.BidEncodingSize {
+		return nil, nil, uint64(0), fmt.Errorf("bid values non-existent or incorrectly encoded, expected %d bytes but found %d", heavy.BidEncodingSize, len(value))
+	}
+
+	D := value[0:32]
+	K := value[32:64]
+	index := binary.LittleEndian.Uint64(value[64:72])
+	return D, K, index, nil
+}
+
+// FetchBlockHeightSince uses binary search to find a block height.
+func (t *transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (uint64, error) {
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	n := uint64(math.Min(float64(tip), float64(offset)))
+
+	pos, err := utils.Search(n, func(pos uint64) (bool, error) {
+		height := tip - n + pos
+
+		hash, heightErr := t.FetchBlockHashByHeight(height)
+		if heightErr != nil {
+			return false, heightErr
+		}
+
+		header, blockHdrErr := t.FetchBlockHeader(hash)
+		if blockHdrErr != nil {
+			return false, blockHdrErr
+		}
+
+		return header.Timestamp >= sinceUnixTime, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return tip - n + pos, nil
+}
+
+func (t *transaction) StoreCandidateMessage(cm block.Block) error {
+	buf := new(bytes.Buffer)
+	if err := message.MarshalBlock(buf, &cm); err != nil {
+		return err
+	}
+
+	return t.put(append(heavy.CandidatePrefix, cm.Header.Hash...), buf.Bytes())
+}
+
+func (t *transaction) FetchCandidateMessage(hash []byte) (block.Block, error) {
+	value, err := t.get(append(heavy.CandidatePrefix, hash...))
+	if err != nil {
+		return block.Block{}, database.ErrBlockNotFound
+	}
+
+	cm := block.NewBlock()
+	if err := message.UnmarshalBlock(bytes.NewBuffer(value), cm); err != nil {
+		return block.Block{}, err
+	}
+
+	return *cm, nil
+}
+
+func (t *transaction) ClearCandidateMessages() error {
+	return t.iterate(heavy.CandidatePrefix, func(key, value []byte) error {
+		return t.delete(key)
+	})
+}
+
+// StoreEvidence stores the evidence of a provisioner equivocation under its ID.
+func (t *transaction) StoreEvidence(e message.Evidence) error {
+	id, err := e.ID()
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalEvidence(buf, e); err != nil {
+		return err
+	}
+
+	return t.put(append(heavy.EvidencePrefix, id...), buf.Bytes())
+}
+
+// FetchEvidence returns all of the stored evidence.
+func (t *transaction) FetchEvidence() ([]message.Evidence, error) {
+	evidence := make([]message.Evidence, 0)
+
+	err := t.iterate(heavy.EvidencePrefix, func(key, value []byte) error {
+		var e message.Evidence
+		if err := message.UnmarshalEvidence(bytes.NewBuffer(value), &e); err != nil {
+			return err
+		}
+
+		evidence = append(evidence, e)
+		return nil
+	})
+
+	return evidence, err
+}
+
+// DeleteEvidence deletes the stored evidence with the ID of e.
+func (t *transaction) DeleteEvidence(e message.Evidence) error {
+	id, err := e.ID()
+	if err != nil {
+		return err
+	}
+
+	return t.delete(append(heavy.EvidencePrefix, id...))
+}
+
+// StoreParticipation stores the participation counters of a provisioner
+// under its BLS public key.
+func (t *transaction) StoreParticipation(p database.Participation) error {
+	value, err := utils.EncodeParticipation(p)
+	if err != nil {
+		return err
+	}
+
+	return t.put(append(heavy.ParticipationPrefix, p.PubKeyBLS...), value)
+}
+
+// FetchParticipation returns the participation counters of a provisioner.
+func (t *transaction) FetchParticipation(pubKeyBLS []byte) (database.Participation, error) {
+	value, err := t.get(append(heavy.ParticipationPrefix, pubKeyBLS...))
+	if err == badgerdb.ErrKeyNotFound {
+		return database.Participation{}, database.ErrParticipationNotFound
+	}
+
+	if err != nil {
+		return database.Participation{}, err
+	}
+
+	return utils.DecodeParticipation(value)
+}
+
+// FetchAllParticipation returns the participation counters of all
+// provisioners, in key order.
+func (t *transaction) FetchAllParticipation() ([]database.Participation, error) {
+	all := make([]database.Participation, 0)
+
+	err := t.iterate(heavy.ParticipationPrefix, func(key, value []byte) error {
+		p, err := utils.DecodeParticipation(value)
+		if err != nil {
+			return err
+		}
+
+		all = append(all, p)
+		return nil
+	})
+
+	return all, err
+}
+
+// StoreBlockParticipation stores the participation counted in the
+// certificate of a block under the block hash.
+func (t *transaction) StoreBlockParticipation(hash []byte, p []database.Participation) error {
+	value, err := utils.EncodeParticipationList(p)
+	if err != nil {
+		return err
+	}
+
+	return t.put(append(heavy.BlockParticipationPrefix, hash...), value)
+}
+
+// FetchBlockParticipation returns the participation counted in the
+// certificate of a block.
+func (t *transaction) FetchBlockParticipation(hash []byte) ([]database.Participation, error) {
+	value, err := t.get(append(heavy.BlockParticipationPrefix, hash...))
+	if err == badgerdb.ErrKeyNotFound {
+		return nil, database.ErrParticipationNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.DecodeParticipationList(value)
+}
+
+// ClearDatabase will wipe all of the data currently in the database.
+func (t *transaction) ClearDatabase() error {
+	return t.iterate(nil, func(key, value []byte) error {
+		return t.delete(key)
+	})
+}
+
+func (t *transaction) FetchTxsByOutputKey(pubKey []byte, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	if len(pubKey) != heavy.OutputKeySize {
+		return nil, fmt.Errorf("output public key size is %d but it must be %d", len(pubKey), heavy.OutputKeySize)
+	}
+
+	return t.fetchTxLocations(append(heavy.OutputIndexPrefix, pubKey...), fromHeight, limit)
+}
+
+func (t *transaction) FetchTxsByType(txType transactions.TxType, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, uint32(txType))
+
+	return t.fetchTxLocations(append(heavy.TypeIndexPrefix, key...), fromHeight, limit)
+}
+
+// fetchTxLocations iterates the secondary index entries under the prefix,
+// from the given height onward.
+func (t *transaction) fetchTxLocations(prefix []byte, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	if !txIndexEnabled() {
+		return nil, database.ErrTxIndexDisabled
+	}
+
+	start := make([]byte, len(prefix)+8)
+	copy(start, prefix)
+	binary.BigEndian.PutUint64(start[len(prefix):], fromHeight)
+
+	it := t.txn.NewIterator(badgerdb.IteratorOptions{Prefix: prefix, PrefetchValues: false})
+	defer it.Close()
+
+	locations := make([]database.TxLocation, 0)
+
+	for it.Seek(start); it.ValidForPrefix(prefix) && len(locations) < limit; it.Next() {
+		key := it.Item().Key()
+		if len(key) != len(prefix)+12 {
+			return nil, errors.New("malformed tx index entry")
+		}
+
+		txID, err := it.Item().ValueCopy(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		locations = append(locations, database.TxLocation{
+			Height:  binary.BigEndian.Uint64(key[len(prefix):]),
+			TxIndex: binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			TxID:    txID,
+		})
+	}
+
+	return locations, nil
+}
+
+func txIndexEnabled() bool {
+	return cfg.Get().Database.TxIndex
+}
+
+// heightKey returns the key of the block hash at the given height.
+func heightKey(height uint64) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return nil, err
+	}
+
+	return append(heavy.HeightPrefix, buf.Bytes()...), nil
+}
+
+func (t *transaction) get(key []byte) ([]byte, error) {
+	item, err := t.txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ValueCopy(nil)
+}
+
+func (t *transaction) has(key []byte) (bool, error) {
+	_, err := t.txn.Get(key)
+	if err == badgerdb.ErrKeyNotFound {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (t *transaction) put(key []byte, value []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction cannot store data")
+	}
+
+	if bytes.Equal(key, heavy.StatePrefix) || bytes.Equal(key, heavy.ExecutedHeightPrefix) {
+		t.movesTip = true
+	}
+
+	return t.txn.Set(key, value)
+}
+
+func (t *transaction) delete(key []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction cannot delete data")
+	}
+
+	return t.txn.Delete(key)
+}
+
+// iterate calls fn with a copy of the key and value of the entries under the
+// prefix, in key order. The entries are read before fn is called, so that fn
+// can iterate and write as well.
+func (t *transaction) iterate(prefix []byte, fn func(key, value []byte) error) error {
+	var keys, values [][]byte
+
+	it := t.txn.NewIterator(badgerdb.IteratorOptions{Prefix: prefix, PrefetchValues: true, PrefetchSize: 100})
+
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		value, err := it.Item().ValueCopy(nil)
+		if err != nil {
+			it.Close()
+			return err
+		}
+
+		keys = append(keys, it.Item().KeyCopy(nil))
+		values = append(values, value)
+	}
+
+	it.Close()
+
+	for i := range keys {
+		if err := fn(keys[i], values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
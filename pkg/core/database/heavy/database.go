@@ -29,6 +29,9 @@ type DB struct {
 
 	// Read-only mode provided at heavy.DB level. If true, accepts read-only Transaction.
 	readOnly bool
+
+	// Durability mode of the writes. See durability.go.
+	durability string
 }
 
 // openStorage is a wrapper around leveldb.OpenFile to provide singleton
@@ -83,12 +86,17 @@ func closeStorage() error {
 // specified path. Readonly option is pseudo read-only mode implemented by
 // heavy.Database. Not to be confused with read-only goleveldb mode.
 func NewDatabase(path string, network protocol.Magic, readonly bool) (database.DB, error) {
+	durability, err := durabilityFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	storage, err := openStorage(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return DB{storage, readonly}, nil
+	return DB{storage, readonly, durability}, nil
 }
 
 // Begin builds read-only or read-write Transaction.
@@ -194,3 +202,31 @@ func (db DB) Close() error {
 func (db DB) GetSnapshot() (*leveldb.Snapshot, error) {
 	return db.storage.GetSnapshot()
 }
+
+// ForEachRecord calls fn with each key-value record of the storage, read from
+// a single storage snapshot. The key and value are only valid until fn
+// returns. It is used to migrate the storage to another driver.
+func ForEachRecord(db database.DB, fn func(key, value []byte) error) error {
+	hdb, ok := db.(DB)
+	if !ok {
+		return errNotHeavy
+	}
+
+	snap, err := hdb.storage.GetSnapshot()
+	if err != nil {
+		return err
+	}
+
+	defer snap.Release()
+
+	iterator := snap.NewIterator(nil, nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		if err := fn(iterator.Key(), iterator.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iterator.Error()
+}
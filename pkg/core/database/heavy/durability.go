@@ -0,0 +1,81 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"fmt"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// Durability modes, set with database.durability. They define which writes
+// wait for fsync. Without fsync, a machine crash may lose the recent writes.
+// Note that if it is just the process that crashes (and the machine does
+// not) then no writes will be lost.
+const (
+	// DurabilityNone never waits for fsync, in sake of faster writes.
+	DurabilityNone = "none"
+	// DurabilityTip waits for fsync on the writes moving the chain tip, or
+	// the height of the Rusk state. The chain tip then never points at a
+	// lost block.
+	DurabilityTip = "tip"
+	// DurabilityAll waits for fsync on every write.
+	DurabilityAll = "all"
+)
+
+// durabilityFromConfig returns the configured durability mode. It defaults to
+// DurabilityNone.
+func durabilityFromConfig() (string, error) {
+	mode := cfg.Get().Database.Durability
+
+	switch mode {
+	case "":
+		return DurabilityNone, nil
+	case DurabilityNone, DurabilityTip, DurabilityAll:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown database durability mode %q", mode)
+	}
+}
+
+// writeOptions returns the options of a write, as per the durability mode.
+func (db DB) writeOptions(movesTip bool) *opt.WriteOptions {
+	switch db.durability {
+	case DurabilityAll:
+		return syncWriteOptions
+	case DurabilityTip:
+		if movesTip {
+			return syncWriteOptions
+		}
+	}
+
+	return asyncWriteOptions
+}
+
+// movesTip returns true if the batch updates the chain state or the executed
+// height.
+func movesTip(batch *leveldb.Batch) bool {
+	r := new(tipReplay)
+	_ = batch.Replay(r)
+	return r.found
+}
+
+// tipReplay looks for the chain state and executed height keys in a batch.
+type tipReplay struct {
+	found bool
+}
+
+func (r *tipReplay) Put(key, value []byte) {
+	if bytes.Equal(key, StatePrefix) || bytes.Equal(key, ExecutedHeightPrefix) {
+		r.found = true
+	}
+}
+
+func (r *tipReplay) Delete(key []byte) {}
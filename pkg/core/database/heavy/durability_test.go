@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestDurability(t *testing.T) {
+	assert := require.New(t)
+
+	r := cfg.Get()
+
+	defer func() {
+		r.Database.Durability = ""
+		cfg.Mock(&r)
+	}()
+
+	tip := new(leveldb.Batch)
+	tip.Put(HeaderPrefix, []byte{1})
+	tip.Put(StatePrefix, []byte{1})
+
+	executed := new(leveldb.Batch)
+	executed.Put(ExecutedHeightPrefix, []byte{1})
+
+	other := new(leveldb.Batch)
+	other.Put(CandidatePrefix, []byte{1})
+	other.Delete(StatePrefix)
+
+	assert.True(movesTip(tip))
+	assert.True(movesTip(executed))
+	assert.False(movesTip(other))
+
+	for mode, synced := range map[string][]bool{
+		"":             {false, false},
+		DurabilityNone: {false, false},
+		DurabilityTip:  {true, false},
+		DurabilityAll:  {true, true},
+	} {
+		r.Database.Durability = mode
+		cfg.Mock(&r)
+
+		durability, err := durabilityFromConfig()
+		assert.NoError(err)
+
+		db := DB{durability: durability}
+		assert.Equal(synced[0], db.writeOptions(movesTip(tip)).Sync, mode)
+		assert.Equal(synced[1], db.writeOptions(movesTip(other)).Sync, mode)
+	}
+
+	r.Database.Durability = "always"
+	cfg.Mock(&r)
+
+	// The mode is checked before opening the storage
+	_, err := NewDatabase("", protocol.DevNet, false)
+	assert.Error(err)
+}
@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// MaxPruneBlocks is the maximum number of blocks pruned on each StoreBlock,
+// so that enabling the pruning on a long chain does not produce a huge batch.
+// The pruning catches up with the configured depth over the next blocks.
+const MaxPruneBlocks = 100
+
+// PruneDepth returns the configured pruning depth. Zero disables the pruning.
+// The transactions of the blocks which can be reverted by a reorganisation
+// are always kept.
+func PruneDepth() uint64 {
+	depth := cfg.Get().Database.PruneDepth
+	if depth != 0 && depth < cfg.MaxReorgDepth {
+		depth = cfg.MaxReorgDepth
+	}
+
+	return depth
+}
+
+// pruneTxs deletes the transactions of the blocks lying more than the pruning
+// depth below the given tip height. Headers, TxID and KeyImage indices are
+// kept, so that lookups can tell pruned transactions apart from unknown ones.
+//
+// Key = PrunedPrefix
+// Value = height below which the transactions are pruned
+func (t transaction) pruneTxs(tipHeight uint64) error {
+	depth := PruneDepth()
+	if depth == 0 || tipHeight <= depth {
+		return nil
+	}
+
+	from, err := t.fetchPrunedHeight()
+	if err != nil {
+		return err
+	}
+
+	to := tipHeight - depth
+	if to > from+MaxPruneBlocks {
+		to = from + MaxPruneBlocks
+	}
+
+	height := from
+	for ; height < to; height++ {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err == database.ErrBlockNotFound {
+			// Blocks stored within this same transaction are not visible
+			// yet. They get pruned on the next StoreBlock.
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		iterator := t.snapshot.NewIterator(util.BytesPrefix(append(TxPrefix, hash...)), nil)
+
+		for iterator.Next() {
+			t.batch.Delete(append([]byte{}, iterator.Key()...))
+		}
+
+		iterator.Release()
+
+		if err := iterator.Error(); err != nil {
+			return err
+		}
+	}
+
+	if height == from {
+		return nil
+	}
+
+	return t.putPrunedHeight(height)
+}
+
+// isPruned returns true if the transactions of the block at the given height
+// were deleted by the pruning.
+func (t transaction) isPruned(height uint64) (bool, error) {
+	pruned, err := t.fetchPrunedHeight()
+	if err != nil {
+		return false, err
+	}
+
+	return height < pruned, nil
+}
+
+// checkPruned returns ErrTxPruned if the transactions of the block were
+// deleted by the pruning.
+func (t transaction) checkPruned(hashHeader []byte) error {
+	header, err := t.FetchBlockHeader(hashHeader)
+	if err != nil {
+		// Unknown blocks have no transactions to prune
+		return nil
+	}
+
+	pruned, err := t.isPruned(header.Height)
+	if err != nil {
+		return err
+	}
+
+	if pruned {
+		return database.ErrTxPruned
+	}
+
+	return nil
+}
+
+// fetchPrunedHeight returns the height below which the transactions are
+// pruned. It is zero if the pruning never ran.
+func (t transaction) fetchPrunedHeight() (uint64, error) {
+	value, err := t.snapshot.Get(PrunedPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewReader(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+func (t transaction) putPrunedHeight(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.put(PrunedPrefix, buf.Bytes())
+	return nil
+}
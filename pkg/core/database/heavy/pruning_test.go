@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneTxs(t *testing.T) {
+	assert := require.New(t)
+
+	r := cfg.Get()
+	r.Database.PruneDepth = 10
+	cfg.Mock(&r)
+
+	defer func() {
+		r.Database.PruneDepth = 0
+		cfg.Mock(&r)
+	}()
+
+	db, closeDB := openTempDB(t)
+	defer closeDB()
+
+	// The depth is raised to the maximum reorganisation depth
+	tip := uint64(cfg.MaxReorgDepth + 5)
+
+	blocks := make([]*block.Block, tip+1)
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 1)
+
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blocks[i])
+		}))
+	}
+
+	txID, err := blocks[0].Txs[0].CalculateHash()
+	assert.NoError(err)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		// Blocks more than MaxReorgDepth below the tip are pruned
+		for _, b := range blocks[:5] {
+			_, err := t.FetchBlockTxs(b.Header.Hash)
+			assert.Equal(database.ErrTxPruned, err)
+
+			// Headers are kept
+			_, err = t.FetchBlockHeader(b.Header.Hash)
+			assert.NoError(err)
+		}
+
+		for _, b := range blocks[5:] {
+			txs, err := t.FetchBlockTxs(b.Header.Hash)
+			assert.NoError(err)
+			assert.Len(txs, len(b.Txs))
+		}
+
+		_, _, hash, err := t.FetchBlockTxByHash(txID)
+		assert.Equal(database.ErrTxPruned, err)
+		assert.Equal(blocks[0].Header.Hash, hash)
+
+		_, _, _, err = t.FetchBlockTxByHash([]byte{1, 2, 3})
+		assert.Equal(database.ErrTxNotFound, err)
+		return nil
+	}))
+}
+
+func TestPruningDisabled(t *testing.T) {
+	assert := require.New(t)
+
+	db, closeDB := openTempDB(t)
+	defer closeDB()
+
+	blocks := make([]*block.Block, cfg.MaxReorgDepth+5)
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 1)
+
+		assert.NoError(db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(blocks[i])
+		}))
+	}
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		txs, err := t.FetchBlockTxs(blocks[0].Header.Hash)
+		assert.NoError(err)
+		assert.Len(txs, len(blocks[0].Txs))
+		return nil
+	}))
+}
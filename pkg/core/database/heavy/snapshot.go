@@ -0,0 +1,404 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// SnapshotVersion is the version of the snapshot archive format.
+const SnapshotVersion uint32 = 1
+
+const (
+	// importBatchSize is the number of records written at once while
+	// importing a snapshot.
+	importBatchSize = 4096
+
+	// maxRecordSize bounds the size of a snapshot record, so that a corrupted
+	// length does not make the import allocate gigabytes.
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+var (
+	snapshotMagic = []byte("DUSKSNAP")
+
+	// snapshotPrefixes are the prefixes of the records making up the chain,
+	// along with the secondary tx index and the participation counters,
+	// which are derived from it. Bid values and evidence are private to the
+	// node, and candidate blocks are only relevant to the consensus round
+	// they were produced in. The executed height belongs to the Rusk state,
+	// which is restored separately.
+	snapshotPrefixes = [][]byte{
+		HeaderPrefix,
+		TxPrefix,
+		HeightPrefix,
+		TxIDPrefix,
+		KeyImagePrefix,
+		StatePrefix,
+		OutputKeyPrefix,
+		PrunedPrefix,
+		OutputIndexPrefix,
+		TypeIndexPrefix,
+		ParticipationPrefix,
+		BlockParticipationPrefix,
+	}
+
+	// ErrSnapshotChecksum is returned when the checksum of a snapshot does
+	// not match its contents.
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+
+	// ErrDatabaseNotEmpty is returned when importing a snapshot into a
+	// database which already holds a chain.
+	ErrDatabaseNotEmpty = errors.New("database already holds a chain")
+
+	errNotHeavy = errors.New("operation only supported by the heavy driver")
+)
+
+// SnapshotHeader describes the chain archived by a snapshot.
+type SnapshotHeader struct {
+	Version uint32
+	Network protocol.Magic
+	Height  uint64
+	TipHash []byte
+
+	// Provisioners is the provisioner set after accepting the tip block.
+	Provisioners user.Provisioners
+}
+
+// ExportSnapshot writes the chain stored in the database to w, together with
+// the provisioner set at the chain tip. The records are read from a single
+// leveldb snapshot, so that the archive is consistent with its header.
+//
+// The archive consists of the header, followed by the key/value records,
+// and terminated by the SHA-256 checksum of all the preceding bytes.
+func ExportSnapshot(db database.DB, w io.Writer, network protocol.Magic, p *user.Provisioners) (*SnapshotHeader, error) {
+	if _, ok := db.(DB); !ok {
+		return nil, errNotHeavy
+	}
+
+	if p == nil {
+		p = user.NewProvisioners()
+	}
+
+	var hdr *SnapshotHeader
+
+	err := db.View(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		if err != nil {
+			return err
+		}
+
+		tip, err := t.FetchBlockHeader(s.TipHash)
+		if err != nil {
+			return err
+		}
+
+		hdr = &SnapshotHeader{
+			Version:      SnapshotVersion,
+			Network:      network,
+			Height:       tip.Height,
+			TipHash:      s.TipHash,
+			Provisioners: *p,
+		}
+
+		bw := bufio.NewWriter(w)
+		h := sha256.New()
+		sw := io.MultiWriter(bw, h)
+
+		if err := writeSnapshotHeader(sw, hdr); err != nil {
+			return err
+		}
+
+		snap := t.(*transaction).snapshot
+
+		for _, prefix := range snapshotPrefixes {
+			if err := writeRecords(sw, snap, prefix); err != nil {
+				return err
+			}
+		}
+
+		// End of the records
+		if err := writeBytes(sw, nil); err != nil {
+			return err
+		}
+
+		if _, err := bw.Write(h.Sum(nil)); err != nil {
+			return err
+		}
+
+		return bw.Flush()
+	})
+
+	return hdr, err
+}
+
+// ImportSnapshot loads a snapshot archive into an empty database, and returns
+// its header. The chain state is only written once the checksum is verified,
+// and the records are removed again on failure, so that an interrupted or
+// corrupted import leaves the database without a chain.
+func ImportSnapshot(db database.DB, r io.Reader, network protocol.Magic) (*SnapshotHeader, error) {
+	hdb, ok := db.(DB)
+	if !ok {
+		return nil, errNotHeavy
+	}
+
+	if _, err := hdb.storage.Get(StatePrefix, nil); err != leveldb.ErrNotFound {
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, ErrDatabaseNotEmpty
+	}
+
+	h := sha256.New()
+	sr := io.TeeReader(bufio.NewReader(r), h)
+
+	hdr, err := readSnapshotHeader(sr)
+	if err != nil {
+		return nil, err
+	}
+
+	if hdr.Network != network {
+		return nil, fmt.Errorf("snapshot of network %d cannot be imported on network %d", hdr.Network, network)
+	}
+
+	state, err := importRecords(hdb, sr, h)
+	if err != nil {
+		hdb.clearSnapshot()
+		return nil, err
+	}
+
+	if err := hdb.storage.Put(StatePrefix, state, hdb.writeOptions(true)); err != nil {
+		hdb.clearSnapshot()
+		return nil, err
+	}
+
+	return hdr, nil
+}
+
+// importRecords writes the records of the archive, but the chain state, and
+// verifies the checksum. It returns the chain state.
+func importRecords(hdb DB, r io.Reader, h hash.Hash) ([]byte, error) {
+	var state []byte
+
+	batch := new(leveldb.Batch)
+
+	for {
+		key, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(key) == 0 {
+			break
+		}
+
+		value, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if !validSnapshotKey(key) {
+			return nil, fmt.Errorf("unexpected record with prefix %#x", key[0])
+		}
+
+		if bytes.Equal(key, StatePrefix) {
+			state = value
+			continue
+		}
+
+		batch.Put(key, value)
+
+		if batch.Len() >= importBatchSize {
+			if err := hdb.storage.Write(batch, asyncWriteOptions); err != nil {
+				return nil, err
+			}
+
+			batch.Reset()
+		}
+	}
+
+	if err := hdb.storage.Write(batch, asyncWriteOptions); err != nil {
+		return nil, err
+	}
+
+	sum := h.Sum(nil)
+
+	checksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r, checksum); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(sum, checksum) {
+		return nil, ErrSnapshotChecksum
+	}
+
+	if state == nil {
+		return nil, errors.New("snapshot without chain state")
+	}
+
+	return state, nil
+}
+
+// clearSnapshot removes the records left behind by a failed import.
+func (db DB) clearSnapshot() {
+	for _, prefix := range snapshotPrefixes {
+		batch := new(leveldb.Batch)
+
+		iter := db.storage.NewIterator(util.BytesPrefix(prefix), nil)
+		for iter.Next() {
+			batch.Delete(iter.Key())
+		}
+
+		iter.Release()
+
+		_ = db.storage.Write(batch, asyncWriteOptions)
+	}
+}
+
+func writeRecords(w io.Writer, snap *leveldb.Snapshot, prefix []byte) error {
+	iter := snap.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := writeBytes(w, iter.Key()); err != nil {
+			return err
+		}
+
+		if err := writeBytes(w, iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func writeSnapshotHeader(w io.Writer, hdr *SnapshotHeader) error {
+	if _, err := w.Write(snapshotMagic); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, hdr.Version); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, uint8(hdr.Network)); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, hdr.Height); err != nil {
+		return err
+	}
+
+	if err := writeBytes(w, hdr.TipHash); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, &hdr.Provisioners); err != nil {
+		return err
+	}
+
+	return writeBytes(w, buf.Bytes())
+}
+
+func readSnapshotHeader(r io.Reader) (*SnapshotHeader, error) {
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(magic, snapshotMagic) {
+		return nil, errors.New("not a snapshot archive")
+	}
+
+	hdr := new(SnapshotHeader)
+	if err := binary.Read(r, binary.LittleEndian, &hdr.Version); err != nil {
+		return nil, err
+	}
+
+	if hdr.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", hdr.Version)
+	}
+
+	var network uint8
+	if err := binary.Read(r, binary.LittleEndian, &network); err != nil {
+		return nil, err
+	}
+
+	hdr.Network = protocol.Magic(network)
+
+	if err := binary.Read(r, binary.LittleEndian, &hdr.Height); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if hdr.TipHash, err = readBytes(r); err != nil {
+		return nil, err
+	}
+
+	p, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if hdr.Provisioners, err = user.UnmarshalProvisioners(bytes.NewBuffer(p)); err != nil {
+		return nil, err
+	}
+
+	return hdr, nil
+}
+
+func writeBytes(w io.Writer, b []byte) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(b))); err != nil {
+		return err
+	}
+
+	_, err := w.Write(b)
+	return err
+}
+
+func readBytes(r io.Reader) ([]byte, error) {
+	var l uint32
+	if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+		return nil, err
+	}
+
+	if l > maxRecordSize {
+		return nil, fmt.Errorf("snapshot record of %d bytes exceeds the limit", l)
+	}
+
+	b := make([]byte, l)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func validSnapshotKey(key []byte) bool {
+	for _, prefix := range snapshotPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
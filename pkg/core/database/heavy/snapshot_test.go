@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+// openTempDB opens the heavy storage in a temporary folder. Only one storage
+// can be open at a time.
+func openTempDB(t *testing.T) (database.DB, func()) {
+	dir, err := ioutil.TempDir("", "heavy_snapshot_")
+	require.NoError(t, err)
+
+	db, err := NewDatabase(dir, protocol.DevNet, false)
+	require.NoError(t, err)
+
+	return db, func() {
+		_ = closeStorage()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestSnapshotExportImport(t *testing.T) {
+	assert := require.New(t)
+
+	defer mockTxIndex(true)()
+
+	db, closeDB := openTempDB(t)
+
+	blocks := make([]*block.Block, 5)
+	for i := range blocks {
+		blocks[i] = helper.RandomBlock(uint64(i), 2)
+	}
+
+	participation := database.Participation{PubKeyBLS: []byte{1, 2, 3}, Expected: 10, Cast: 7, Missed: 3}
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		for _, b := range blocks {
+			if err := t.StoreBlock(b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		if err := t.StoreParticipation(participation); err != nil {
+			return err
+		}
+
+		return t.StoreBidValues([]byte{1}, []byte{2}, 3, 10)
+	}))
+
+	var indexed []database.TxLocation
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		var err error
+		indexed, err = t.FetchTxsByType(blocks[0].Txs[0].Type(), 0, 100)
+		return err
+	}))
+	assert.NotEmpty(indexed)
+
+	p := user.NewProvisioners()
+	assert.NoError(p.Add(bytes.Repeat([]byte{1}, 129), 1000, 0, 100))
+
+	archive := new(bytes.Buffer)
+	hdr, err := ExportSnapshot(db, archive, protocol.DevNet, p)
+	assert.NoError(err)
+	assert.Equal(uint64(4), hdr.Height)
+	assert.Equal(blocks[4].Header.Hash, hdr.TipHash)
+
+	data := archive.Bytes()
+
+	closeDB()
+
+	db, closeDB = openTempDB(t)
+	defer closeDB()
+
+	// The network must match
+	_, err = ImportSnapshot(db, bytes.NewReader(data), protocol.TestNet)
+	assert.Error(err)
+
+	// A corrupted archive leaves the database without a chain
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-100] ^= 0xff
+
+	_, err = ImportSnapshot(db, bytes.NewReader(corrupted), protocol.DevNet)
+	assert.Equal(ErrSnapshotChecksum, err)
+
+	assert.Error(db.View(func(t database.Transaction) error {
+		_, err := t.FetchBlockHashByHeight(0)
+		return err
+	}))
+
+	hdr, err = ImportSnapshot(db, bytes.NewReader(data), protocol.DevNet)
+	assert.NoError(err)
+	assert.Equal(uint64(4), hdr.Height)
+	assert.Equal(p.Members, hdr.Provisioners.Members)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(blocks[4].Header.Hash, s.TipHash)
+
+		for _, b := range blocks {
+			hash, err := t.FetchBlockHashByHeight(b.Header.Height)
+			if err != nil {
+				return err
+			}
+
+			assert.Equal(b.Header.Hash, hash)
+
+			txs, err := t.FetchBlockTxs(hash)
+			if err != nil {
+				return err
+			}
+
+			assert.Len(txs, len(b.Txs))
+		}
+
+		// The secondary tx index and the participation counters follow the
+		// chain
+		locations, err := t.FetchTxsByType(blocks[0].Txs[0].Type(), 0, 100)
+		assert.NoError(err)
+		assert.Equal(indexed, locations)
+
+		stored, err := t.FetchParticipation(participation.PubKeyBLS)
+		assert.NoError(err)
+		assert.Equal(participation, stored)
+
+		// Bid values are not part of the snapshot
+		_, _, _, err = t.FetchBidValues()
+		assert.Error(err)
+		return nil
+	}))
+
+	// A chain is never overwritten
+	_, err = ImportSnapshot(db, bytes.NewReader(data), protocol.DevNet)
+	assert.Equal(ErrDatabaseNotEmpty, err)
+}
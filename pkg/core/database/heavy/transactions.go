@@ -24,20 +24,14 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
-const (
-	// Explicitly show we don't want fsync in sake of faster writes.
-	//
-	// If false, and the machine crashes, then some recent writes may be lost.
-	// Note that if it is just the process that crashes (and the machine does
-	// not) then no writes will be lost.
-	optionFsyncEnabled = false
-
-	optionNoWriteMerge = false
-)
+const optionNoWriteMerge = false
 
 var (
-	// writeOptions used by both non-Batch and Batch leveldb.Put.
-	writeOptions = &opt.WriteOptions{NoWriteMerge: optionNoWriteMerge, Sync: optionFsyncEnabled}
+	// asyncWriteOptions used by the writes which do not wait for fsync. See
+	// also durability.go.
+	asyncWriteOptions = &opt.WriteOptions{NoWriteMerge: optionNoWriteMerge, Sync: false}
+	// syncWriteOptions used by the writes which wait for fsync.
+	syncWriteOptions = &opt.WriteOptions{NoWriteMerge: optionNoWriteMerge, Sync: true}
 
 	// Key values prefixes to provide prefix-based sorting mechanism.
 	// Refer to README.md for overview idea.
@@ -60,6 +54,27 @@ var (
 	BidValuesPrefix = []byte{0x08}
 	// CandidatePrefix is the prefix to identify Candidate messages.
 	CandidatePrefix = []byte{0x09}
+	// PrunedPrefix is the prefix to identify the height below which the
+	// transactions are pruned.
+	PrunedPrefix = []byte{0x0a}
+	// OutputIndexPrefix is the prefix to identify the secondary tx index by
+	// output public key.
+	OutputIndexPrefix = []byte{0x0b}
+	// TypeIndexPrefix is the prefix to identify the secondary tx index by tx
+	// type.
+	TypeIndexPrefix = []byte{0x0c}
+	// ExecutedHeightPrefix is the prefix to identify the height of the last
+	// block executed on the Rusk state.
+	ExecutedHeightPrefix = []byte{0x0d}
+	// EvidencePrefix is the prefix to identify the evidence of provisioner
+	// equivocations.
+	EvidencePrefix = []byte{0x0e}
+	// ParticipationPrefix is the prefix to identify the consensus
+	// participation counters of the provisioners.
+	ParticipationPrefix = []byte{0x0f}
+	// BlockParticipationPrefix is the prefix to identify the participation
+	// counted in the certificate of each block.
+	BlockParticipationPrefix = []byte{0x10}
 )
 
 type transaction struct {
@@ -149,6 +164,12 @@ func (t transaction) StoreBlock(b *block.Block) error {
 		//
 		// For the retrival of a single transaction by TxId
 		t.put(append(TxIDPrefix, txID...), b.Header.Hash)
+
+		// For the retrieval of the txs by output public key or type. See
+		// also txindex.go
+		if txIndexEnabled() {
+			t.indexTx(tx, b.Header.Height, uint32(i), txID)
+		}
 	}
 
 	// Key = HeightPrefix + block.header.height
@@ -176,6 +197,11 @@ func (t transaction) StoreBlock(b *block.Block) error {
 
 	t.put(key, value)
 
+	// Delete the transactions of the blocks below the pruning depth
+	if err := t.pruneTxs(b.Header.Height); err != nil {
+		return err
+	}
+
 	// Delete expired bid values
 	key = BidValuesPrefix
 
@@ -206,7 +232,94 @@ func (t transaction) StoreBlock(b *block.Block) error {
 	return iterator.Error()
 }
 
-// Commit writes a batch to LevelDB storage. See also fsyncEnabled variable.
+// DeleteBlock removes the chain tip from the storage. It reverts all the KV
+// pairs put by StoreBlock and points the chain state back at the block found
+// at the previous height.
+//
+// As reads are applied to the snapshot only, DeleteBlock cannot be called
+// more than once within a single Transaction.
+func (t transaction) DeleteBlock(hash []byte) error {
+	if t.batch == nil {
+		return errors.New("DeleteBlock cannot be called on read-only transaction")
+	}
+
+	state, err := t.FetchState()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(state.TipHash, hash) {
+		return database.ErrNotChainTip
+	}
+
+	header, err := t.FetchBlockHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	if header.Height == 0 {
+		return database.ErrGenesisDeletion
+	}
+
+	prevHash, err := t.FetchBlockHashByHeight(header.Height - 1)
+	if err != nil {
+		return err
+	}
+
+	// Delete block transactions together with their TxID and secondary index
+	// entries.
+	// Key = TxPrefix + block.header.hash + txID
+	scanFilter := append(TxPrefix, hash...)
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(scanFilter), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		key := iterator.Key()
+		txID := key[len(scanFilter):]
+
+		tx, txIndex, err := utils.DecodeBlockTx(iterator.Value(), database.AnyTxType)
+		if err != nil {
+			return err
+		}
+
+		t.deleteTxIndex(tx, header.Height, txIndex)
+		t.batch.Delete(append(TxIDPrefix, txID...))
+		t.batch.Delete(append([]byte{}, key...))
+	}
+
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, header.Height); err != nil {
+		return err
+	}
+
+	t.batch.Delete(append(HeightPrefix, heightBuf.Bytes()...))
+	t.batch.Delete(append(HeaderPrefix, hash...))
+	t.batch.Delete(append(BlockParticipationPrefix, hash...))
+
+	// A block stored again at this height comes with its transactions
+	pruned, err := t.isPruned(header.Height)
+	if err != nil {
+		return err
+	}
+
+	if pruned {
+		if err := t.putPrunedHeight(header.Height); err != nil {
+			return err
+		}
+	}
+
+	// Move the chain tip back
+	t.put(StatePrefix, prevHash)
+	return nil
+}
+
+// Commit writes a batch to LevelDB storage. Whether the write waits for fsync
+// depends on the durability mode, see durability.go.
 func (t *transaction) Commit() error {
 	if !t.writable {
 		return errors.New("read-only transaction cannot commit changes")
@@ -216,7 +329,7 @@ func (t *transaction) Commit() error {
 		return errors.New("already closed transaction cannot commit changes")
 	}
 
-	return t.db.storage.Write(t.batch, writeOptions)
+	return t.db.storage.Write(t.batch, t.db.writeOptions(movesTip(t.batch)))
 }
 
 // Rollback is not used by database layer.
@@ -333,6 +446,13 @@ func (t transaction) FetchBlockTxs(hashHeader []byte) ([]transactions.ContractCa
 		tempTxs[txIndex] = tx
 	}
 
+	// Every block holds at least the coinbase tx
+	if len(tempTxs) == 0 {
+		if err := t.checkPruned(hashHeader); err != nil {
+			return nil, err
+		}
+	}
+
 	// Reorder Tx slice as per retrieved indexes
 	resultTxs := make([]transactions.ContractCall, len(tempTxs))
 	for k, v := range tempTxs {
@@ -436,6 +556,10 @@ func (t transaction) FetchBlockTxByHash(txID []byte) (transactions.ContractCall,
 		return tx, idx, hashHeader, nil
 	}
 
+	if err := t.checkPruned(hashHeader); err != nil {
+		return nil, txIndex, hashHeader, err
+	}
+
 	return nil, txIndex, nil, errors.New("block tx is available but fetching it fails")
 }
 
@@ -493,6 +617,40 @@ func (t transaction) FetchState() (*database.State, error) {
 	return &database.State{TipHash: value}, nil
 }
 
+// StoreExecutedHeight stores the height of the last block executed on the
+// Rusk state.
+//
+// Key = ExecutedHeightPrefix
+// Value = height
+func (t transaction) StoreExecutedHeight(height uint64) error {
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.put(ExecutedHeightPrefix, buf.Bytes())
+	return nil
+}
+
+func (t transaction) FetchExecutedHeight() (uint64, error) {
+	value, err := t.snapshot.Get(ExecutedHeightPrefix, nil)
+	if err == leveldb.ErrNotFound {
+		// overwrite error message
+		err = database.ErrStateNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewReader(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
 func (t transaction) FetchCurrentHeight() (uint64, error) {
 	state, err := t.FetchState()
 	if err != nil {
@@ -650,6 +808,125 @@ func (t transaction) ClearCandidateMessages() error {
 	return iter.Error()
 }
 
+// StoreEvidence stores the evidence of a provisioner equivocation under its ID.
+func (t transaction) StoreEvidence(e message.Evidence) error {
+	id, err := e.ID()
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalEvidence(buf, e); err != nil {
+		return err
+	}
+
+	t.put(append(EvidencePrefix, id...), buf.Bytes())
+	return nil
+}
+
+// FetchEvidence returns all of the stored evidence.
+func (t transaction) FetchEvidence() ([]message.Evidence, error) {
+	iter := t.snapshot.NewIterator(util.BytesPrefix(EvidencePrefix), nil)
+	defer iter.Release()
+
+	evidence := make([]message.Evidence, 0)
+
+	for iter.Next() {
+		var e message.Evidence
+		if err := message.UnmarshalEvidence(bytes.NewBuffer(iter.Value()), &e); err != nil {
+			return nil, err
+		}
+
+		evidence = append(evidence, e)
+	}
+
+	return evidence, iter.Error()
+}
+
+// DeleteEvidence deletes the stored evidence with the ID of e.
+func (t transaction) DeleteEvidence(e message.Evidence) error {
+	id, err := e.ID()
+	if err != nil {
+		return err
+	}
+
+	t.batch.Delete(append(EvidencePrefix, id...))
+	return nil
+}
+
+// StoreParticipation stores the participation counters of a provisioner
+// under its BLS public key.
+func (t transaction) StoreParticipation(p database.Participation) error {
+	value, err := utils.EncodeParticipation(p)
+	if err != nil {
+		return err
+	}
+
+	t.put(append(ParticipationPrefix, p.PubKeyBLS...), value)
+	return nil
+}
+
+// FetchParticipation returns the participation counters of a provisioner.
+func (t transaction) FetchParticipation(pubKeyBLS []byte) (database.Participation, error) {
+	value, err := t.snapshot.Get(append(ParticipationPrefix, pubKeyBLS...), nil)
+	if err == leveldb.ErrNotFound {
+		return database.Participation{}, database.ErrParticipationNotFound
+	}
+
+	if err != nil {
+		return database.Participation{}, err
+	}
+
+	return utils.DecodeParticipation(value)
+}
+
+// FetchAllParticipation returns the participation counters of all
+// provisioners, in key order.
+func (t transaction) FetchAllParticipation() ([]database.Participation, error) {
+	iter := t.snapshot.NewIterator(util.BytesPrefix(ParticipationPrefix), nil)
+	defer iter.Release()
+
+	all := make([]database.Participation, 0)
+
+	for iter.Next() {
+		p, err := utils.DecodeParticipation(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, p)
+	}
+
+	return all, iter.Error()
+}
+
+// StoreBlockParticipation stores the participation counted in the
+// certificate of a block under the block hash.
+func (t transaction) StoreBlockParticipation(hash []byte, p []database.Participation) error {
+	value, err := utils.EncodeParticipationList(p)
+	if err != nil {
+		return err
+	}
+
+	t.put(append(BlockParticipationPrefix, hash...), value)
+	return nil
+}
+
+// FetchBlockParticipation returns the participation counted in the
+// certificate of a block.
+func (t transaction) FetchBlockParticipation(hash []byte) ([]database.Participation, error) {
+	value, err := t.snapshot.Get(append(BlockParticipationPrefix, hash...), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, database.ErrParticipationNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.DecodeParticipationList(value)
+}
+
 // ClearDatabase will wipe all of the data currently in the database.
 func (t transaction) ClearDatabase() error {
 	iter := t.snapshot.NewIterator(nil, nil)
@@ -0,0 +1,207 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	// OutputKeySize is the size of the note public keys (PkR).
+	OutputKeySize = 32
+
+	// indexBatchSize is the number of blocks indexed within a single
+	// transaction by IndexTxs.
+	indexBatchSize = 1000
+)
+
+// The secondary tx index maps the output public keys and the tx types to the
+// location of the transactions in the chain.
+//
+// Key = OutputIndexPrefix + note.PkR + height + txIndex
+// Key = TypeIndexPrefix + txType + height + txIndex
+// Value = txID
+//
+// Unlike the rest of the storage, the height and the tx index are big-endian
+// encoded, so that the entries of a public key or a type are iterated in
+// chain order.
+
+func txIndexEnabled() bool {
+	return cfg.Get().Database.TxIndex
+}
+
+// TxIndexKeys returns the secondary index keys of a tx. They are shared by
+// the badger driver, which stores the chain with the same schema.
+func TxIndexKeys(tx transactions.ContractCall, height uint64, txIndex uint32) [][]byte {
+	suffix := make([]byte, 12)
+	binary.BigEndian.PutUint64(suffix, height)
+	binary.BigEndian.PutUint32(suffix[8:], txIndex)
+
+	txType := make([]byte, 4)
+	binary.BigEndian.PutUint32(txType, uint32(tx.Type()))
+
+	key := append(TypeIndexPrefix, txType...)
+	keys := [][]byte{append(key, suffix...)}
+
+	seen := make(map[string]struct{})
+
+	for _, note := range tx.StandardTx().Notes {
+		if note == nil || len(note.PkR) != OutputKeySize {
+			continue
+		}
+
+		// A tx is indexed once per public key
+		if _, ok := seen[string(note.PkR)]; ok {
+			continue
+		}
+
+		seen[string(note.PkR)] = struct{}{}
+
+		key := append(OutputIndexPrefix, note.PkR...)
+		keys = append(keys, append(key, suffix...))
+	}
+
+	return keys
+}
+
+// indexTx puts the secondary index entries of a tx.
+func (t transaction) indexTx(tx transactions.ContractCall, height uint64, txIndex uint32, txID []byte) {
+	for _, key := range TxIndexKeys(tx, height, txIndex) {
+		t.put(key, txID)
+	}
+}
+
+// deleteTxIndex deletes the secondary index entries of a tx. It is applied
+// whether the index is enabled or not, so that disabling it for a while
+// never leaves entries of reverted blocks behind.
+func (t transaction) deleteTxIndex(tx transactions.ContractCall, height uint64, txIndex uint32) {
+	for _, key := range TxIndexKeys(tx, height, txIndex) {
+		t.batch.Delete(key)
+	}
+}
+
+func (t transaction) FetchTxsByOutputKey(pubKey []byte, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	if len(pubKey) != OutputKeySize {
+		return nil, fmt.Errorf("output public key size is %d but it must be %d", len(pubKey), OutputKeySize)
+	}
+
+	return t.fetchTxLocations(append(OutputIndexPrefix, pubKey...), fromHeight, limit)
+}
+
+func (t transaction) FetchTxsByType(txType transactions.TxType, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, uint32(txType))
+
+	return t.fetchTxLocations(append(TypeIndexPrefix, key...), fromHeight, limit)
+}
+
+// fetchTxLocations iterates the secondary index entries under the prefix,
+// from the given height onward.
+func (t transaction) fetchTxLocations(prefix []byte, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	if !txIndexEnabled() {
+		return nil, database.ErrTxIndexDisabled
+	}
+
+	height := make([]byte, 8)
+	binary.BigEndian.PutUint64(height, fromHeight)
+
+	r := util.BytesPrefix(prefix)
+	r.Start = append(append([]byte{}, prefix...), height...)
+
+	iterator := t.snapshot.NewIterator(r, nil)
+	defer iterator.Release()
+
+	locations := make([]database.TxLocation, 0)
+
+	for len(locations) < limit && iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(prefix)+12 {
+			return nil, errors.New("malformed tx index entry")
+		}
+
+		locations = append(locations, database.TxLocation{
+			Height:  binary.BigEndian.Uint64(key[len(prefix):]),
+			TxIndex: binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			TxID:    append([]byte{}, iterator.Value()...),
+		})
+	}
+
+	return locations, iterator.Error()
+}
+
+// IndexTxs adds the transactions of the main chain to the secondary tx index.
+// It is used to index the blocks stored before the index was enabled, and
+// returns the number of indexed transactions. Pruned transactions cannot be
+// indexed.
+func IndexTxs(db database.DB) (uint64, error) {
+	if _, ok := db.(DB); !ok {
+		return 0, errNotHeavy
+	}
+
+	if !txIndexEnabled() {
+		return 0, database.ErrTxIndexDisabled
+	}
+
+	var tip uint64
+
+	err := db.View(func(t database.Transaction) error {
+		var err error
+		tip, err = t.FetchCurrentHeight()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var indexed uint64
+
+	for from := uint64(0); from <= tip; from += indexBatchSize {
+		err := db.Update(func(t database.Transaction) error {
+			tx := t.(*transaction)
+
+			for height := from; height <= tip && height < from+indexBatchSize; height++ {
+				hash, err := tx.FetchBlockHashByHeight(height)
+				if err != nil {
+					return err
+				}
+
+				txs, err := tx.FetchBlockTxs(hash)
+				if err == database.ErrTxPruned {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				for i, call := range txs {
+					txID, err := call.CalculateHash()
+					if err != nil {
+						return err
+					}
+
+					tx.indexTx(call, height, uint32(i), txID)
+					indexed++
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return indexed, err
+		}
+	}
+
+	return indexed, nil
+}
@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTxIndex(enabled bool) func() {
+	r := cfg.Get()
+	r.Database.TxIndex = enabled
+	cfg.Mock(&r)
+
+	return func() {
+		r.Database.TxIndex = false
+		cfg.Mock(&r)
+	}
+}
+
+// txLocations returns the locations of the matching txs.
+func txLocations(t *testing.T, blocks []*block.Block, match func(transactions.ContractCall) bool) []database.TxLocation {
+	locations := make([]database.TxLocation, 0)
+
+	for _, b := range blocks {
+		for i, tx := range b.Txs {
+			if !match(tx) {
+				continue
+			}
+
+			txID, err := tx.CalculateHash()
+			require.NoError(t, err)
+
+			locations = append(locations, database.TxLocation{
+				Height:  b.Header.Height,
+				TxIndex: uint32(i),
+				TxID:    txID,
+			})
+		}
+	}
+
+	return locations
+}
+
+func typeLocations(t *testing.T, blocks []*block.Block, txType transactions.TxType) []database.TxLocation {
+	return txLocations(t, blocks, func(tx transactions.ContractCall) bool {
+		return tx.Type() == txType
+	})
+}
+
+func outputLocations(t *testing.T, blocks []*block.Block, pubKey []byte) []database.TxLocation {
+	return txLocations(t, blocks, func(tx transactions.ContractCall) bool {
+		for _, note := range tx.StandardTx().Notes {
+			if bytes.Equal(note.PkR, pubKey) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func TestTxIndex(t *testing.T) {
+	assert := require.New(t)
+
+	defer mockTxIndex(true)()
+
+	db, closeDB := openTempDB(t)
+	defer closeDB()
+
+	blocks := storeChain(t, db, 4)
+	expected := typeLocations(t, blocks, transactions.Distribute)
+	fromOne := typeLocations(t, blocks[1:], transactions.Distribute)
+	reverted := typeLocations(t, blocks[:3], transactions.Distribute)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByType(transactions.Distribute, 1, 10)
+		assert.NoError(err)
+		assert.Equal(fromOne, locations)
+
+		locations, err = t.FetchTxsByType(transactions.Distribute, 0, 2)
+		assert.NoError(err)
+		assert.Equal(expected[:2], locations)
+		return nil
+	}))
+
+	pubKey := blocks[3].Txs[0].StandardTx().Notes[0].PkR
+	outputs := outputLocations(t, blocks, pubKey)
+	tipOutputs := outputLocations(t, blocks[3:], pubKey)
+	assert.NotEmpty(tipOutputs)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByOutputKey(pubKey, 0, 100)
+		assert.NoError(err)
+		assert.Equal(outputs, locations)
+
+		locations, err = t.FetchTxsByOutputKey(pubKey, 3, 100)
+		assert.NoError(err)
+		assert.Equal(tipOutputs, locations)
+
+		_, err = t.FetchTxsByOutputKey([]byte{1, 2, 3}, 0, 10)
+		assert.Error(err)
+		return nil
+	}))
+
+	// Reverted blocks are removed from the index
+	assert.NoError(db.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(blocks[3].Header.Hash)
+	}))
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByOutputKey(pubKey, 3, 100)
+		assert.NoError(err)
+		assert.Empty(locations)
+
+		locations, err = t.FetchTxsByType(transactions.Distribute, 0, 10)
+		assert.NoError(err)
+		assert.Equal(reverted, locations)
+		return nil
+	}))
+}
+
+func TestIndexTxs(t *testing.T) {
+	assert := require.New(t)
+
+	db, closeDB := openTempDB(t)
+	defer closeDB()
+
+	blocks := storeChain(t, db, 3)
+	expected := typeLocations(t, blocks, transactions.Distribute)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		_, err := t.FetchTxsByType(transactions.Distribute, 0, 10)
+		assert.Equal(database.ErrTxIndexDisabled, err)
+		return nil
+	}))
+
+	_, err := IndexTxs(db)
+	assert.Equal(database.ErrTxIndexDisabled, err)
+
+	defer mockTxIndex(true)()
+
+	// Blocks stored while the index was disabled are not indexed
+	assert.NoError(db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByType(transactions.Distribute, 0, 10)
+		assert.NoError(err)
+		assert.Empty(locations)
+		return nil
+	}))
+
+	var txs int
+	for _, b := range blocks {
+		txs += len(b.Txs)
+	}
+
+	indexed, err := IndexTxs(db)
+	assert.NoError(err)
+	assert.Equal(uint64(txs), indexed)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByType(transactions.Distribute, 0, 10)
+		assert.NoError(err)
+		assert.Equal(expected, locations)
+		return nil
+	}))
+}
@@ -0,0 +1,550 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/utils"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// VerifyReport lists the inconsistencies found in the storage.
+type VerifyReport struct {
+	// TipHeight is the height of the chain tip pointed at by the chain
+	// state.
+	TipHeight uint64
+	// Blocks is the number of blocks found in the height index.
+	Blocks uint64
+	Issues []string
+}
+
+func (r *VerifyReport) addIssue(format string, a ...interface{}) {
+	r.Issues = append(r.Issues, fmt.Sprintf(format, a...))
+}
+
+// chainIndex maps the heights to the hashes of the main chain blocks, and
+// back.
+type chainIndex struct {
+	hashes  map[uint64][]byte
+	heights map[string]uint64
+	top     uint64
+}
+
+func newChainIndex() *chainIndex {
+	return &chainIndex{
+		hashes:  make(map[uint64][]byte),
+		heights: make(map[string]uint64),
+	}
+}
+
+func (c *chainIndex) add(height uint64, hash []byte) {
+	c.hashes[height] = hash
+	c.heights[string(hash)] = height
+
+	if height > c.top {
+		c.top = height
+	}
+}
+
+// Verify scans the whole storage, and reports:
+//   - blocks whose header hash or tx root do not match their contents
+//   - blocks which do not chain to the previous one
+//   - height index entries not matching the block headers
+//   - headers and transactions of blocks outside of the main chain
+//   - TxID index entries not pointing at a stored transaction
+//   - a chain state not pointing at the highest block
+func Verify(db database.DB) (*VerifyReport, error) {
+	if _, ok := db.(DB); !ok {
+		return nil, errNotHeavy
+	}
+
+	r := new(VerifyReport)
+
+	err := db.View(func(t database.Transaction) error {
+		tx := t.(*transaction)
+
+		index, err := tx.readHeightIndex()
+		if err != nil {
+			return err
+		}
+
+		r.Blocks = uint64(len(index.hashes))
+
+		if err := tx.verifyChain(index, r); err != nil {
+			return err
+		}
+
+		if err := tx.verifyState(index, r); err != nil {
+			return err
+		}
+
+		if err := tx.verifyHeaders(index, r); err != nil {
+			return err
+		}
+
+		return tx.verifyTxIndex(index, r)
+	})
+
+	return r, err
+}
+
+// readHeightIndex reads the height index as it is stored.
+func (t transaction) readHeightIndex() (*chainIndex, error) {
+	index := newChainIndex()
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeightPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		var height uint64
+		if err := utils.ReadUint64(bytes.NewReader(iterator.Key()[len(HeightPrefix):]), &height); err != nil {
+			return nil, err
+		}
+
+		index.add(height, append([]byte{}, iterator.Value()...))
+	}
+
+	return index, iterator.Error()
+}
+
+// verifyChain checks each block of the height index against its header and
+// transactions, and against the previous block.
+func (t transaction) verifyChain(index *chainIndex, r *VerifyReport) error {
+	var prev *block.Header
+
+	if len(index.hashes) == 0 {
+		return nil
+	}
+
+	for height := uint64(0); height <= index.top; height++ {
+		hash, ok := index.hashes[height]
+		if !ok {
+			r.addIssue("height %d: missing from the height index", height)
+			prev = nil
+			continue
+		}
+
+		header, err := t.FetchBlockHeader(hash)
+		if err == database.ErrBlockNotFound {
+			r.addIssue("height %d: header %x not found", height, hash)
+			prev = nil
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if header.Height != height {
+			r.addIssue("height %d: indexed block %x has height %d", height, hash, header.Height)
+		}
+
+		calculated, err := header.CalculateHash()
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(calculated, hash) {
+			r.addIssue("height %d: header hash %x does not match its contents", height, hash)
+		}
+
+		if prev != nil && !bytes.Equal(header.PrevBlockHash, prev.Hash) {
+			r.addIssue("height %d: block %x does not chain to block %x", height, hash, prev.Hash)
+		}
+
+		prev = header
+
+		if err := t.verifyTxRoot(header, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t transaction) verifyTxRoot(header *block.Header, r *VerifyReport) error {
+	txs, err := t.FetchBlockTxs(header.Hash)
+	if err == database.ErrTxPruned {
+		return nil
+	}
+
+	if err != nil {
+		r.addIssue("height %d: could not read the transactions: %v", header.Height, err)
+		return nil
+	}
+
+	b := block.Block{Header: header, Txs: txs}
+
+	root, err := b.CalculateRoot()
+	if err != nil {
+		r.addIssue("height %d: could not calculate the tx root: %v", header.Height, err)
+		return nil
+	}
+
+	if !bytes.Equal(root, header.TxRoot) {
+		r.addIssue("height %d: tx root does not match the stored transactions", header.Height)
+	}
+
+	return nil
+}
+
+// verifyState checks that the chain state points at the highest block.
+func (t transaction) verifyState(index *chainIndex, r *VerifyReport) error {
+	s, err := t.FetchState()
+	if err == database.ErrStateNotFound {
+		r.addIssue("chain state not found")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	height, ok := index.heights[string(s.TipHash)]
+	if !ok {
+		r.addIssue("chain state points at block %x, which is not in the height index", s.TipHash)
+		return nil
+	}
+
+	r.TipHeight = height
+
+	if _, ok := index.hashes[height+1]; ok {
+		r.addIssue("chain state points at height %d, but the height index is higher", height)
+	}
+
+	return nil
+}
+
+// verifyHeaders reports the headers of blocks outside of the main chain.
+func (t transaction) verifyHeaders(index *chainIndex, r *VerifyReport) error {
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeaderPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		hash := iterator.Key()[len(HeaderPrefix):]
+		if _, ok := index.heights[string(hash)]; !ok {
+			r.addIssue("header %x is not in the height index", hash)
+		}
+	}
+
+	return iterator.Error()
+}
+
+// verifyTxIndex checks the TxID index against the stored transactions.
+func (t transaction) verifyTxIndex(index *chainIndex, r *VerifyReport) error {
+	pruned, err := t.fetchPrunedHeight()
+	if err != nil {
+		return err
+	}
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(TxIDPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		txID := iterator.Key()[len(TxIDPrefix):]
+		hash := iterator.Value()
+
+		height, ok := index.heights[string(hash)]
+		if !ok {
+			if !t.hasHeader(hash) {
+				r.addIssue("tx %x: indexed in block %x, which is not in the height index", txID, hash)
+			}
+
+			continue
+		}
+
+		if height < pruned {
+			continue
+		}
+
+		exists, err := t.snapshot.Has(txKey(hash, txID), nil)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			r.addIssue("tx %x: not found in block %x", txID, hash)
+		}
+	}
+
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+
+	txIterator := t.snapshot.NewIterator(util.BytesPrefix(TxPrefix), nil)
+	defer txIterator.Release()
+
+	for txIterator.Next() {
+		hash, txID, err := splitTxKey(txIterator.Key())
+		if err != nil {
+			r.addIssue("%v", err)
+			continue
+		}
+
+		if _, ok := index.heights[string(hash)]; !ok {
+			if !t.hasHeader(hash) {
+				r.addIssue("tx %x: stored in block %x, which is not in the height index", txID, hash)
+			}
+
+			continue
+		}
+
+		indexed, err := t.snapshot.Get(append(TxIDPrefix, txID...), nil)
+		if err != nil && err != leveldb.ErrNotFound {
+			return err
+		}
+
+		if !bytes.Equal(indexed, hash) {
+			r.addIssue("tx %x: missing from the TxID index", txID)
+		}
+	}
+
+	return txIterator.Error()
+}
+
+// Repair rebuilds the secondary indices from the headers and transactions.
+// The main chain is found by walking back the headers from the chain tip, or
+// from the highest header chaining to the genesis if the chain state is lost.
+// It returns the list of fixed entries.
+//
+// Headers and transactions are never modified. If the main chain cannot be
+// walked back to the genesis, the chain must be restored from a snapshot.
+func Repair(db database.DB) ([]string, error) {
+	if _, ok := db.(DB); !ok {
+		return nil, errNotHeavy
+	}
+
+	var fixed []string
+
+	err := db.Update(func(t database.Transaction) error {
+		tx := t.(*transaction)
+
+		tip, err := tx.findTip()
+		if err != nil {
+			return err
+		}
+
+		index, err := tx.walkChain(tip)
+		if err != nil {
+			return err
+		}
+
+		stored, err := tx.readHeightIndex()
+		if err != nil {
+			return err
+		}
+
+		fixed = append(fixed, tx.repairHeightIndex(index, stored)...)
+
+		txFixes, err := tx.repairTxIndex(index)
+		if err != nil {
+			return err
+		}
+
+		fixed = append(fixed, txFixes...)
+
+		if s, err := tx.FetchState(); err != nil || !bytes.Equal(s.TipHash, tip.Hash) {
+			tx.put(StatePrefix, tip.Hash)
+			fixed = append(fixed, fmt.Sprintf("chain state set to block %x at height %d", tip.Hash, tip.Height))
+		}
+
+		return nil
+	})
+
+	return fixed, err
+}
+
+// findTip returns the header pointed at by the chain state or, if it is
+// lost, the highest header chaining back to the genesis.
+func (t transaction) findTip() (*block.Header, error) {
+	if s, err := t.FetchState(); err == nil {
+		if header, err := t.FetchBlockHeader(s.TipHash); err == nil {
+			return header, nil
+		}
+	}
+
+	var tip *block.Header
+
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(HeaderPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		header, err := t.FetchBlockHeader(iterator.Key()[len(HeaderPrefix):])
+		if err != nil {
+			return nil, err
+		}
+
+		if tip != nil && header.Height <= tip.Height {
+			continue
+		}
+
+		if _, err := t.walkChain(header); err == nil {
+			tip = header
+		}
+	}
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	if tip == nil {
+		return nil, errors.New("no chain found in the database")
+	}
+
+	return tip, nil
+}
+
+// walkChain follows the previous block hashes from the tip down to the
+// genesis.
+func (t transaction) walkChain(tip *block.Header) (*chainIndex, error) {
+	index := newChainIndex()
+	header := tip
+
+	for {
+		index.add(header.Height, header.Hash)
+
+		if header.Height == 0 {
+			return index, nil
+		}
+
+		prev, err := t.FetchBlockHeader(header.PrevBlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("chain broken at height %d: %v", header.Height-1, err)
+		}
+
+		if prev.Height != header.Height-1 {
+			return nil, fmt.Errorf("chain broken at height %d: block %x has height %d", header.Height-1, prev.Hash, prev.Height)
+		}
+
+		header = prev
+	}
+}
+
+func (t transaction) repairHeightIndex(index, stored *chainIndex) []string {
+	var fixed []string
+
+	for height, hash := range stored.hashes {
+		if _, ok := index.hashes[height]; !ok {
+			t.batch.Delete(heightKey(height))
+			fixed = append(fixed, fmt.Sprintf("height %d: removed index entry of block %x", height, hash))
+		}
+	}
+
+	for height, hash := range index.hashes {
+		if !bytes.Equal(stored.hashes[height], hash) {
+			t.put(heightKey(height), hash)
+			fixed = append(fixed, fmt.Sprintf("height %d: indexed block %x", height, hash))
+		}
+	}
+
+	return fixed
+}
+
+func (t transaction) repairTxIndex(index *chainIndex) ([]string, error) {
+	var fixed []string
+
+	pruned, err := t.fetchPrunedHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the entries which do not point at a stored transaction. The
+	// transactions of the pruned blocks can not be checked, and are kept.
+	iterator := t.snapshot.NewIterator(util.BytesPrefix(TxIDPrefix), nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		txID := iterator.Key()[len(TxIDPrefix):]
+		hash := iterator.Value()
+
+		height, ok := index.heights[string(hash)]
+		if ok && height < pruned {
+			continue
+		}
+
+		if ok {
+			exists, err := t.snapshot.Has(txKey(hash, txID), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			if exists {
+				continue
+			}
+		}
+
+		t.batch.Delete(append([]byte{}, iterator.Key()...))
+		fixed = append(fixed, fmt.Sprintf("tx %x: removed index entry of block %x", txID, hash))
+	}
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	// Index the transactions of the main chain blocks
+	txIterator := t.snapshot.NewIterator(util.BytesPrefix(TxPrefix), nil)
+	defer txIterator.Release()
+
+	for txIterator.Next() {
+		hash, txID, err := splitTxKey(txIterator.Key())
+		if err != nil {
+			continue
+		}
+
+		if _, ok := index.heights[string(hash)]; !ok {
+			continue
+		}
+
+		indexed, err := t.snapshot.Get(append(TxIDPrefix, txID...), nil)
+		if err != nil && err != leveldb.ErrNotFound {
+			return nil, err
+		}
+
+		if !bytes.Equal(indexed, hash) {
+			t.put(append(TxIDPrefix, txID...), append([]byte{}, hash...))
+			fixed = append(fixed, fmt.Sprintf("tx %x: indexed in block %x", txID, hash))
+		}
+	}
+
+	return fixed, txIterator.Error()
+}
+
+// hasHeader returns true if the header of the block is stored. The headers
+// outside of the height index are reported on their own, rather than for
+// each of their transactions.
+func (t transaction) hasHeader(hash []byte) bool {
+	exists, err := t.snapshot.Has(append(HeaderPrefix, hash...), nil)
+	return err == nil && exists
+}
+
+func heightKey(height uint64) []byte {
+	buf := new(bytes.Buffer)
+	_ = utils.WriteUint64(buf, height)
+
+	return append(append([]byte{}, HeightPrefix...), buf.Bytes()...)
+}
+
+func txKey(hash, txID []byte) []byte {
+	key := append(append([]byte{}, TxPrefix...), hash...)
+	return append(key, txID...)
+}
+
+// splitTxKey splits a TxPrefix key into the block hash and the tx ID.
+func splitTxKey(key []byte) ([]byte, []byte, error) {
+	l := len(TxPrefix) + block.HeaderHashSize
+	if len(key) <= l {
+		return nil, nil, fmt.Errorf("malformed tx key %x", key)
+	}
+
+	return key[len(TxPrefix):l], key[l:], nil
+}
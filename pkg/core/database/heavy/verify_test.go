@@ -0,0 +1,115 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package heavy
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/stretchr/testify/require"
+)
+
+// storeChain stores a chain of linked blocks.
+func storeChain(t *testing.T, db database.DB, n int) []*block.Block {
+	blocks := make([]*block.Block, n)
+
+	for i := range blocks {
+		b := helper.RandomBlock(uint64(i), 1)
+
+		if i > 0 {
+			b.SetPrevBlock(blocks[i-1].Header)
+
+			hash, err := b.CalculateHash()
+			require.NoError(t, err)
+
+			b.Header.Hash = hash
+		}
+
+		require.NoError(t, db.Update(func(t database.Transaction) error {
+			return t.StoreBlock(b)
+		}))
+
+		blocks[i] = b
+	}
+
+	return blocks
+}
+
+func TestVerifyRepair(t *testing.T) {
+	assert := require.New(t)
+
+	db, closeDB := openTempDB(t)
+	defer closeDB()
+
+	blocks := storeChain(t, db, 5)
+
+	r, err := Verify(db)
+	assert.NoError(err)
+	assert.Empty(r.Issues)
+	assert.Equal(uint64(5), r.Blocks)
+	assert.Equal(uint64(4), r.TipHeight)
+
+	// Corrupt the secondary indices
+	storage := db.(DB).storage
+
+	txID, err := blocks[2].Txs[0].CalculateHash()
+	assert.NoError(err)
+
+	assert.NoError(storage.Delete(heightKey(2), nil))
+	assert.NoError(storage.Delete(append(TxIDPrefix, txID...), nil))
+	assert.NoError(storage.Put(append(TxIDPrefix, 1, 2, 3), blocks[0].Header.Hash, nil))
+	assert.NoError(storage.Put(StatePrefix, []byte{1, 2, 3}, nil))
+
+	r, err = Verify(db)
+	assert.NoError(err)
+	assert.Len(r.Issues, 4)
+
+	fixed, err := Repair(db)
+	assert.NoError(err)
+	assert.Len(fixed, 4)
+
+	r, err = Verify(db)
+	assert.NoError(err)
+	assert.Empty(r.Issues)
+	assert.Equal(uint64(4), r.TipHeight)
+	assert.Equal(uint64(5), r.Blocks)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		_, _, hash, err := t.FetchBlockTxByHash(txID)
+		assert.NoError(err)
+		assert.Equal(blocks[2].Header.Hash, hash)
+		return nil
+	}))
+}
+
+func TestRepairLostState(t *testing.T) {
+	assert := require.New(t)
+
+	db, closeDB := openTempDB(t)
+	defer closeDB()
+
+	blocks := storeChain(t, db, 3)
+
+	storage := db.(DB).storage
+	assert.NoError(storage.Delete(StatePrefix, nil))
+
+	r, err := Verify(db)
+	assert.NoError(err)
+	assert.Equal([]string{"chain state not found"}, r.Issues)
+
+	_, err = Repair(db)
+	assert.NoError(err)
+
+	assert.NoError(db.View(func(t database.Transaction) error {
+		s, err := t.FetchState()
+		assert.NoError(err)
+		assert.Equal(blocks[2].Header.Hash, s.TipHash)
+		return nil
+	}))
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 )
 
@@ -29,6 +30,20 @@ var (
 	ErrStateNotFound = errors.New("database: state not found")
 	// ErrOutputNotFound returned on output lookup during tx verification.
 	ErrOutputNotFound = errors.New("database: output not found")
+	// ErrTxPruned returned on a lookup of transactions deleted by the
+	// pruning mode.
+	ErrTxPruned = errors.New("database: transaction pruned")
+	// ErrTxIndexDisabled returned on a lookup of the secondary tx index
+	// while it is not enabled in the configuration.
+	ErrTxIndexDisabled = errors.New("database: tx index disabled")
+	// ErrNotChainTip returned when attempting to delete a block which is not
+	// the current chain tip.
+	ErrNotChainTip = errors.New("database: block is not the chain tip")
+	// ErrGenesisDeletion returned when attempting to delete the genesis block.
+	ErrGenesisDeletion = errors.New("database: genesis block cannot be deleted")
+	// ErrParticipationNotFound returned on a lookup of the participation of
+	// a provisioner which never had a seat in a certifying committee.
+	ErrParticipationNotFound = errors.New("database: participation not found")
 
 	// AnyTxType is used as a filter value on FetchBlockTxByHash.
 	AnyTxType = transactions.TxType(math.MaxUint8)
@@ -60,9 +75,11 @@ type Transaction interface {
 
 	FetchBlockHeader(hash []byte) (*block.Header, error)
 	// Fetch all of the Txs that belong to a block with this header.hash.
+	// ErrTxPruned is returned if the Txs were deleted by the pruning mode.
 	FetchBlockTxs(hash []byte) ([]transactions.ContractCall, error)
 	// Fetch tx by txID. If succeeds, it returns tx data, tx index and
-	// hash of the block it belongs to.
+	// hash of the block it belongs to. ErrTxPruned is returned if the tx
+	// was deleted by the pruning mode.
 	FetchBlockTxByHash(txID []byte) (tx transactions.ContractCall, txIndex uint32, blockHeaderHash []byte, err error)
 	FetchBlockHashByHeight(height uint64) ([]byte, error)
 	FetchBlockExists(hash []byte) (bool, error)
@@ -73,12 +90,40 @@ type Transaction interface {
 	// also txID the input belongs to.
 	FetchKeyImageExists(keyImage []byte) (exists bool, txID []byte, err error)
 
+	// Fetch the locations of the Txs holding an output to the given
+	// public key (the note PkR), from the given height onward, in ascending
+	// order. At most limit locations are returned. ErrTxIndexDisabled is
+	// returned if the secondary tx index is not enabled.
+	FetchTxsByOutputKey(pubKey []byte, fromHeight uint64, limit int) ([]TxLocation, error)
+	// Fetch the locations of the Txs of the given type, from the given height
+	// onward, in ascending order. At most limit locations are returned.
+	// ErrTxIndexDisabled is returned if the secondary tx index is not
+	// enabled.
+	FetchTxsByType(txType transactions.TxType, fromHeight uint64, limit int) ([]TxLocation, error)
+
 	// Read-write transactions
 	// Store the next chain block in a append-only manner
 	// Overwrites only if block with same hash already stored
 	// Not to be called concurrently, as it updates chain tip.
 	StoreBlock(block *block.Block) error
 
+	// DeleteBlock removes the chain tip identified by hash, together with its
+	// transactions and all of the indices pointing at them. The chain state
+	// is moved back to the block stored at the previous height.
+	// Only the current tip can be deleted, and not to be called concurrently,
+	// as it updates chain tip.
+	DeleteBlock(hash []byte) error
+
+	// StoreExecutedHeight records the height of the last block executed on
+	// the Rusk state. It is stored before the state transition is executed,
+	// so that a chain tip left behind the Rusk state by a crash can be
+	// detected on startup.
+	StoreExecutedHeight(height uint64) error
+
+	// FetchExecutedHeight returns the height stored by StoreExecutedHeight.
+	// ErrStateNotFound is returned if it was never stored.
+	FetchExecutedHeight() (uint64, error)
+
 	// FetchBlock will return a block, given a hash.
 	FetchBlock(hash []byte) (*block.Block, error)
 
@@ -118,6 +163,41 @@ type Transaction interface {
 
 	ClearCandidateMessages() error
 
+	// StoreEvidence persists the evidence of a provisioner equivocation,
+	// until it is deleted with DeleteEvidence. Evidence with the same ID is
+	// overwritten.
+	StoreEvidence(e message.Evidence) error
+
+	// FetchEvidence returns all of the persisted evidence.
+	FetchEvidence() ([]message.Evidence, error)
+
+	// DeleteEvidence removes the persisted evidence with the ID of e.
+	DeleteEvidence(e message.Evidence) error
+
+	// StoreParticipation stores the consensus participation counters of a
+	// provisioner, overwriting the previous ones.
+	StoreParticipation(p Participation) error
+
+	// FetchParticipation returns the consensus participation counters of the
+	// provisioner with the given BLS public key. ErrParticipationNotFound is
+	// returned if none were stored.
+	FetchParticipation(pubKeyBLS []byte) (Participation, error)
+
+	// FetchAllParticipation returns the consensus participation counters of
+	// all provisioners, sorted by BLS public key.
+	FetchAllParticipation() ([]Participation, error)
+
+	// StoreBlockParticipation stores the participation counted in the
+	// certificate of a block, under its hash, so that it can be subtracted
+	// from the counters when the block is reverted. It is deleted along with
+	// the block by DeleteBlock.
+	StoreBlockParticipation(hash []byte, p []Participation) error
+
+	// FetchBlockParticipation returns the participation counted in the
+	// certificate of a block. ErrParticipationNotFound is returned if none
+	// was stored.
+	FetchBlockParticipation(hash []byte) ([]Participation, error)
+
 	// ClearDatabase will remove all information from the database.
 	ClearDatabase() error
 
@@ -149,3 +229,24 @@ type DB interface {
 type State struct {
 	TipHash []byte
 }
+
+// TxLocation locates a Tx in the chain, as returned by the secondary tx
+// index lookups.
+type TxLocation struct {
+	Height  uint64
+	TxIndex uint32
+	TxID    []byte
+}
+
+// Participation counts the seats a provisioner had in the reduction
+// committees certifying the accepted blocks, and the votes it cast with
+// them, as recorded in the block certificates.
+type Participation struct {
+	PubKeyBLS []byte
+	// Expected is the amount of seats held in the committees.
+	Expected uint64
+	// Cast is the amount of seats whose vote made it into a certificate.
+	Cast uint64
+	// Missed is the amount of seats whose vote did not.
+	Missed uint64
+}
@@ -30,10 +30,16 @@ const (
 	bidValuesInd
 	outputKeyInd
 	candidateInd
+	evidenceInd
+	participationInd
+	blockParticipationInd
 	maxInd
 )
 
-var stateKey = []byte{1}
+var (
+	stateKey    = []byte{1}
+	executedKey = []byte{2}
+)
 
 // DB represents the db struct.
 type DB struct {
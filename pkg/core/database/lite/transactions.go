@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -95,6 +96,65 @@ func (t *transaction) StoreBlock(b *block.Block) error {
 	return nil
 }
 
+// DeleteBlock removes the chain tip and all its indices. As with the other
+// destructive operations of this driver, changes are applied directly to the
+// storage.
+func (t *transaction) DeleteBlock(hash []byte) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	state, err := t.FetchState()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(state.TipHash, hash) {
+		return database.ErrNotChainTip
+	}
+
+	data, exists := t.db.storage[blocksInd][toKey(hash)]
+	if !exists {
+		return database.ErrBlockNotFound
+	}
+
+	b := block.NewBlock()
+	if err = message.UnmarshalBlock(bytes.NewBuffer(data), b); err != nil {
+		return err
+	}
+
+	if b.Header.Height == 0 {
+		return database.ErrGenesisDeletion
+	}
+
+	prevHash, err := t.FetchBlockHashByHeight(b.Header.Height - 1)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range b.Txs {
+		txID, hashErr := tx.CalculateHash()
+		if hashErr != nil {
+			return hashErr
+		}
+
+		delete(t.db.storage[txsInd], toKey(txID))
+		delete(t.db.storage[txHashInd], toKey(txID))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, b.Header.Height); err != nil {
+		return err
+	}
+
+	delete(t.db.storage[heightInd], toKey(buf.Bytes()))
+	delete(t.db.storage[blocksInd], toKey(hash))
+	delete(t.db.storage[blockParticipationInd], toKey(hash))
+
+	t.db.storage[stateInd][toKey(stateKey)] = prevHash
+	return nil
+}
+
 // Commit writes a batch to LevelDB storage. See also fsyncEnabled variable.
 func (t *transaction) Commit() error {
 	if !t.writable {
@@ -269,6 +329,34 @@ func (t *transaction) FetchBlock(hash []byte) (*block.Block, error) {
 	}, nil
 }
 
+func (t *transaction) StoreExecutedHeight(height uint64) error {
+	if !t.writable {
+		return errors.New("read-only transaction")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := utils.WriteUint64(buf, height); err != nil {
+		return err
+	}
+
+	t.batch[stateInd][toKey(executedKey)] = buf.Bytes()
+	return nil
+}
+
+func (t *transaction) FetchExecutedHeight() (uint64, error) {
+	value, exists := t.db.storage[stateInd][toKey(executedKey)]
+	if !exists {
+		return 0, database.ErrStateNotFound
+	}
+
+	var height uint64
+	if err := utils.ReadUint64(bytes.NewReader(value), &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
 func (t *transaction) FetchCurrentHeight() (uint64, error) {
 	state, err := t.FetchState()
 	if err != nil {
@@ -363,6 +451,72 @@ func (t transaction) FetchBlockHeightSince(sinceUnixTime int64, offset uint64) (
 	return tip - n + pos, nil
 }
 
+// FetchTxsByOutputKey scans the stored blocks, as the in-memory driver keeps
+// no secondary tx index.
+func (t *transaction) FetchTxsByOutputKey(pubKey []byte, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	return t.scanTxs(fromHeight, limit, func(tx transactions.ContractCall) bool {
+		for _, note := range tx.StandardTx().Notes {
+			if note != nil && bytes.Equal(note.PkR, pubKey) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// FetchTxsByType scans the stored blocks, as the in-memory driver keeps no
+// secondary tx index.
+func (t *transaction) FetchTxsByType(txType transactions.TxType, fromHeight uint64, limit int) ([]database.TxLocation, error) {
+	return t.scanTxs(fromHeight, limit, func(tx transactions.ContractCall) bool {
+		return tx.Type() == txType
+	})
+}
+
+func (t *transaction) scanTxs(fromHeight uint64, limit int, match func(transactions.ContractCall) bool) ([]database.TxLocation, error) {
+	tip, err := t.FetchCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]database.TxLocation, 0)
+
+	for height := fromHeight; height <= tip && len(locations) < limit; height++ {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+
+		txs, err := t.FetchBlockTxs(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, tx := range txs {
+			if len(locations) == limit {
+				break
+			}
+
+			if !match(tx) {
+				continue
+			}
+
+			txID, err := tx.CalculateHash()
+			if err != nil {
+				return nil, err
+			}
+
+			locations = append(locations, database.TxLocation{
+				Height:  height,
+				TxIndex: uint32(i),
+				TxID:    txID,
+			})
+		}
+	}
+
+	return locations, nil
+}
+
 func (t *transaction) StoreCandidateMessage(cm block.Block) error {
 	buf := new(bytes.Buffer)
 	if err := message.MarshalBlock(buf, &cm); err != nil {
@@ -395,6 +549,103 @@ func (t *transaction) ClearCandidateMessages() error {
 	return nil
 }
 
+func (t *transaction) StoreEvidence(e message.Evidence) error {
+	id, err := e.ID()
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalEvidence(buf, e); err != nil {
+		return err
+	}
+
+	t.db.storage[evidenceInd][toKey(id)] = buf.Bytes()
+	return nil
+}
+
+func (t *transaction) FetchEvidence() ([]message.Evidence, error) {
+	evidence := make([]message.Evidence, 0, len(t.db.storage[evidenceInd]))
+
+	for _, value := range t.db.storage[evidenceInd] {
+		var e message.Evidence
+		if err := message.UnmarshalEvidence(bytes.NewBuffer(value), &e); err != nil {
+			return nil, err
+		}
+
+		evidence = append(evidence, e)
+	}
+
+	return evidence, nil
+}
+
+func (t *transaction) DeleteEvidence(e message.Evidence) error {
+	id, err := e.ID()
+	if err != nil {
+		return err
+	}
+
+	delete(t.db.storage[evidenceInd], toKey(id))
+	return nil
+}
+
+func (t *transaction) StoreParticipation(p database.Participation) error {
+	value, err := utils.EncodeParticipation(p)
+	if err != nil {
+		return err
+	}
+
+	t.db.storage[participationInd][toKey(p.PubKeyBLS)] = value
+	return nil
+}
+
+func (t *transaction) FetchParticipation(pubKeyBLS []byte) (database.Participation, error) {
+	value, ok := t.db.storage[participationInd][toKey(pubKeyBLS)]
+	if !ok {
+		return database.Participation{}, database.ErrParticipationNotFound
+	}
+
+	return utils.DecodeParticipation(value)
+}
+
+func (t *transaction) FetchAllParticipation() ([]database.Participation, error) {
+	all := make([]database.Participation, 0, len(t.db.storage[participationInd]))
+
+	for _, value := range t.db.storage[participationInd] {
+		p, err := utils.DecodeParticipation(value)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, p)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return bytes.Compare(all[i].PubKeyBLS, all[j].PubKeyBLS) < 0
+	})
+
+	return all, nil
+}
+
+func (t *transaction) StoreBlockParticipation(hash []byte, p []database.Participation) error {
+	value, err := utils.EncodeParticipationList(p)
+	if err != nil {
+		return err
+	}
+
+	t.db.storage[blockParticipationInd][toKey(hash)] = value
+	return nil
+}
+
+func (t *transaction) FetchBlockParticipation(hash []byte) ([]database.Participation, error) {
+	value, ok := t.db.storage[blockParticipationInd][toKey(hash)]
+	if !ok {
+		return nil, database.ErrParticipationNotFound
+	}
+
+	return utils.DecodeParticipationList(value)
+}
+
 func (t transaction) ClearDatabase() error {
 	for key := range t.db.storage {
 		t.db.storage[key] = make(table)
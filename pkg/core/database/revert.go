@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package database
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStateAhead is returned by RevertTip when the Rusk state has executed
+// blocks which would be reverted. Rusk can only step its state forward, so
+// the chain can not be reverted below the executed height.
+var ErrStateAhead = errors.New("the Rusk state has executed the blocks to revert")
+
+// RevertTip deletes the last n blocks of the chain, one atomic Update at a
+// time, and returns the height of the new chain tip. The genesis block can
+// never be reverted.
+//
+// The Rusk state has to follow the chain tip. As it can not be reverted, the
+// revert is refused with ErrStateAhead, before any block is deleted, if the
+// executed height is above the new tip. The only way to bring such a node
+// back is to resync it from scratch, with an empty database and Rusk state.
+//
+// update runs in the transaction deleting each block, before the block is
+// deleted, with the hash of the block. It reverts the records derived from
+// the block, such as the consensus participation counters (see
+// participation.Revert).
+//
+// If an error occurs, the chain tip is left at the last block which was
+// successfully reverted to.
+func RevertTip(db DB, n uint64, update func(t Transaction, hash []byte) error) (uint64, error) {
+	var height, executed uint64
+
+	if err := db.View(func(t Transaction) error {
+		var err error
+		height, err = t.FetchCurrentHeight()
+		if err != nil {
+			return err
+		}
+
+		executed, err = t.FetchExecutedHeight()
+		if err == ErrStateNotFound {
+			// No block executed yet
+			executed = 0
+			return nil
+		}
+
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	if n > height {
+		return height, fmt.Errorf("cannot revert %d blocks from height %d", n, height)
+	}
+
+	if executed > height-n {
+		return height, fmt.Errorf("%w: executed height %d, target height %d", ErrStateAhead, executed, height-n)
+	}
+
+	for i := uint64(0); i < n; i++ {
+		err := db.Update(func(t Transaction) error {
+			s, err := t.FetchState()
+			if err != nil {
+				return err
+			}
+
+			if update != nil {
+				if err := update(t, s.TipHash); err != nil {
+					return err
+				}
+			}
+
+			return t.DeleteBlock(s.TipHash)
+		})
+		if err != nil {
+			return height, err
+		}
+
+		height--
+	}
+
+	return height, nil
+}
@@ -30,9 +30,11 @@ import (
 
 	// Import here any supported drivers to verify if they are fully compliant
 	// to the blockchain database layer requirements.
+	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/badger"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
 )
 
@@ -193,6 +195,136 @@ func TestStoreBlock(test *testing.T) {
 	}
 }
 
+func TestDeleteBlock(test *testing.T) {
+	genBlocks, err := generateChainBlocks(2)
+	require.NoError(test, err)
+	require.NoError(test, storeBlocks(db, genBlocks))
+
+	prev, tip := genBlocks[0], genBlocks[1]
+
+	// Only the chain tip can be deleted
+	err = db.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(prev.Header.Hash)
+	})
+	require.Equal(test, database.ErrNotChainTip, err)
+
+	require.NoError(test, db.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(tip.Header.Hash)
+	}))
+
+	require.NoError(test, db.View(func(t database.Transaction) error {
+		s, err1 := t.FetchState()
+		require.NoError(test, err1)
+		require.Equal(test, prev.Header.Hash, s.TipHash)
+
+		_, err1 = t.FetchBlockExists(tip.Header.Hash)
+		require.Equal(test, database.ErrBlockNotFound, err1)
+
+		_, err1 = t.FetchBlockHashByHeight(tip.Header.Height)
+		require.Equal(test, database.ErrBlockNotFound, err1)
+
+		for _, tx := range tip.Txs {
+			txID, err2 := tx.CalculateHash()
+			require.NoError(test, err2)
+
+			_, _, _, err2 = t.FetchBlockTxByHash(txID)
+			require.Equal(test, database.ErrTxNotFound, err2)
+		}
+
+		// The previous block should be left untouched
+		txs, err1 := t.FetchBlockTxs(prev.Header.Hash)
+		require.NoError(test, err1)
+		require.Equal(test, len(prev.Txs), len(txs))
+		return nil
+	}))
+
+	// Leave the chain tip as it was found
+	require.NoError(test, db.Update(func(t database.Transaction) error {
+		return t.DeleteBlock(prev.Header.Hash)
+	}))
+}
+
+func TestRevertTip(test *testing.T) {
+	genBlocks, err := generateChainBlocks(3)
+	require.NoError(test, err)
+	require.NoError(test, storeBlocks(db, genBlocks))
+
+	tip := genBlocks[len(genBlocks)-1].Header.Height
+
+	// Reverting more blocks than the chain height is refused
+	height, err := database.RevertTip(db, tip+1, nil)
+	require.Error(test, err)
+	require.Equal(test, tip, height)
+
+	// Reverting blocks executed by the Rusk state is refused
+	require.NoError(test, db.Update(func(t database.Transaction) error {
+		return t.StoreExecutedHeight(tip)
+	}))
+
+	height, err = database.RevertTip(db, 2, nil)
+	require.True(test, errors.Is(err, database.ErrStateAhead))
+	require.Equal(test, tip, height)
+
+	require.NoError(test, db.View(func(t database.Transaction) error {
+		h, err1 := t.FetchCurrentHeight()
+		require.NoError(test, err1)
+		require.Equal(test, tip, h)
+		return nil
+	}))
+
+	require.NoError(test, db.Update(func(t database.Transaction) error {
+		return t.StoreExecutedHeight(tip - 2)
+	}))
+
+	// The participation of each reverted block is still readable by update
+	pk, _ := crypto.RandEntropy(129)
+	recorded := []database.Participation{{PubKeyBLS: pk, Expected: 2, Cast: 1, Missed: 1}}
+
+	require.NoError(test, db.Update(func(t database.Transaction) error {
+		for _, blk := range genBlocks[1:] {
+			if err := t.StoreBlockParticipation(blk.Header.Hash, recorded); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	reverted := make([][]byte, 0)
+
+	height, err = database.RevertTip(db, 2, func(t database.Transaction, hash []byte) error {
+		p, err1 := t.FetchBlockParticipation(hash)
+		require.NoError(test, err1)
+		require.Equal(test, recorded, p)
+
+		reverted = append(reverted, hash)
+		return nil
+	})
+	require.NoError(test, err)
+	require.Equal(test, tip-2, height)
+	require.Equal(test, [][]byte{genBlocks[2].Header.Hash, genBlocks[1].Header.Hash}, reverted)
+
+	require.NoError(test, db.View(func(t database.Transaction) error {
+		h, err1 := t.FetchCurrentHeight()
+		require.NoError(test, err1)
+		require.Equal(test, tip-2, h)
+
+		s, err1 := t.FetchState()
+		require.NoError(test, err1)
+		require.Equal(test, genBlocks[0].Header.Hash, s.TipHash)
+
+		for _, blk := range genBlocks[1:] {
+			_, err1 = t.FetchBlockExists(blk.Header.Hash)
+			require.Equal(test, database.ErrBlockNotFound, err1)
+
+			_, err1 = t.FetchBlockParticipation(blk.Header.Hash)
+			require.Equal(test, database.ErrParticipationNotFound, err1)
+		}
+
+		return nil
+	}))
+}
+
 func TestFetchBlockExists(test *testing.T) {
 	test.Parallel()
 
@@ -714,6 +846,93 @@ func TestStoreFetchBidValues(test *testing.T) {
 	}))
 }
 
+func TestStoreFetchDeleteEvidence(test *testing.T) {
+	first, _ := crypto.RandEntropy(32)
+	second, _ := crypto.RandEntropy(32)
+	pk, _ := crypto.RandEntropy(96)
+
+	e := message.Evidence{
+		Topic:     topics.Reduction,
+		Round:     10,
+		Step:      2,
+		PubKeyBLS: pk,
+		First:     first,
+		Second:    second,
+	}
+
+	assert.NoError(test, db.Update(func(t database.Transaction) error {
+		// Storing the same equivocation twice should keep a single entry
+		if err := t.StoreEvidence(e); err != nil {
+			return err
+		}
+
+		return t.StoreEvidence(e)
+	}))
+
+	assert.NoError(test, db.View(func(t database.Transaction) error {
+		evidence, err := t.FetchEvidence()
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(test, []message.Evidence{e}, evidence)
+		return nil
+	}))
+
+	assert.NoError(test, db.Update(func(t database.Transaction) error {
+		return t.DeleteEvidence(e)
+	}))
+
+	assert.NoError(test, db.View(func(t database.Transaction) error {
+		evidence, err := t.FetchEvidence()
+		if err != nil {
+			return err
+		}
+
+		assert.Empty(test, evidence)
+		return nil
+	}))
+}
+
+func TestStoreFetchParticipation(test *testing.T) {
+	pk1, _ := crypto.RandEntropy(129)
+	pk2, _ := crypto.RandEntropy(129)
+
+	p1 := database.Participation{PubKeyBLS: pk1, Expected: 10, Cast: 7, Missed: 3}
+	p2 := database.Participation{PubKeyBLS: pk2, Expected: 4, Cast: 4}
+
+	assert.NoError(test, db.Update(func(t database.Transaction) error {
+		if err := t.StoreParticipation(p1); err != nil {
+			return err
+		}
+
+		return t.StoreParticipation(p2)
+	}))
+
+	assert.NoError(test, db.View(func(t database.Transaction) error {
+		stored, err := t.FetchParticipation(pk1)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(test, p1, stored)
+
+		unknown, _ := crypto.RandEntropy(129)
+		if _, err := t.FetchParticipation(unknown); err != database.ErrParticipationNotFound {
+			test.Fatal("ErrParticipationNotFound is expected when non-existing participation is looked up")
+		}
+
+		all, err := t.FetchAllParticipation()
+		if err != nil {
+			return err
+		}
+
+		assert.ElementsMatch(test, []database.Participation{p1, p2}, all)
+		assert.True(test, bytes.Compare(all[0].PubKeyBLS, all[1].PubKeyBLS) < 0)
+		return nil
+	}))
+}
+
 // _TestPersistence tries to ensure if driver provides persistence storage.
 // The procedure is simply based on:
 // 1. Close the driver
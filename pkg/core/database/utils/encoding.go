@@ -15,6 +15,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 )
 
 var byteOrder = binary.LittleEndian
@@ -77,6 +78,93 @@ func DecodeBlockTx(data []byte, typeFilter transactions.TxType) (transactions.Co
 	return tx, txIndex, err
 }
 
+// EncodeParticipation serializes a database.Participation.
+func EncodeParticipation(p database.Participation) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	if err := encoding.WriteVarBytes(buf, p.PubKeyBLS); err != nil {
+		return nil, err
+	}
+
+	for _, v := range []uint64{p.Expected, p.Cast, p.Missed} {
+		if err := WriteUint64(buf, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeParticipation deserializes a database.Participation encoded by
+// EncodeParticipation.
+func DecodeParticipation(data []byte) (database.Participation, error) {
+	var p database.Participation
+
+	buf := bytes.NewBuffer(data)
+	if err := encoding.ReadVarBytes(buf, &p.PubKeyBLS); err != nil {
+		return database.Participation{}, err
+	}
+
+	for _, v := range []*uint64{&p.Expected, &p.Cast, &p.Missed} {
+		if err := ReadUint64(buf, v); err != nil {
+			return database.Participation{}, err
+		}
+	}
+
+	return p, nil
+}
+
+// EncodeParticipationList serializes a list of database.Participation.
+func EncodeParticipationList(list []database.Participation) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	if err := encoding.WriteVarInt(buf, uint64(len(list))); err != nil {
+		return nil, err
+	}
+
+	for _, p := range list {
+		value, err := EncodeParticipation(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := encoding.WriteVarBytes(buf, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeParticipationList deserializes a list of database.Participation
+// encoded by EncodeParticipationList.
+func DecodeParticipationList(data []byte) ([]database.Participation, error) {
+	buf := bytes.NewBuffer(data)
+
+	n, err := encoding.ReadVarInt(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]database.Participation, 0, n)
+
+	for i := uint64(0); i < n; i++ {
+		var value []byte
+		if err := encoding.ReadVarBytes(buf, &value); err != nil {
+			return nil, err
+		}
+
+		p, err := DecodeParticipation(value)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, p)
+	}
+
+	return list, nil
+}
+
 // WriteUint32 Tx utility to use a Tx byteOrder on internal encoding.
 func WriteUint32(w io.Writer, value uint32) error {
 	var b [4]byte
@@ -110,3 +198,16 @@ func WriteUint64(w io.Writer, value uint64) error {
 	_, err := w.Write(b[:])
 	return err
 }
+
+// ReadUint64 will read eight bytes and convert them to a uint64 from the Tx
+// byteOrder. The result is put into v.
+func ReadUint64(r io.Reader, v *uint64) error {
+	var b [8]byte
+
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return err
+	}
+
+	*v = byteOrder.Uint64(b[:])
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/firststep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/secondstep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
@@ -126,6 +127,7 @@ func (c *Consensus) CreateStateMachine(db database.DB, consensusTimeOut time.Dur
 func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.Controller, round consensus.RoundUpdate) consensus.Results {
 	// Ensure the eventQueue is emptied when the round is finished.
 	defer c.eventQueue.Clear(round.Round)
+	defer c.reportQueueStats(round.Round)
 
 	// we create two context cancelation from the same parent context. This way
 	// we can let the agreement interrupt the stateMachine's loop cycle.
@@ -215,6 +217,55 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 
 var steps = []string{"selection", "reduction1", "reduction2"} // nolint
 
+// reportQueueStats logs the amount of future events dropped by the queues so
+// far, if any, and stores the queue counters for the monitoring API.
+func (c *Consensus) reportQueueStats(round uint64) {
+	for name, q := range map[string]*consensus.Queue{"event": c.eventQueue, "round": c.roundQueue} {
+		stats := q.Stats()
+		storeQueueStats(name, round, stats)
+
+		if stats.Dropped() == 0 {
+			continue
+		}
+
+		lg.
+			WithFields(log.Fields{
+				"round":              round,
+				"queue":              name,
+				"queued":             stats.Queued,
+				"dropped_far_future": stats.DroppedFarFuture,
+				"dropped_step_full":  stats.DroppedStepFull,
+				"dropped_duplicate":  stats.DroppedDuplicate,
+			}).
+			Debugln("consensus queue dropped events")
+	}
+}
+
+// storeQueueStats saves the counters of a queue, served by the
+// /consensus/eventqueuestats API route.
+func storeQueueStats(name string, round uint64, stats consensus.QueueStats) {
+	if !config.Get().API.Enabled {
+		return
+	}
+
+	go func() {
+		store := capi.GetStormDBInstance()
+		statsJSON := capi.EventQueueStatsJSON{
+			Queue:            name,
+			Round:            round,
+			Queued:           stats.Queued,
+			DroppedFarFuture: stats.DroppedFarFuture,
+			DroppedStepFull:  stats.DroppedStepFull,
+			DroppedDuplicate: stats.DroppedDuplicate,
+			UpdatedAt:        time.Now(),
+		}
+
+		if err := store.Save(&statsJSON); err != nil {
+			lg.WithError(err).Error("failed to save event queue stats into StormDB")
+		}
+	}()
+}
+
 func report(round uint64, step uint8) {
 	/*
 		store := capi.GetBuntStoreInstance()
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+)
+
+// EventType is the kind of change a mempool Event notifies about.
+type EventType uint8
+
+const (
+	// EventAccepted a tx has been verified and added to the pool.
+	EventAccepted EventType = iota
+	// EventRejected a tx has been refused by the mempool.
+	EventRejected
+	// EventEvicted a tx has been removed from the pool before being included
+	// in a block.
+	EventEvicted
+	// EventIncluded a tx has been removed from the pool as it is part of an
+	// accepted block.
+	EventIncluded
+)
+
+func (e EventType) String() string {
+	switch e {
+	case EventAccepted:
+		return "accepted"
+	case EventRejected:
+		return "rejected"
+	case EventEvicted:
+		return "evicted"
+	case EventIncluded:
+		return "included"
+	default:
+		return "unknown"
+	}
+}
+
+// Event notifies about a tx entering or leaving the mempool. Events are
+// published on topics.MempoolEvent.
+type Event struct {
+	Type   EventType
+	TxID   []byte
+	TxType transactions.TxType
+
+	// Reason is set for rejected and evicted txs.
+	Reason string
+	// Height is the height of the block including the tx. It is only set for
+	// included txs.
+	Height uint64
+}
+
+// Copy complies with message.Safe interface. It returns a deep copy of
+// the message safe to publish to multiple subscribers.
+func (e Event) Copy() payload.Safe {
+	cpy := e
+	cpy.TxID = make([]byte, len(e.TxID))
+	copy(cpy.TxID, e.TxID)
+
+	return cpy
+}
+
+// publishEvent notifies the subscribers of topics.MempoolEvent.
+func (m *Mempool) publishEvent(e Event) {
+	// Subsystems listening for this topic:
+	// mempool.Mempool Events stream
+	// gql.notifications.Broker
+	msg := message.New(topics.MempoolEvent, e)
+	errList := m.eventBus.Publish(topics.MempoolEvent, msg)
+
+	diagnostics.LogPublishErrors("mempool/events.go, topics.MempoolEvent", errList)
+}
+
+// notifyProcessed publishes the outcome of the processing of a tx.
+func (m *Mempool) notifyProcessed(t TxDesc, txid []byte, err error) {
+	if len(txid) == 0 {
+		return
+	}
+
+	e := Event{Type: EventAccepted, TxID: txid, TxType: t.tx.Type()}
+	if err != nil {
+		e.Type = EventRejected
+		e.Reason = err.Error()
+	}
+
+	m.publishEvent(e)
+}
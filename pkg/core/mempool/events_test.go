@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool/mempoolpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestMempoolEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, bus, _, _ := startMempoolTest(ctx)
+
+	eventChan := make(chan message.Message, 10)
+	bus.Subscribe(topics.MempoolEvent, eventbus.NewChanListener(eventChan))
+
+	next := func() Event {
+		select {
+		case msg := <-eventChan:
+			return msg.Payload().(Event)
+		case <-time.After(time.Second):
+			t.Fatal("mempool event not published")
+		}
+
+		return Event{}
+	}
+
+	// Accepted
+	accepted := txDescWithFee(10, 100)
+	_, err := m.processTx(accepted)
+	assert.NoError(err)
+
+	e := next()
+	assert.Equal(EventAccepted, e.Type)
+	assert.Equal(hashOf(t, accepted), e.TxID)
+
+	// Rejected, with the reason
+	invalid := txDescWithFee(10, 100)
+	transactions.Invalidate(invalid.tx)
+
+	_, err = m.processTx(invalid)
+	assert.Error(err)
+
+	e = next()
+	assert.Equal(EventRejected, e.Type)
+	assert.Equal(err.Error(), e.Reason)
+
+	// Evicted
+	var k txHash
+
+	copy(k[:], hashOf(t, accepted))
+	m.evict([]txHash{k}, "expired")
+
+	e = next()
+	assert.Equal(EventEvicted, e.Type)
+	assert.Equal("expired", e.Reason)
+
+	// Included
+	included := txDescWithFee(10, 100)
+	_, err = m.processTx(included)
+	assert.NoError(err)
+	assert.Equal(EventAccepted, next().Type)
+
+	b := helper.RandomBlock(200, 0)
+	b.Txs = []transactions.ContractCall{included.tx}
+	m.removeAccepted(*b)
+
+	e = next()
+	assert.Equal(EventIncluded, e.Type)
+	assert.Equal(uint64(200), e.Height)
+}
+
+type mockEventsServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *mempoolpb.MempoolEvent
+}
+
+func (s *mockEventsServer) Send(e *mempoolpb.MempoolEvent) error {
+	select {
+	case s.sent <- e:
+	default:
+	}
+
+	return nil
+}
+
+func (s *mockEventsServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestEventsStream(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	streamCtx, closeStream := context.WithCancel(context.Background())
+	stream := &mockEventsServer{ctx: streamCtx, sent: make(chan *mempoolpb.MempoolEvent, 1)}
+
+	done := make(chan error)
+
+	go func() {
+		done <- m.Events(&mempoolpb.EventsRequest{}, stream)
+	}()
+
+	// Wait for the stream to be subscribed
+	var e *mempoolpb.MempoolEvent
+
+	for e == nil {
+		_, err := m.processTx(txDescWithFee(10, 100))
+		assert.NoError(err)
+
+		select {
+		case e = <-stream.sent:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	assert.Equal("accepted", e.Type)
+	_, err := hex.DecodeString(e.Id)
+	assert.NoError(err)
+
+	// The stream ends when the client goes away
+	closeStream()
+	assert.NoError(<-done)
+}
@@ -0,0 +1,177 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+)
+
+// ErrMempoolFull the mempool is full and the tx does not pay enough to replace
+// any of the pooled ones.
+var ErrMempoolFull = errors.New("mempool is full, dropping transaction")
+
+// evictionCandidates returns the keys of the lowest-fee txs which need to be
+// evicted in order to make room for t. Only txs paying a lower fee than t are
+// considered. The txs which t replaces are accounted as already evicted. If
+// not enough space can be freed, ErrMempoolFull is returned.
+//
+// The candidates are not removed from the pool, so that the caller can decide
+// to evict them only once t has been verified.
+func (m *Mempool) evictionCandidates(t TxDesc, replaced map[txHash]TxDesc) ([]txHash, error) {
+	maxSizeBytes := config.Get().Mempool.MaxSizeMB * 1000 * 1000
+
+	size := m.verified.Size() + uint32(t.size)
+	for _, d := range replaced {
+		size -= uint32(d.size)
+	}
+
+	if size <= maxSizeBytes {
+		return nil, nil
+	}
+
+	// RangeSort walks the pool by descending fee, so the cheapest txs are
+	// found at the end of the list.
+	sorted := make([]TxDesc, 0, m.verified.Len())
+	keys := make([]txHash, 0, m.verified.Len())
+
+	_ = m.verified.RangeSort(func(k txHash, d TxDesc) (bool, error) {
+		sorted = append(sorted, d)
+		keys = append(keys, k)
+		return false, nil
+	})
+
+	_, fee := t.tx.Values()
+	evicted := make([]txHash, 0)
+
+	for i := len(sorted) - 1; i >= 0 && size > maxSizeBytes; i-- {
+		if _, ok := replaced[keys[i]]; ok {
+			continue
+		}
+
+		if _, f := sorted[i].tx.Values(); f >= fee {
+			break
+		}
+
+		evicted = append(evicted, keys[i])
+		size -= uint32(sorted[i].size)
+	}
+
+	if size > maxSizeBytes {
+		return nil, ErrMempoolFull
+	}
+
+	return evicted, nil
+}
+
+// evict removes txs from the verified pool.
+func (m *Mempool) evict(keys []txHash, reason string) {
+	for _, k := range keys {
+		tx := m.removeTx(k)
+		if tx == nil {
+			continue
+		}
+
+		log.WithField("txid", toHex(k[:])).
+			WithField("reason", reason).
+			Trace("evicted transaction")
+
+		m.publishEvent(Event{
+			Type:   EventEvicted,
+			TxID:   append([]byte{}, k[:]...),
+			TxType: tx.Type(),
+			Reason: reason,
+		})
+	}
+}
+
+// removeTx deletes a tx from the verified pool. It returns the removed tx, or
+// nil if it was not in the pool.
+func (m *Mempool) removeTx(k txHash) transactions.ContractCall {
+	tx := m.verified.Get(k[:])
+	if tx == nil {
+		return nil
+	}
+
+	m.verified.Delete(k[:])
+	m.spent.remove(k, tx)
+
+	return tx
+}
+
+// expireTxs removes all txs which have been in the pool for longer than the
+// configured TTL. It returns the number of txs removed.
+func (m *Mempool) expireTxs(now time.Time) int {
+	ttl := config.Get().Mempool.TxTTL
+	if ttl == 0 {
+		return 0
+	}
+
+	expired := make([]txHash, 0)
+
+	_ = m.verified.Range(func(k txHash, t TxDesc) error {
+		if now.Sub(t.received) > time.Duration(ttl)*time.Second {
+			expired = append(expired, k)
+		}
+
+		return nil
+	})
+
+	m.evict(expired, "expired")
+	return len(expired)
+}
+
+// reverifyOldest runs the verification procedure again on the oldest txs of
+// the pool, and removes the ones which are no longer valid. This gets rid of
+// txs which somehow were accepted into the blockchain without being removed
+// from the pool, or which conflict with the current chain state. It returns
+// the number of txs removed.
+func (m *Mempool) reverifyOldest() int {
+	count := int(config.Get().Mempool.ReverifyTxs)
+	if count == 0 || m.verified.Len() == 0 {
+		return 0
+	}
+
+	type entry struct {
+		k txHash
+		t TxDesc
+	}
+
+	entries := make([]entry, 0, m.verified.Len())
+
+	_ = m.verified.Range(func(k txHash, t TxDesc) error {
+		entries = append(entries, entry{k, t})
+		return nil
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].t.received.Before(entries[j].t.received)
+	})
+
+	if len(entries) > count {
+		entries = entries[:count]
+	}
+
+	invalid := make([]txHash, 0)
+
+	for _, e := range entries {
+		if err := m.checkTx(e.t.tx); err != nil {
+			log.WithError(err).
+				WithField("txid", toHex(e.k[:])).
+				Debug("pooled transaction no longer valid")
+
+			invalid = append(invalid, e.k)
+		}
+	}
+
+	m.evict(invalid, "invalid")
+	return len(invalid)
+}
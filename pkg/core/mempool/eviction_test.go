@@ -0,0 +1,140 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	assert "github.com/stretchr/testify/require"
+)
+
+// Test that a higher-fee tx replaces the cheapest ones when the pool is
+// full, while a lower-fee one is dropped.
+func TestEvictLowestFee(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	// MaxSizeMB is 1, so that only two of these txs fit in the pool
+	const size = 400 * 1000
+
+	cheap := txDescWithFee(10, size)
+	expensive := txDescWithFee(30, size)
+
+	_, err := m.processTx(cheap)
+	assert.NoError(err)
+	_, err = m.processTx(expensive)
+	assert.NoError(err)
+
+	// Paying less than any pooled tx is not enough
+	_, err = m.processTx(txDescWithFee(5, size))
+	assert.Equal(ErrMempoolFull, err)
+
+	// Paying more than the cheapest one evicts it
+	replacement := txDescWithFee(20, size)
+	_, err = m.processTx(replacement)
+	assert.NoError(err)
+
+	assert.Equal(2, m.verified.Len())
+	assert.False(m.verified.Contains(hashOf(t, cheap)))
+	assert.True(m.verified.Contains(hashOf(t, expensive)))
+	assert.True(m.verified.Contains(hashOf(t, replacement)))
+}
+
+func TestExpireTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	r := config.Get()
+	r.Mempool.TxTTL = 60
+	config.Mock(&r)
+
+	defer func() {
+		r.Mempool.TxTTL = 0
+		config.Mock(&r)
+	}()
+
+	old := txDescWithFee(10, 100)
+	old.received = time.Now().Add(-2 * time.Minute)
+	fresh := txDescWithFee(10, 100)
+
+	assert.NoError(m.verified.Put(old))
+	assert.NoError(m.verified.Put(fresh))
+
+	assert.Equal(1, m.expireTxs(time.Now()))
+	assert.False(m.verified.Contains(hashOf(t, old)))
+	assert.True(m.verified.Contains(hashOf(t, fresh)))
+}
+
+// Test that only the oldest txs are re-verified, and that the ones not
+// valid anymore are removed.
+func TestReverifyOldest(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	r := config.Get()
+	r.Mempool.ReverifyTxs = 2
+	config.Mock(&r)
+
+	defer func() {
+		r.Mempool.ReverifyTxs = 0
+		config.Mock(&r)
+	}()
+
+	now := time.Now()
+	descs := make([]TxDesc, 3)
+
+	for i := range descs {
+		descs[i] = txDescWithFee(10, 100)
+		descs[i].received = now.Add(time.Duration(i-3) * time.Minute)
+		assert.NoError(m.verified.Put(descs[i]))
+	}
+
+	keys := make([][]byte, len(descs))
+	for i := range descs {
+		keys[i] = hashOf(t, descs[i])
+	}
+
+	// The oldest and the newest txs are no longer valid. Only the former
+	// is checked.
+	transactions.Invalidate(descs[0].tx)
+	transactions.Invalidate(descs[2].tx)
+
+	assert.Equal(1, m.reverifyOldest())
+	assert.False(m.verified.Contains(keys[0]))
+	assert.True(m.verified.Contains(keys[1]))
+	assert.True(m.verified.Contains(keys[2]))
+}
+
+func txDescWithFee(fee uint64, size uint) TxDesc {
+	tx := transactions.RandTx()
+	tx.Payload.Fee.GasLimit = fee
+	tx.Payload.Fee.GasPrice = 1
+
+	return TxDesc{tx: tx, received: time.Now(), size: size}
+}
+
+func hashOf(t *testing.T, d TxDesc) []byte {
+	txid, err := d.tx.CalculateHash()
+	assert.NoError(t, err)
+	return txid
+}
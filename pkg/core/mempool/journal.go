@@ -0,0 +1,237 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+)
+
+// journal persists the verified txs on disk, so that they survive a node
+// restart. Accepted txs are appended to the journal file as they come, while
+// the txs removed from the pool are only dropped from it when the journal
+// is rotated.
+//
+// Each record consists of the time the tx was received, as a unix timestamp
+// in nanoseconds, followed by the marshaled tx.
+type journal struct {
+	lock sync.Mutex
+	path string
+
+	// file is the append handle. It is nil until the journal is rotated for
+	// the first time, so that the txs being replayed are not written twice.
+	file *os.File
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads all the txs stored in the journal. A missing journal is not an
+// error. Reading stops at the first incomplete record, which is what is left
+// behind by a node crashing in the middle of a write.
+func (j *journal) load() ([]TxDesc, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	data, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewBuffer(data)
+	txs := make([]TxDesc, 0)
+
+	for r.Len() > 0 {
+		var received uint64
+		if err := encoding.ReadUint64LE(r, &received); err != nil {
+			break
+		}
+
+		var txBytes []byte
+		if err := encoding.ReadVarBytes(r, &txBytes); err != nil {
+			break
+		}
+
+		tx := transactions.NewTransaction()
+		if err := transactions.Unmarshal(bytes.NewBuffer(txBytes), tx); err != nil {
+			log.WithError(err).Warn("skipping malformed journal record")
+			continue
+		}
+
+		txs = append(txs, TxDesc{
+			tx:       tx,
+			received: time.Unix(0, int64(received)),
+			size:     uint(len(txBytes)),
+		})
+	}
+
+	return txs, nil
+}
+
+// insert appends a tx to the journal.
+func (j *journal) insert(t TxDesc) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+
+	buf, err := encodeJournalRecord(t)
+	if err != nil {
+		return err
+	}
+
+	_, err = j.file.Write(buf.Bytes())
+	return err
+}
+
+// rotate rewrites the journal with the content of the pool. The new journal
+// is written aside and then moved in place, so that a crash never leaves a
+// partially written journal behind.
+func (j *journal) rotate(p Pool) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	buf := new(bytes.Buffer)
+
+	err := p.Range(func(k txHash, t TxDesc) error {
+		record, err := encodeJournalRecord(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = buf.Write(record.Bytes())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if j.file != nil {
+		_ = j.file.Close()
+		j.file = nil
+	}
+
+	tmpPath := j.path + ".new"
+	if err = ioutil.WriteFile(tmpPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	return err
+}
+
+// close the append handle.
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+
+	err := j.file.Close()
+	j.file = nil
+
+	return err
+}
+
+// loadJournal replays the txs stored in the journal through the full
+// verification procedure, so that the ones which became invalid while the
+// node was down are dropped. The journal is then rotated to only keep the
+// txs which made it back into the pool.
+func (m *Mempool) loadJournal() {
+	if m.journal == nil {
+		return
+	}
+
+	l := log.WithField("path", m.journal.path)
+
+	txs, err := m.journal.load()
+	if err != nil {
+		l.WithError(err).Error("could not load mempool journal")
+	}
+
+	var restored int
+
+	for _, t := range txs {
+		t.kadHeight = config.KadcastInitialHeight
+
+		if txid, err := m.processTx(t); err != nil {
+			l.WithError(err).WithField("txid", toHex(txid)).
+				Debug("journaled transaction not restored")
+			continue
+		}
+
+		restored++
+	}
+
+	l.WithField("journaled_txs_count", len(txs)).
+		WithField("restored_txs_count", restored).
+		Info("mempool journal loaded")
+
+	m.rotateJournal()
+}
+
+// rotateJournal rewrites the journal with the current content of the pool.
+func (m *Mempool) rotateJournal() {
+	if m.journal == nil {
+		return
+	}
+
+	if err := m.journal.rotate(m.verified); err != nil {
+		log.WithError(err).Error("could not rotate mempool journal")
+	}
+}
+
+// closeJournal rotates the journal one last time and releases it.
+func (m *Mempool) closeJournal() {
+	if m.journal == nil {
+		return
+	}
+
+	m.rotateJournal()
+
+	if err := m.journal.close(); err != nil {
+		log.WithError(err).Warn("could not close mempool journal")
+	}
+}
+
+func encodeJournalRecord(t TxDesc) (*bytes.Buffer, error) {
+	txBuf := new(bytes.Buffer)
+	if err := transactions.Marshal(txBuf, t.tx); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := encoding.WriteUint64LE(buf, uint64(t.received.UnixNano())); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteVarBytes(buf, txBuf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestJournalRotateAndLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "mempool_journal_")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	j := newJournal(filepath.Join(dir, "mempool.journal"))
+
+	// A missing journal is an empty one
+	txs, err := j.load()
+	assert.NoError(err)
+	assert.Empty(txs)
+
+	pool := &HashMap{lock: &sync.RWMutex{}, Capacity: 10}
+	received := time.Now().Add(-time.Minute)
+
+	assert.NoError(pool.Put(TxDesc{tx: transactions.RandTx(), received: received}))
+	assert.NoError(j.rotate(pool))
+
+	// Txs inserted after the rotation are appended
+	assert.NoError(j.insert(TxDesc{tx: transactions.RandTx(), received: received}))
+	assert.NoError(j.close())
+
+	txs, err = j.load()
+	assert.NoError(err)
+	assert.Len(txs, 2)
+
+	for _, d := range txs {
+		assert.Equal(received.UnixNano(), d.received.UnixNano())
+	}
+
+	// An incomplete record at the end of the journal is ignored
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	assert.NoError(err)
+	_, err = f.Write([]byte{1, 2, 3})
+	assert.NoError(err)
+	assert.NoError(f.Close())
+
+	txs, err = j.load()
+	assert.NoError(err)
+	assert.Len(txs, 2)
+}
+
+// Test that the journaled txs are given back to the mempool on startup, and
+// that the ones which became invalid are dropped.
+func TestMempoolJournalReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir(os.TempDir(), "mempool_journal_")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	j := newJournal(filepath.Join(dir, "mempool.journal"))
+
+	valid := transactions.RandTx()
+	invalid := transactions.RandTx()
+	transactions.Invalidate(invalid)
+
+	pool := &HashMap{lock: &sync.RWMutex{}, Capacity: 10}
+	assert.NoError(pool.Put(TxDesc{tx: valid, received: time.Now()}))
+	assert.NoError(pool.Put(TxDesc{tx: invalid, received: time.Now()}))
+	assert.NoError(j.rotate(pool))
+	assert.NoError(j.close())
+
+	bus, _ := eventbus.CreateGossipStreamer()
+	v := &transactions.MockProxy{}
+	m := NewMempool(bus, rpcbus.New(), v.Prober(), nil)
+	m.journal = j
+
+	m.loadJournal()
+
+	assert.Equal(1, m.verified.Len())
+	assert.True(m.verified.Contains(hashOf(t, TxDesc{tx: valid})))
+
+	// The journal only keeps what made it back into the pool
+	assert.NoError(j.close())
+
+	txs, err := j.load()
+	assert.NoError(err)
+	assert.Len(txs, 1)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool/mempoolpb"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -34,7 +35,15 @@ import (
 
 var log = logger.WithFields(logger.Fields{"prefix": "mempool"})
 
-const idleTime = 20 * time.Second
+const (
+	idleTime = 20 * time.Second
+
+	// maintenanceTime is the interval at which the expired txs are removed,
+	// the oldest ones re-verified and the journal rotated. Unlike the idle
+	// timer, it is not postponed by the incoming events, so that it keeps
+	// running on a busy node.
+	maintenanceTime = 30 * time.Second
+)
 
 var (
 	// ErrCoinbaseTxNotAllowed coinbase tx must be built by block generator only.
@@ -43,6 +52,8 @@ var (
 	ErrAlreadyExists = errors.New("already exists")
 	// ErrDoubleSpending transaction uses outputs spent in other mempool txs.
 	ErrDoubleSpending = errors.New("double-spending in mempool")
+	// ErrVerification transaction is refused by the verifier.
+	ErrVerification = errors.New("verification err")
 )
 
 // Mempool is a storage for the chain transactions that are valid according to the
@@ -58,6 +69,9 @@ type Mempool struct {
 	// the collector to listen for new accepted blocks.
 	acceptedBlockChan <-chan block.Block
 
+	// the collector to listen for blocks reverted on a chain reorganisation.
+	revertedBlockChan <-chan block.Block
+
 	// used by tx verification procedure.
 	latestBlockTimestamp int64
 
@@ -65,6 +79,15 @@ type Mempool struct {
 
 	// the magic function that knows best what is valid chain Tx.
 	verifier transactions.UnconfirmedTxProber
+
+	// journal persists the verified txs across restarts. Nil if disabled.
+	journal *journal
+
+	// spent indexes the inputs of the verified txs.
+	spent *spentIndex
+
+	// peers accounts the txs received from each network peer.
+	peers *peerLimiter
 }
 
 // checkTx is responsible to determine if a tx is valid or not.
@@ -102,25 +125,35 @@ func NewMempool(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, verifier tra
 	}
 
 	acceptedBlockChan, _ := consensus.InitAcceptedBlockUpdate(eventBus)
+	revertedBlockChan, _ := consensus.InitRevertedBlockUpdate(eventBus)
 
 	m := &Mempool{
 		eventBus:                eventBus,
 		latestBlockTimestamp:    math.MinInt32,
 		acceptedBlockChan:       acceptedBlockChan,
+		revertedBlockChan:       revertedBlockChan,
 		getMempoolTxsChan:       getMempoolTxsChan,
 		getMempoolTxsBySizeChan: getMempoolTxsBySizeChan,
 		sendTxChan:              sendTxChan,
 		verifier:                verifier,
+		spent:                   newSpentIndex(),
+		peers:                   newPeerLimiter(),
 	}
 
 	// Setting the pool where to cache verified transactions.
-	// The pool is normally a Hashmap
+	// The pool is either a Hashmap or a SkipList
 	m.verified = m.newPool()
 
+	if path := config.Get().Mempool.JournalPath; path != "" {
+		m.journal = newJournal(path)
+	}
+
 	log.WithField("type", config.Get().Mempool.PoolType).Info("running")
 
 	if srv != nil {
-		node.RegisterMempoolServer(srv, m)
+		// The Mempool service of dusk-protobuf, extended with the Events
+		// stream
+		mempoolpb.RegisterMempoolServer(srv, m)
 	}
 
 	return m
@@ -134,9 +167,14 @@ func NewMempool(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, verifier tra
 // protection-by-mutex needed.
 func (m *Mempool) Run(ctx context.Context) {
 	go func() {
+		m.loadJournal()
+
 		ticker := time.NewTicker(idleTime)
 		defer ticker.Stop()
 
+		maintenanceTicker := time.NewTicker(maintenanceTime)
+		defer maintenanceTicker.Stop()
+
 		for {
 			select {
 			// rpcbus methods.
@@ -148,11 +186,18 @@ func (m *Mempool) Run(ctx context.Context) {
 				handleRequest(r, m.processGetMempoolTxsBySizeRequest, "GetMempoolTxsBySize")
 			case b := <-m.acceptedBlockChan:
 				m.onBlock(b)
+			case b := <-m.revertedBlockChan:
+				m.onRevertedBlock(b)
+			case <-maintenanceTicker.C:
+				m.onMaintenance()
+				// The idle timer is not reset by the maintenance.
+				continue
 			case <-ticker.C:
 				m.onIdle()
 			// Mempool terminating.
 			case <-ctx.Done():
 				// m.eventBus.Unsubscribe(topics.Tx, m.txSubscriberID)
+				m.closeJournal()
 				return
 			}
 
@@ -162,15 +207,9 @@ func (m *Mempool) Run(ctx context.Context) {
 }
 
 // ProcessTx handles a submitted tx from any source (rpcBus or eventBus).
+// The txs received from a network peer are rate limited, and the peer is
+// banned if it keeps sending invalid ones.
 func (m *Mempool) ProcessTx(srcPeerID string, msg message.Message) ([]bytes.Buffer, error) {
-	maxSizeBytes := config.Get().Mempool.MaxSizeMB * 1000 * 1000
-	if m.verified.Size() > maxSizeBytes {
-		log.WithField("max_size_mb", maxSizeBytes).
-			WithField("current_size", m.verified.Size()).
-			Warn("mempool is full, dropping transaction")
-		return nil, errors.New("mempool is full, dropping transaction")
-	}
-
 	var h byte
 	if len(msg.Header()) > 0 {
 		h = msg.Header()[0]
@@ -178,10 +217,19 @@ func (m *Mempool) ProcessTx(srcPeerID string, msg message.Message) ([]bytes.Buff
 
 	t := TxDesc{tx: msg.Payload().(transactions.ContractCall), received: time.Now(), size: uint(len(msg.Id())), kadHeight: h}
 
+	if srcPeerID != "" && !m.peers.allow(srcPeerID, t.received) {
+		m.accountRejected(srcPeerID, ErrRateLimited)
+		return nil, ErrRateLimited
+	}
+
 	start := time.Now()
 	txid, err := m.processTx(t)
 	elapsed := time.Since(start)
 
+	if err != nil && srcPeerID != "" {
+		m.accountRejected(srcPeerID, err)
+	}
+
 	if err != nil {
 		log.WithError(err).
 			WithField("txid", toHex(txid)).
@@ -201,13 +249,17 @@ func (m *Mempool) ProcessTx(srcPeerID string, msg message.Message) ([]bytes.Buff
 }
 
 // processTx ensures all transaction rules are satisfied before adding the tx
-// into the verified pool.
-func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
-	txid, err := t.tx.CalculateHash()
+// into the verified pool. The outcome is notified on topics.MempoolEvent.
+func (m *Mempool) processTx(t TxDesc) (txid []byte, err error) {
+	txid, err = t.tx.CalculateHash()
 	if err != nil {
 		return txid, fmt.Errorf("hash err: %s", err.Error())
 	}
 
+	defer func() {
+		m.notifyProcessed(t, txid, err)
+	}()
+
 	log.WithField("txid", txid).
 		Trace("ensuring transaction rules satisfied")
 
@@ -221,19 +273,56 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 		return txid, ErrAlreadyExists
 	}
 
+	// a tx spending the same inputs of verified txs must pay enough to
+	// replace them
+	replaced, err := m.replacedTxs(t)
+	if err != nil {
+		return txid, err
+	}
+
+	// when the pool is full, make sure the tx pays enough to replace some of
+	// the cheapest ones
+	evicted, err := m.evictionCandidates(t, replaced)
+	if err != nil {
+		log.WithField("max_size_mb", config.Get().Mempool.MaxSizeMB).
+			WithField("current_size", m.verified.Size()).
+			Warn("mempool is full, dropping transaction")
+		return txid, err
+	}
+
 	// execute tx verification procedure
-	if err := m.checkTx(t.tx); err != nil {
-		return txid, fmt.Errorf("verification err - %v", err)
+	if err = m.checkTx(t.tx); err != nil {
+		return txid, fmt.Errorf("%w - %v", ErrVerification, err)
 	}
 
 	// if consumer's verification passes, mark it as verified
 	t.verified = time.Now()
 
+	replacedKeys := make([]txHash, 0, len(replaced))
+	for k := range replaced {
+		replacedKeys = append(replacedKeys, k)
+	}
+
+	m.evict(replacedKeys, "replaced")
+	m.evict(evicted, "low fee")
+
 	// we've got a valid transaction pushed
-	if err := m.verified.Put(t); err != nil {
+	if err = m.verified.Put(t); err != nil {
 		return txid, fmt.Errorf("store err - %v", err)
 	}
 
+	var k txHash
+
+	copy(k[:], txid)
+	m.spent.add(k, t.tx)
+
+	if m.journal != nil {
+		if journalErr := m.journal.insert(t); journalErr != nil {
+			log.WithError(journalErr).WithField("txid", toHex(txid)).
+				Warn("could not journal transaction")
+		}
+	}
+
 	// try to (re)propagate transaction in both gossip and kadcast networks
 	m.propagateTx(t, txid)
 
@@ -291,32 +380,98 @@ func (m *Mempool) removeAccepted(b block.Block) {
 			log.WithError(err).Panic("could not calculate tx hash")
 		}
 
-		m.verified.Delete(hash)
+		var k txHash
+
+		copy(k[:], hash)
+
+		if removed := m.removeTx(k); removed != nil {
+			m.publishEvent(Event{
+				Type:   EventIncluded,
+				TxID:   hash,
+				TxType: removed.Type(),
+				Height: b.Header.Height,
+			})
+		}
+
+		// txs spending the same inputs can not be accepted anymore
+		m.evict(m.spent.conflicts(tx), "double spent")
 	}
 
 	l.Info("processing_block_completed")
 }
 
-// TODO: Get rid of stuck/expired transactions
-// TODO: Check periodically the oldest txs if somehow were accepted into the
-// blockchain but were not removed from mempool verified list.
+// onRevertedBlock gives back to the mempool the transactions of a block that
+// has been removed from the chain on a reorganisation. Each transaction goes
+// through the full verification procedure again, so the ones conflicting with
+// the new canonical chain are simply dropped.
+func (m *Mempool) onRevertedBlock(b block.Block) {
+	l := log.WithField("blk_height", b.Header.Height).
+		WithField("blk_txs_count", len(b.Txs))
+
+	var restored int
+
+	for _, tx := range b.Txs {
+		if tx.Type() == transactions.Distribute {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if err := transactions.Marshal(buf, tx); err != nil {
+			l.WithError(err).Warn("could not marshal reverted transaction")
+			continue
+		}
+
+		t := TxDesc{tx: tx, received: time.Now(), size: uint(buf.Len()), kadHeight: config.KadcastInitialHeight}
+		if txid, err := m.processTx(t); err != nil {
+			l.WithError(err).WithField("txid", toHex(txid)).
+				Debug("reverted transaction not restored")
+			continue
+		}
+
+		restored++
+	}
+
+	l.WithField("restored_txs_count", restored).Info("processing_reverted_block_completed")
+}
+
 func (m *Mempool) onIdle() {
 	log.
 		WithField("mempool_alloc_size_kB", int64(m.verified.Size())/1000).
 		WithField("mempool_txs_count", m.verified.Len()).Info("process_on_idle")
 }
 
+// onMaintenance gets rid of the expired txs and re-verifies the oldest ones,
+// in case they were accepted into the blockchain but not removed from the
+// verified pool. The journal is then rotated, so that it does not keep
+// growing with the txs removed from the pool, and the idle peer rate limiters
+// are pruned.
+func (m *Mempool) onMaintenance() {
+	now := time.Now()
+
+	expired := m.expireTxs(now)
+	invalid := m.reverifyOldest()
+
+	m.rotateJournal()
+	m.peers.prune(now)
+
+	log.
+		WithField("mempool_txs_count", m.verified.Len()).
+		WithField("expired_txs_count", expired).
+		WithField("invalid_txs_count", invalid).Debug("process_on_maintenance")
+}
+
 func (m *Mempool) newPool() Pool {
 	preallocTxs := config.Get().Mempool.PreallocTxs
 
 	var p Pool
 
-	switch config.Get().Mempool.PoolType {
+	switch poolType := config.Get().Mempool.PoolType; poolType {
 	case "hashmap":
 		p = &HashMap{lock: &sync.RWMutex{}, Capacity: preallocTxs}
-	case "syncpool":
-		log.Panic("syncpool not supported")
+	case "skiplist":
+		p = &SkipList{lock: &sync.RWMutex{}, Capacity: preallocTxs}
 	default:
+		log.WithField("type", poolType).Warn("unsupported pool type, falling back to hashmap")
 		p = &HashMap{lock: &sync.RWMutex{}, Capacity: preallocTxs}
 	}
 
@@ -0,0 +1,344 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: mempool_events.proto
+
+package mempoolpb
+
+import (
+	context "context"
+	fmt "fmt"
+	node "github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// EventsRequest opens the stream of the mempool events.
+type EventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
+func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
+func (*EventsRequest) ProtoMessage()    {}
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36b905b141978079, []int{0}
+}
+func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventsRequest.Unmarshal(m, b)
+}
+func (m *EventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventsRequest.Marshal(b, m, deterministic)
+}
+func (m *EventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsRequest.Merge(m, src)
+}
+func (m *EventsRequest) XXX_Size() int {
+	return xxx_messageInfo_EventsRequest.Size(m)
+}
+func (m *EventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventsRequest proto.InternalMessageInfo
+
+// MempoolEvent notifies a transaction entering or leaving the mempool.
+type MempoolEvent struct {
+	// type is one of accepted, rejected, evicted or included
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// id is the hex encoded transaction hash
+	Id     string      `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	TxType node.TxType `protobuf:"varint,3,opt,name=tx_type,json=txType,proto3,enum=node.TxType" json:"tx_type,omitempty"`
+	// reason explains why a transaction was rejected or evicted
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// height is the height of the block including the transaction
+	Height               uint64   `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MempoolEvent) Reset()         { *m = MempoolEvent{} }
+func (m *MempoolEvent) String() string { return proto.CompactTextString(m) }
+func (*MempoolEvent) ProtoMessage()    {}
+func (*MempoolEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36b905b141978079, []int{1}
+}
+func (m *MempoolEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolEvent.Unmarshal(m, b)
+}
+func (m *MempoolEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolEvent.Marshal(b, m, deterministic)
+}
+func (m *MempoolEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolEvent.Merge(m, src)
+}
+func (m *MempoolEvent) XXX_Size() int {
+	return xxx_messageInfo_MempoolEvent.Size(m)
+}
+func (m *MempoolEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolEvent proto.InternalMessageInfo
+
+func (m *MempoolEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *MempoolEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MempoolEvent) GetTxType() node.TxType {
+	if m != nil {
+		return m.TxType
+	}
+	return node.TxType_STANDARD
+}
+
+func (m *MempoolEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *MempoolEvent) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*EventsRequest)(nil), "node.EventsRequest")
+	proto.RegisterType((*MempoolEvent)(nil), "node.MempoolEvent")
+}
+
+func init() { proto.RegisterFile("mempool_events.proto", fileDescriptor_36b905b141978079) }
+
+var fileDescriptor_36b905b141978079 = []byte{
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xc1, 0x4e, 0xc2, 0x40,
+	0x10, 0x86, 0x59, 0xac, 0x45, 0x37, 0x80, 0xc9, 0x0a, 0xa6, 0xe9, 0x89, 0x34, 0x31, 0xe1, 0x62,
+	0x6b, 0x30, 0xc6, 0x3b, 0xd1, 0x78, 0xf2, 0x52, 0xeb, 0xc5, 0x0b, 0x69, 0xb7, 0x23, 0x6d, 0xda,
+	0xee, 0xae, 0xed, 0x20, 0xf0, 0x08, 0x3e, 0x9d, 0xaf, 0x64, 0xd8, 0x2d, 0x44, 0x12, 0x4f, 0xd3,
+	0xff, 0x9f, 0xf9, 0x32, 0xdd, 0x7f, 0xe8, 0xa8, 0x82, 0x4a, 0x49, 0x59, 0x2e, 0xe0, 0x0b, 0x04,
+	0x36, 0xbe, 0xaa, 0x25, 0x4a, 0x66, 0x09, 0x99, 0x82, 0x3b, 0x68, 0x7b, 0xc6, 0x74, 0xfb, 0xeb,
+	0xb8, 0x2c, 0x01, 0x8d, 0xf2, 0x2e, 0xe8, 0xe0, 0x49, 0x23, 0x21, 0x7c, 0xae, 0xa0, 0x41, 0xef,
+	0x9b, 0xd0, 0xfe, 0x8b, 0x01, 0x74, 0x83, 0x31, 0x6a, 0xe1, 0x56, 0x81, 0x43, 0x26, 0x64, 0x7a,
+	0x1e, 0xea, 0x6f, 0x36, 0xa4, 0xdd, 0x3c, 0x75, 0xba, 0xda, 0xe9, 0xe6, 0x29, 0xbb, 0xa6, 0x3d,
+	0xdc, 0x2c, 0xf4, 0xd8, 0xc9, 0x84, 0x4c, 0x87, 0xb3, 0xbe, 0xbf, 0x5b, 0xed, 0x47, 0x9b, 0x68,
+	0xab, 0x20, 0xb4, 0x51, 0x57, 0x76, 0x45, 0xed, 0x1a, 0xe2, 0x46, 0x0a, 0xc7, 0xd2, 0x68, 0xab,
+	0x76, 0x7e, 0x06, 0xf9, 0x32, 0x43, 0xe7, 0x74, 0x42, 0xa6, 0x56, 0xd8, 0xaa, 0xd9, 0x0f, 0xa1,
+	0xbd, 0xf6, 0x5f, 0x58, 0x44, 0xc7, 0xcf, 0x80, 0x6f, 0x82, 0x4b, 0xf1, 0x91, 0xd7, 0x15, 0xa4,
+	0xf3, 0xb8, 0x8c, 0x05, 0x07, 0xe6, 0x99, 0x55, 0xff, 0x36, 0xdb, 0x47, 0xb9, 0x63, 0x33, 0x73,
+	0x70, 0x1b, 0x25, 0x45, 0x03, 0x5e, 0x87, 0x3d, 0xd0, 0xb3, 0x57, 0x28, 0x81, 0x63, 0xb4, 0x61,
+	0x97, 0x66, 0xc8, 0xe8, 0x3d, 0x39, 0x3a, 0x36, 0x0f, 0xe0, 0x3d, 0xb5, 0x4d, 0x6e, 0x7b, 0xec,
+	0x28, 0x45, 0x97, 0x19, 0xf3, 0x6f, 0x90, 0x5e, 0xe7, 0x96, 0xcc, 0x1f, 0xdf, 0xe7, 0xcb, 0x1c,
+	0xb3, 0x55, 0xe2, 0x73, 0x59, 0x05, 0xe9, 0xaa, 0x29, 0x6e, 0x04, 0xe0, 0x5a, 0xd6, 0x85, 0x11,
+	0x49, 0x29, 0x79, 0xc1, 0xb3, 0x38, 0x17, 0x81, 0x2a, 0x96, 0x01, 0x97, 0x35, 0x04, 0xed, 0xf9,
+	0xf6, 0x55, 0x25, 0x89, 0xad, 0x6f, 0x77, 0xf7, 0x3b, 0x00, 0x3d, 0xcf, 0xa8, 0xbc, 0xf6, 0x01,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MempoolClient is the client API for Mempool service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MempoolClient interface {
+	GetUnconfirmedBalance(ctx context.Context, in *node.GetUnconfirmedBalanceRequest, opts ...grpc.CallOption) (*node.BalanceResponse, error)
+	SelectTx(ctx context.Context, in *node.SelectRequest, opts ...grpc.CallOption) (*node.SelectResponse, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Mempool_EventsClient, error)
+}
+
+type mempoolClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMempoolClient(cc *grpc.ClientConn) MempoolClient {
+	return &mempoolClient{cc}
+}
+
+func (c *mempoolClient) GetUnconfirmedBalance(ctx context.Context, in *node.GetUnconfirmedBalanceRequest, opts ...grpc.CallOption) (*node.BalanceResponse, error) {
+	out := new(node.BalanceResponse)
+	err := c.cc.Invoke(ctx, "/node.Mempool/GetUnconfirmedBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolClient) SelectTx(ctx context.Context, in *node.SelectRequest, opts ...grpc.CallOption) (*node.SelectResponse, error) {
+	out := new(node.SelectResponse)
+	err := c.cc.Invoke(ctx, "/node.Mempool/SelectTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Mempool_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Mempool_serviceDesc.Streams[0], "/node.Mempool/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mempoolEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mempool_EventsClient interface {
+	Recv() (*MempoolEvent, error)
+	grpc.ClientStream
+}
+
+type mempoolEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mempoolEventsClient) Recv() (*MempoolEvent, error) {
+	m := new(MempoolEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MempoolServer is the server API for Mempool service.
+type MempoolServer interface {
+	GetUnconfirmedBalance(context.Context, *node.GetUnconfirmedBalanceRequest) (*node.BalanceResponse, error)
+	SelectTx(context.Context, *node.SelectRequest) (*node.SelectResponse, error)
+	Events(*EventsRequest, Mempool_EventsServer) error
+}
+
+// UnimplementedMempoolServer can be embedded to have forward compatible implementations.
+type UnimplementedMempoolServer struct {
+}
+
+func (*UnimplementedMempoolServer) GetUnconfirmedBalance(ctx context.Context, req *node.GetUnconfirmedBalanceRequest) (*node.BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnconfirmedBalance not implemented")
+}
+func (*UnimplementedMempoolServer) SelectTx(ctx context.Context, req *node.SelectRequest) (*node.SelectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectTx not implemented")
+}
+func (*UnimplementedMempoolServer) Events(req *EventsRequest, srv Mempool_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+
+func RegisterMempoolServer(s *grpc.Server, srv MempoolServer) {
+	s.RegisterService(&_Mempool_serviceDesc, srv)
+}
+
+func _Mempool_GetUnconfirmedBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(node.GetUnconfirmedBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServer).GetUnconfirmedBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.Mempool/GetUnconfirmedBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServer).GetUnconfirmedBalance(ctx, req.(*node.GetUnconfirmedBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempool_SelectTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(node.SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServer).SelectTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/node.Mempool/SelectTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServer).SelectTx(ctx, req.(*node.SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mempool_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MempoolServer).Events(m, &mempoolEventsServer{stream})
+}
+
+type Mempool_EventsServer interface {
+	Send(*MempoolEvent) error
+	grpc.ServerStream
+}
+
+type mempoolEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mempoolEventsServer) Send(m *MempoolEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Mempool_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "node.Mempool",
+	HandlerType: (*MempoolServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUnconfirmedBalance",
+			Handler:    _Mempool_GetUnconfirmedBalance_Handler,
+		},
+		{
+			MethodName: "SelectTx",
+			Handler:    _Mempool_SelectTx_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _Mempool_Events_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mempool_events.proto",
+}
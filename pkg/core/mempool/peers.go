@@ -0,0 +1,149 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+)
+
+// ErrRateLimited the peer is sending txs faster than allowed.
+var ErrRateLimited = errors.New("peer tx rate limit exceeded")
+
+const (
+	// rateLimitedScore is the misbehaviour score of a rate limited tx.
+	rateLimitedScore = 1
+	// invalidTxScore is the misbehaviour score of a tx failing the
+	// verification.
+	invalidTxScore = 10
+	// peerStateTTL is the inactivity after which the state of a peer is
+	// dropped.
+	peerStateTTL = 10 * time.Minute
+)
+
+type (
+	peerState struct {
+		// tokens is the number of txs the peer can currently send.
+		tokens   float64
+		rejected uint64
+		updated  time.Time
+	}
+
+	// peerLimiter accounts the txs received from each peer, and rate limits
+	// them with a token bucket.
+	peerLimiter struct {
+		lock  sync.Mutex
+		peers map[string]*peerState
+	}
+)
+
+func newPeerLimiter() *peerLimiter {
+	return &peerLimiter{peers: make(map[string]*peerState)}
+}
+
+// get returns the state of a peer, refilling its bucket according to the
+// time elapsed since the last update.
+func (l *peerLimiter) get(srcPeerID string, now time.Time) *peerState {
+	cfg := config.Get().Mempool
+
+	p, ok := l.peers[srcPeerID]
+	if !ok {
+		p = &peerState{tokens: float64(cfg.PeerTxBurst), updated: now}
+		l.peers[srcPeerID] = p
+	}
+
+	elapsed := now.Sub(p.updated).Seconds()
+	if elapsed > 0 {
+		p.tokens += elapsed * float64(cfg.PeerTxRate)
+		if p.tokens > float64(cfg.PeerTxBurst) {
+			p.tokens = float64(cfg.PeerTxBurst)
+		}
+
+		p.updated = now
+	}
+
+	return p
+}
+
+// allow takes a token from the bucket of the peer. It returns false if the
+// peer has exhausted its bucket.
+func (l *peerLimiter) allow(srcPeerID string, now time.Time) bool {
+	if config.Get().Mempool.PeerTxRate == 0 {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	p := l.get(srcPeerID, now)
+	if p.tokens < 1 {
+		return false
+	}
+
+	p.tokens--
+	return true
+}
+
+// reject accounts a rejected tx against the peer. It returns the number of
+// txs of the peer rejected so far.
+func (l *peerLimiter) reject(srcPeerID string, now time.Time) uint64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	p := l.get(srcPeerID, now)
+	p.rejected++
+
+	return p.rejected
+}
+
+// prune drops the state of the peers inactive since peerStateTTL.
+func (l *peerLimiter) prune(now time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for srcPeerID, p := range l.peers {
+		if now.Sub(p.updated) > peerStateTTL {
+			delete(l.peers, srcPeerID)
+		}
+	}
+}
+
+// misbehaviourScore returns the score a rejection adds to the ban score of
+// the peer. Txs rejected for reasons the peer could not know about, such as
+// a full mempool or a duplicate, are not accounted.
+func misbehaviourScore(err error) uint32 {
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return rateLimitedScore
+	case errors.Is(err, ErrVerification), errors.Is(err, ErrCoinbaseTxNotAllowed):
+		return invalidTxScore
+	default:
+		return 0
+	}
+}
+
+// accountRejected accounts a rejected tx against the peer which sent it, and
+// reports the peer to the p2p layer if the tx is invalid.
+func (m *Mempool) accountRejected(srcPeerID string, err error) {
+	rejected := m.peers.reject(srcPeerID, time.Now())
+
+	score := misbehaviourScore(err)
+	if score == 0 {
+		return
+	}
+
+	log.WithField("peer", srcPeerID).
+		WithField("rejected_txs_count", rejected).
+		WithError(err).
+		Debug("peer misbehaviour")
+
+	peer.ReportMisbehaviour(m.eventBus, srcPeerID, score, "mempool: "+err.Error())
+}
@@ -0,0 +1,165 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+func mockPeerConfig(rate, burst uint32) func() {
+	r := config.Get()
+	r.Mempool.PeerTxRate = rate
+	r.Mempool.PeerTxBurst = burst
+	config.Mock(&r)
+
+	return func() {
+		r.Mempool.PeerTxRate = 0
+		r.Mempool.PeerTxBurst = 0
+		config.Mock(&r)
+	}
+}
+
+func TestPeerTokenBucket(t *testing.T) {
+	assert := assert.New(t)
+
+	defer mockPeerConfig(10, 5)()
+
+	l := newPeerLimiter()
+	now := time.Now()
+
+	// The whole burst is available at once
+	for i := 0; i < 5; i++ {
+		assert.True(l.allow("peer", now))
+	}
+
+	assert.False(l.allow("peer", now))
+
+	// Other peers have their own bucket
+	assert.True(l.allow("other", now))
+
+	// The bucket is refilled at the given rate, up to the burst
+	now = now.Add(200 * time.Millisecond)
+	assert.True(l.allow("peer", now))
+	assert.True(l.allow("peer", now))
+	assert.False(l.allow("peer", now))
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 5; i++ {
+		assert.True(l.allow("peer", now))
+	}
+
+	assert.False(l.allow("peer", now))
+}
+
+func TestPeerStatePrune(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newPeerLimiter()
+	now := time.Now()
+
+	assert.Equal(uint64(1), l.reject("peer", now))
+	assert.Equal(uint64(2), l.reject("peer", now))
+	assert.Equal(uint64(1), l.reject("other", now.Add(peerStateTTL)))
+
+	// Inactive peers are forgotten
+	l.prune(now.Add(peerStateTTL + time.Second))
+	assert.Len(l.peers, 1)
+	assert.Contains(l.peers, "other")
+}
+
+// Test that a peer sending invalid txs is reported to the p2p layer, while
+// txs submitted through RPC are never accounted.
+func TestReportMisbehavingPeer(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, bus, _, _ := startMempoolTest(ctx)
+
+	reportChan := make(chan message.Message, 10)
+	bus.Subscribe(topics.Misbehaviour, eventbus.NewChanListener(reportChan))
+
+	sendInvalid := func(srcPeerID string) {
+		tx := transactions.RandTx()
+		transactions.Invalidate(tx)
+
+		_, err := m.ProcessTx(srcPeerID, message.New(topics.Tx, tx))
+		assert.True(errors.Is(err, ErrVerification))
+	}
+
+	for i := 0; i < 5; i++ {
+		sendInvalid("")
+	}
+
+	select {
+	case <-reportChan:
+		t.Fatal("RPC submission reported")
+	default:
+	}
+
+	sendInvalid("127.0.0.1:7000")
+
+	select {
+	case msg := <-reportChan:
+		r := msg.Payload().(message.Misbehaviour)
+		assert.Equal("127.0.0.1:7000", r.Address)
+		assert.Equal(uint32(invalidTxScore), r.Score)
+	case <-time.After(time.Second):
+		t.Fatal("peer not reported")
+	}
+
+	// Duplicates are not the fault of the peer
+	tx := transactions.RandTx()
+
+	_, err := m.ProcessTx("127.0.0.1:7000", message.New(topics.Tx, tx))
+	assert.NoError(err)
+
+	_, err = m.ProcessTx("127.0.0.1:7000", message.New(topics.Tx, tx))
+	assert.Equal(ErrAlreadyExists, err)
+
+	select {
+	case <-reportChan:
+		t.Fatal("duplicate reported")
+	default:
+	}
+}
+
+func TestRateLimitedPeer(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	defer mockPeerConfig(1, 2)()
+
+	for i := 0; i < 2; i++ {
+		_, err := m.ProcessTx("127.0.0.1:7000", message.New(topics.Tx, transactions.RandTx()))
+		assert.NoError(err)
+	}
+
+	// The tx does not reach the verifier
+	_, err := m.ProcessTx("127.0.0.1:7000", message.New(topics.Tx, transactions.RandTx()))
+	assert.Equal(ErrRateLimited, err)
+	assert.Equal(2, m.verified.Len())
+
+	// RPC submissions are not rate limited
+	_, err = m.ProcessTx("", message.New(topics.Tx, transactions.RandTx()))
+	assert.NoError(err)
+}
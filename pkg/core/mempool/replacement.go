@@ -0,0 +1,119 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+)
+
+// spentIndex maps the nullifiers of the verified txs to the tx spending them,
+// so that conflicting txs can be found without scanning the whole pool.
+type spentIndex struct {
+	lock    sync.RWMutex
+	spentBy map[string]txHash
+}
+
+func newSpentIndex() *spentIndex {
+	return &spentIndex{spentBy: make(map[string]txHash)}
+}
+
+// add the nullifiers of the tx stored under key k.
+func (s *spentIndex) add(k txHash, tx transactions.ContractCall) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, n := range nullifiers(tx) {
+		s.spentBy[string(n)] = k
+	}
+}
+
+// remove the nullifiers of the tx stored under key k. Nullifiers claimed in
+// the meantime by another tx are left untouched.
+func (s *spentIndex) remove(k txHash, tx transactions.ContractCall) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, n := range nullifiers(tx) {
+		if s.spentBy[string(n)] == k {
+			delete(s.spentBy, string(n))
+		}
+	}
+}
+
+// conflicts returns the keys of the txs spending any of the nullifiers of tx.
+func (s *spentIndex) conflicts(tx transactions.ContractCall) []txHash {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	keys := make([]txHash, 0)
+	seen := make(map[txHash]struct{})
+
+	for _, n := range nullifiers(tx) {
+		k, ok := s.spentBy[string(n)]
+		if !ok {
+			continue
+		}
+
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+func nullifiers(tx transactions.ContractCall) [][]byte {
+	payload := tx.StandardTx()
+	if payload == nil {
+		return nil
+	}
+
+	return payload.Nullifiers
+}
+
+// replacedTxs returns the pooled txs spending any of the inputs of t, which
+// t is going to replace. A replacement must pay at least the configured
+// MinFeeBump percentage over the fees of all the txs it replaces, otherwise
+// ErrDoubleSpending is returned.
+func (m *Mempool) replacedTxs(t TxDesc) (map[txHash]TxDesc, error) {
+	keys := m.spent.conflicts(t.tx)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	replaced := make(map[txHash]TxDesc, len(keys))
+
+	_ = m.verified.Range(func(k txHash, d TxDesc) error {
+		for _, c := range keys {
+			if c == k {
+				replaced[k] = d
+			}
+		}
+
+		return nil
+	})
+
+	var replacedFee uint64
+
+	for _, d := range replaced {
+		_, f := d.tx.Values()
+		replacedFee += f
+	}
+
+	bump := uint64(config.Get().Mempool.MinFeeBump)
+	minFee := replacedFee + replacedFee*bump/100
+
+	if _, fee := t.tx.Values(); fee <= replacedFee || fee < minFee {
+		return nil, ErrDoubleSpending
+	}
+
+	return replaced, nil
+}
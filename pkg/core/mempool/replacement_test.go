@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	assert "github.com/stretchr/testify/require"
+)
+
+// Test that a tx spending the same inputs of a pooled one replaces it only
+// if it pays the minimum fee bump, and that the replacement is propagated.
+func TestReplaceByFee(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, streamer := startMempoolTest(ctx)
+
+	r := config.Get()
+	r.Mempool.MinFeeBump = 10
+	config.Mock(&r)
+
+	defer func() {
+		r.Mempool.MinFeeBump = 0
+		config.Mock(&r)
+	}()
+
+	nullifier := transactions.Rand32Bytes()
+
+	original := txDescSpending(100, nullifier)
+	_, err := m.processTx(original)
+	assert.NoError(err)
+
+	// A bump lower than 10% is not enough
+	_, err = m.processTx(txDescSpending(105, nullifier))
+	assert.Equal(ErrDoubleSpending, err)
+
+	replacement := txDescSpending(110, nullifier)
+	_, err = m.processTx(replacement)
+	assert.NoError(err)
+
+	assert.Equal(1, m.verified.Len())
+	assert.False(m.verified.Contains(hashOf(t, original)))
+	assert.True(m.verified.Contains(hashOf(t, replacement)))
+
+	// Both the original and the replacement are advertised, in any order
+	advertised := make([][]byte, 0, 2)
+
+	for i := 0; i < 2; i++ {
+		inv, err := streamer.Read()
+		assert.NoError(err)
+
+		msg := &message.Inv{}
+		assert.NoError(msg.Decode(bytes.NewBuffer(inv)))
+
+		advertised = append(advertised, msg.InvList[0].Hash)
+	}
+
+	assert.ElementsMatch([][]byte{hashOf(t, original), hashOf(t, replacement)}, advertised)
+}
+
+// Test that a pooled tx is removed once a block spends the same inputs.
+func TestRemoveDoubleSpent(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _, _, _ := startMempoolTest(ctx)
+
+	nullifier := transactions.Rand32Bytes()
+
+	pooled := txDescSpending(100, nullifier)
+	_, err := m.processTx(pooled)
+	assert.NoError(err)
+
+	b := helper.RandomBlock(200, 0)
+	b.Txs = []transactions.ContractCall{txDescSpending(50, nullifier).tx}
+
+	m.removeAccepted(*b)
+
+	assert.Equal(0, m.verified.Len())
+	assert.Empty(m.spent.conflicts(pooled.tx))
+}
+
+func txDescSpending(fee uint64, nullifier []byte) TxDesc {
+	d := txDescWithFee(fee, 100)
+	d.tx.StandardTx().Nullifiers = [][]byte{nullifier}
+	return d
+}
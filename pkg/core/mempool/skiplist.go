@@ -0,0 +1,289 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+)
+
+const (
+	// skipListMaxLevel allows for up to 4^16 entries to be indexed
+	// efficiently.
+	skipListMaxLevel = 16
+	// skipListP is the probability for a node to be promoted to the next level.
+	skipListP = 0.25
+)
+
+type (
+	skipNode struct {
+		k   txHash
+		t   TxDesc
+		fee uint64
+		// seq is the insertion order, used to keep txs with the same fee in
+		// order of receiving.
+		seq  uint64
+		next []*skipNode
+	}
+
+	// SkipList represents a pool implementation which keeps the txs ordered
+	// by Fee in a skip list, next to a map for lookups by txID. Both insertion
+	// and deletion take O(log n), so that the fee ordering is maintained
+	// incrementally rather than shifting a sorted slice on every change.
+	SkipList struct {
+		lock *sync.RWMutex
+		data map[txHash]*skipNode
+
+		// head is a sentinel node. Entries are linked in descending Fee
+		// order.
+		head  *skipNode
+		level int
+		seq   uint64
+		rnd   *rand.Rand
+
+		Capacity uint32
+		txsSize  uint32
+	}
+)
+
+// before returns true if n is to be placed before an entry with the given fee
+// and insertion sequence.
+func (n *skipNode) before(fee, seq uint64) bool {
+	return n.fee > fee || (n.fee == fee && n.seq < seq)
+}
+
+func (m *SkipList) init() {
+	if m.data != nil {
+		return
+	}
+
+	m.data = make(map[txHash]*skipNode, m.Capacity)
+	m.head = &skipNode{next: make([]*skipNode, skipListMaxLevel)}
+	m.level = 1
+	m.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+func (m *SkipList) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && m.rnd.Float64() < skipListP {
+		level++
+	}
+
+	return level
+}
+
+// Put sets the value for the given key. It overwrites any previous value
+// for that key.
+func (m *SkipList) Put(t TxDesc) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.init()
+
+	txID, err := t.tx.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	var k txHash
+	copy(k[:], txID)
+
+	if n, ok := m.data[k]; ok {
+		m.unlink(n)
+	}
+
+	_, fee := t.tx.Values()
+
+	m.seq++
+	n := &skipNode{k: k, t: t, fee: fee, seq: m.seq}
+
+	// find the last node of each level placed before the new one
+	update := make([]*skipNode, skipListMaxLevel)
+	x := m.head
+
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].before(fee, n.seq) {
+			x = x.next[i]
+		}
+
+		update[i] = x
+	}
+
+	level := m.randomLevel()
+	if level > m.level {
+		for i := m.level; i < level; i++ {
+			update[i] = m.head
+		}
+
+		m.level = level
+	}
+
+	n.next = make([]*skipNode, level)
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+
+	m.data[k] = n
+	m.txsSize += uint32(t.size)
+
+	return nil
+}
+
+// unlink removes a node from both the list and the map.
+func (m *SkipList) unlink(n *skipNode) {
+	x := m.head
+
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].before(n.fee, n.seq) {
+			x = x.next[i]
+		}
+
+		if x.next[i] == n {
+			x.next[i] = n.next[i]
+		}
+	}
+
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+
+	delete(m.data, n.k)
+	m.txsSize -= uint32(n.t.size)
+}
+
+// Clone the entire pool.
+func (m *SkipList) Clone() []transactions.ContractCall {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	r := make([]transactions.ContractCall, 0, len(m.data))
+	for _, n := range m.data {
+		r = append(r, n.t.tx)
+	}
+
+	return r
+}
+
+// FilterByType returns all transactions for a specific type that are
+// currently in the SkipList.
+func (m *SkipList) FilterByType(filterType transactions.TxType) []transactions.ContractCall {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	txs := make([]transactions.ContractCall, 0)
+
+	for _, n := range m.data {
+		if n.t.tx.Type() == filterType {
+			txs = append(txs, n.t.tx)
+		}
+	}
+
+	return txs
+}
+
+// Contains returns true if the given key is in the pool.
+func (m *SkipList) Contains(txID []byte) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var k txHash
+
+	copy(k[:], txID)
+	_, ok := m.data[k]
+
+	return ok
+}
+
+// Get returns a tx for a given txID if it exists.
+func (m *SkipList) Get(txID []byte) transactions.ContractCall {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var k txHash
+
+	copy(k[:], txID)
+
+	n, ok := m.data[k]
+	if !ok {
+		return nil
+	}
+
+	return n.t.tx
+}
+
+// Delete a key in the skip list.
+func (m *SkipList) Delete(txID []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var k txHash
+
+	copy(k[:], txID)
+
+	n, ok := m.data[k]
+	if !ok {
+		return
+	}
+
+	m.unlink(n)
+}
+
+// Size of the txs.
+func (m *SkipList) Size() uint32 {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.txsSize
+}
+
+// Len returns the number of tx entries.
+func (m *SkipList) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.data)
+}
+
+// Range iterates through all tx entries.
+func (m *SkipList) Range(fn func(k txHash, t TxDesc) error) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for k, n := range m.data {
+		if err := fn(k, n.t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RangeSort iterates through all tx entries sorted by Fee
+// in a descending order.
+func (m *SkipList) RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.head == nil {
+		return nil
+	}
+
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		done, err := fn(n.k, n.t)
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestSkipListSortedKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := &SkipList{lock: &sync.RWMutex{}, Capacity: 100}
+	hashes := make([][]byte, 0, 100)
+
+	for i := 0; i < 100; i++ {
+		tx := transactions.RandTx()
+		assert.NoError(pool.Put(TxDesc{tx: tx, size: 10}))
+
+		hash, err := tx.CalculateHash()
+		assert.NoError(err)
+
+		hashes = append(hashes, hash)
+	}
+
+	// Delete every third entry
+	for i := 0; i < len(hashes); i += 3 {
+		pool.Delete(hashes[i])
+		assert.False(pool.Contains(hashes[i]))
+	}
+
+	assert.Equal(66, pool.Len())
+	assert.Equal(uint32(660), pool.Size())
+
+	var (
+		prevVal uint64 = math.MaxUint64
+		count   int
+	)
+
+	err := pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+		_, fee := t.tx.Values()
+		if prevVal < fee {
+			return false, errors.New("keys not in a descending order")
+		}
+
+		prevVal = fee
+		count++
+
+		return false, nil
+	})
+
+	assert.NoError(err)
+	assert.Equal(66, count)
+}
+
+func TestSkipListStableSortedKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := &SkipList{lock: &sync.RWMutex{}, Capacity: 100}
+
+	for i := 0; i < 100; i++ {
+		tx := transactions.MockTx(false, transactions.RandBlind(), true)
+		assert.NoError(pool.Put(TxDesc{tx: tx, received: time.Now()}))
+	}
+
+	// The order of receiving is kept when txs have the same fee
+	var prevReceived time.Time
+
+	err := pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+		if prevReceived.After(t.received) {
+			return false, errors.New("order of receiving should be kept")
+		}
+
+		prevReceived = t.received
+		return false, nil
+	})
+
+	assert.NoError(err)
+}
+
+func TestSkipListOverwrite(t *testing.T) {
+	assert := assert.New(t)
+
+	pool := &SkipList{lock: &sync.RWMutex{}}
+	tx := transactions.RandTx()
+
+	assert.NoError(pool.Put(TxDesc{tx: tx, size: 10}))
+	assert.NoError(pool.Put(TxDesc{tx: tx, size: 20}))
+
+	assert.Equal(1, pool.Len())
+	assert.Equal(uint32(20), pool.Size())
+
+	var count int
+
+	assert.NoError(pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+		count++
+		return false, nil
+	}))
+	assert.Equal(1, count)
+
+	// An empty pool can be ranged over
+	empty := &SkipList{lock: &sync.RWMutex{}}
+	assert.NoError(empty.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+		return false, errors.New("unexpected entry")
+	}))
+}
+
+const benchmarkPoolTxs = 100000
+
+var benchmarkTxs []transactions.ContractCall
+
+func poolBenchmarkTxs() []transactions.ContractCall {
+	if benchmarkTxs == nil {
+		benchmarkTxs = transactions.RandContractCalls(benchmarkPoolTxs, 0, false)
+	}
+
+	return benchmarkTxs
+}
+
+func fillPool(b *testing.B, pool Pool, txs []transactions.ContractCall) {
+	for i := 0; i < len(txs); i++ {
+		td := TxDesc{tx: txs[i], received: time.Now(), size: uint(i)}
+		if err := pool.Put(td); err != nil {
+			b.Fatalf(err.Error())
+		}
+	}
+}
+
+func newBenchmarkPools() map[string]func() Pool {
+	return map[string]func() Pool{
+		"HashMap": func() Pool {
+			return &HashMap{lock: &sync.RWMutex{}, Capacity: benchmarkPoolTxs}
+		},
+		"SkipList": func() Pool {
+			return &SkipList{lock: &sync.RWMutex{}, Capacity: benchmarkPoolTxs}
+		},
+	}
+}
+
+// BenchmarkPoolPut measures the time needed to fill a pool with 100k txs.
+func BenchmarkPoolPut(b *testing.B) {
+	txs := poolBenchmarkTxs()
+
+	for name, newPool := range newBenchmarkPools() {
+		b.Run(name, func(b *testing.B) {
+			for tN := 0; tN < b.N; tN++ {
+				fillPool(b, newPool(), txs)
+			}
+		})
+	}
+}
+
+// BenchmarkPoolPutDelete measures the cost of a tx going through a pool of
+// 100k txs, which is what happens on a block acceptance.
+func BenchmarkPoolPutDelete(b *testing.B) {
+	txs := poolBenchmarkTxs()
+	extra := transactions.RandContractCalls(1000, 0, false)
+
+	hashes := make([][]byte, len(extra))
+	for i, tx := range extra {
+		hashes[i], _ = tx.CalculateHash()
+	}
+
+	for name, newPool := range newBenchmarkPools() {
+		b.Run(name, func(b *testing.B) {
+			pool := newPool()
+			fillPool(b, pool, txs)
+
+			b.ResetTimer()
+
+			for tN := 0; tN < b.N; tN++ {
+				i := tN % len(extra)
+
+				if err := pool.Put(TxDesc{tx: extra[i], received: time.Now()}); err != nil {
+					b.Fatalf(err.Error())
+				}
+
+				pool.Delete(hashes[i])
+			}
+		})
+	}
+}
+
+// BenchmarkPoolRangeSort measures the time needed to select the 1000
+// highest-fee txs out of a pool of 100k txs, as done on block generation.
+func BenchmarkPoolRangeSort(b *testing.B) {
+	txs := poolBenchmarkTxs()
+
+	for name, newPool := range newBenchmarkPools() {
+		b.Run(name, func(b *testing.B) {
+			pool := newPool()
+			fillPool(b, pool, txs)
+
+			b.ResetTimer()
+
+			for tN := 0; tN < b.N; tN++ {
+				var count int
+
+				err := pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+					count++
+					return count == 1000, nil
+				})
+				if err != nil {
+					b.Fatalf(err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package mempool
+
+import (
+	"encoding/hex"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool/mempoolpb"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+)
+
+// eventsBufferSize is the number of events buffered for each Events stream.
+// Events are dropped for the clients which fall behind.
+const eventsBufferSize = 1000
+
+// Events streams the mempool events to a gRPC client, until the client goes
+// away. It extends the Mempool service with the Events RPC, defined in
+// mempoolpb/mempool_events.proto.
+func (m *Mempool) Events(req *mempoolpb.EventsRequest, stream mempoolpb.Mempool_EventsServer) error {
+	eventChan := make(chan message.Message, eventsBufferSize)
+
+	id := m.eventBus.Subscribe(topics.MempoolEvent, eventbus.NewChanListener(eventChan))
+	defer m.eventBus.Unsubscribe(topics.MempoolEvent, id)
+
+	for {
+		select {
+		case msg := <-eventChan:
+			e := msg.Payload().(Event)
+
+			if err := stream.Send(e.toProto()); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (e Event) toProto() *mempoolpb.MempoolEvent {
+	return &mempoolpb.MempoolEvent{
+		Type:   e.Type.String(),
+		Id:     hex.EncodeToString(e.TxID),
+		TxType: node.TxType(e.TxType),
+		Reason: e.Reason,
+		Height: e.Height,
+	}
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/txrecords"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
@@ -30,29 +32,26 @@ var (
 
 	errWalletNotLoaded     = errors.New("wallet is not loaded yet") //nolint
 	errWalletAlreadyLoaded = errors.New("wallet is already loaded") //nolint
+	errAccountUnsupported  = errors.New("only the default wallet account is supported")
 )
 
-func (t *Transactor) handleAddress() (*node.LoadResponse, error) {
+func (t *Transactor) handleAddress(account wallet.Account) (*node.LoadResponse, error) {
 	// sk := t.w.SecretKey
 	/*
 		if sk.IsEmpty() {
 			return nil, errors.New("SecretKey is not set")
 		}
 	*/
-	return loadResponseFromPub(t.w.PublicKey), nil
+	return loadResponseFromPub(account.PublicKey), nil
 }
 
-func (t *Transactor) handleGetTxHistory() (*node.TxHistoryResponse, error) {
-	if t.w == nil {
-		return nil, errWalletNotLoaded
-	}
-
-	records, err := t.w.FetchTxHistory()
+func (t *Transactor) handleGetTxHistory(account wallet.Account) (*node.TxHistoryResponse, error) {
+	records, err := t.w.FetchTxHistory(account.Index)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &node.TxHistoryResponse{Records: []*node.TxRecord{}}
+	resp := &node.TxHistoryResponse{Records: make([]*node.TxRecord, len(records))}
 
 	for i, record := range records {
 		view := record.View()
@@ -73,7 +72,7 @@ func (t *Transactor) handleGetTxHistory() (*node.TxHistoryResponse, error) {
 	return resp, nil
 }
 
-func (t *Transactor) handleSendBidTx(req *node.BidRequest) (*node.TransactionResponse, error) {
+func (t *Transactor) handleSendBidTx(account wallet.Account, req *node.BidRequest) (*node.TransactionResponse, error) {
 	if t.w == nil {
 		return nil, errWalletNotLoaded
 	}
@@ -148,7 +147,7 @@ func (t *Transactor) handleSendBidTx(req *node.BidRequest) (*node.TransactionRes
 		return nil, err
 	}
 
-	hash, err := t.publishTx(tx.Tx)
+	hash, err := t.publishTx(account, tx.Tx)
 	if err != nil {
 		// DB operations should never fail. If for some reason during runtime we
 		// can no longer use the DB, we should panic.
@@ -169,7 +168,7 @@ func (t *Transactor) handleSendBidTx(req *node.BidRequest) (*node.TransactionRes
 	return &node.TransactionResponse{Hash: hash}, nil
 }
 
-func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.TransactionResponse, error) {
+func (t *Transactor) handleSendStakeTx(account wallet.Account, req *node.StakeRequest) (*node.TransactionResponse, error) {
 	if t.w == nil {
 		return nil, errWalletNotLoaded
 	}
@@ -199,7 +198,7 @@ func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.Transactio
 		return nil, err
 	}
 
-	hash, err := t.publishTx(tx)
+	hash, err := t.publishTx(account, tx)
 	if err != nil {
 		log.
 			WithField("amount", req.Amount).
@@ -216,7 +215,7 @@ func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.Transactio
 	return &node.TransactionResponse{Hash: hash}, nil
 }
 
-func (t *Transactor) handleSendStandardTx(req *node.TransferRequest) (*node.TransactionResponse, error) {
+func (t *Transactor) handleSendStandardTx(account wallet.Account, req *node.TransferRequest) (*node.TransactionResponse, error) {
 	if t.w == nil {
 		return nil, errWalletNotLoaded
 	}
@@ -249,7 +248,7 @@ func (t *Transactor) handleSendStandardTx(req *node.TransferRequest) (*node.Tran
 	log.WithField("duration_ms", d).Debug("NewTransfer grpc call")
 
 	// Publish transaction to the mempool processing
-	hash, err := t.publishTx(tx)
+	hash, err := t.publishTx(account, tx)
 	if err != nil {
 		log.
 			WithField("amount", req.Amount).
@@ -261,16 +260,12 @@ func (t *Transactor) handleSendStandardTx(req *node.TransferRequest) (*node.Tran
 	return &node.TransactionResponse{Hash: hash}, nil
 }
 
-func (t *Transactor) handleBalance() (*node.BalanceResponse, error) {
-	if t.w == nil {
-		return nil, errWalletNotLoaded
-	}
-
+func (t *Transactor) handleBalance(account wallet.Account) (*node.BalanceResponse, error) {
 	// NOTE: maybe we will separate the locked and unlocked balances
 	// This call should be updated in that case
 	ctx := context.Background()
 
-	ub, lb, err := t.proxy.Provider().GetBalance(ctx, t.w.ViewKey)
+	ub, lb, err := t.proxy.Provider().GetBalance(ctx, account.ViewKey)
 	if err != nil {
 		return nil, err
 	}
@@ -293,14 +288,40 @@ func (t *Transactor) handleClearWalletDatabase() (*node.GenericResponse, error)
 	return &node.GenericResponse{Response: "Wallet database deleted."}, nil
 }
 
-func (t *Transactor) publishTx(tx transactions.ContractCall) ([]byte, error) {
+// publishTx sends a transaction of an account to the mempool, and adds it to
+// the history of the account.
+func (t *Transactor) publishTx(account wallet.Account, tx transactions.ContractCall) ([]byte, error) {
 	hash, err := tx.CalculateHash()
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = t.rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 5*time.Second)
-	return hash, err
+	if _, err = t.rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 5*time.Second); err != nil {
+		return hash, err
+	}
+
+	t.recordSentTx(account, tx)
+	return hash, nil
+}
+
+// recordSentTx stores a transaction sent by an account in its history, at
+// the current chain height. The transaction is already in the mempool, so a
+// failure is logged rather than returned.
+func (t *Transactor) recordSentTx(account wallet.Account, tx transactions.ContractCall) {
+	var height uint64
+
+	err := t.db.View(func(t database.Transaction) error {
+		var err error
+		height, err = t.FetchCurrentHeight()
+		return err
+	})
+	if err == nil {
+		err = t.w.StoreTxRecord(account.Index, tx, height, txrecords.Out)
+	}
+
+	if err != nil {
+		log.WithError(err).Error("failed to store the record of a sent transaction")
+	}
 }
 
 func (t *Transactor) handleSendContract(c *node.CallContractRequest) (*node.TransactionResponse, error) {
@@ -10,6 +10,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -22,6 +24,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // Transactor is the implementation of both the Waller and the Transactor GRPC servers.
@@ -149,9 +152,61 @@ func (t *Transactor) canStake() bool {
 	return false
 }
 
+// account returns the wallet account selected by the metadata of a gRPC
+// request (see wallet.AccountMetadataKey). Requests without it apply to the
+// default account.
+//
+// Rusk spends the funds of the keys it was started with, and its API does
+// not take the keys of the sender, so the accounts derived from the seed
+// can not spend. They are not served until they can.
+func (t *Transactor) account(ctx context.Context) (wallet.Account, error) {
+	if t.w == nil {
+		return wallet.Account{}, errWalletNotLoaded
+	}
+
+	index := wallet.DefaultAccount
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(wallet.AccountMetadataKey); len(values) > 0 {
+			i, err := strconv.ParseUint(values[0], 10, 32)
+			if err != nil {
+				return wallet.Account{}, fmt.Errorf("%w: %q", wallet.ErrUnknownAccount, values[0])
+			}
+
+			index = uint32(i)
+		}
+	}
+
+	if index != wallet.DefaultAccount {
+		return wallet.Account{}, fmt.Errorf("%w: account %d", errAccountUnsupported, index)
+	}
+
+	return t.w.Account(index)
+}
+
+// spendingAccount returns the account selected by a request, if it can send
+// transactions. A watch-only wallet can not sign them.
+func (t *Transactor) spendingAccount(ctx context.Context) (wallet.Account, error) {
+	account, err := t.account(ctx)
+	if err != nil {
+		return wallet.Account{}, err
+	}
+
+	if t.w.IsWatchOnly() {
+		return wallet.Account{}, wallet.ErrWatchOnly
+	}
+
+	return account, nil
+}
+
 // GetTxHistory will return a subset of the transactions that were sent and received.
 func (t *Transactor) GetTxHistory(ctx context.Context, e *node.EmptyRequest) (*node.TxHistoryResponse, error) {
-	return t.handleGetTxHistory()
+	account, err := t.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.handleGetTxHistory(account)
 }
 
 // ClearWalletDatabase clears the wallet database, containing the unspent outputs.
@@ -161,35 +216,64 @@ func (t *Transactor) ClearWalletDatabase(ctx context.Context, e *node.EmptyReque
 
 // CallContract will create a transaction that calls a smart contract.
 func (t *Transactor) CallContract(ctx context.Context, c *node.CallContractRequest) (*node.TransactionResponse, error) {
+	if _, err := t.spendingAccount(ctx); err != nil {
+		return nil, err
+	}
+
 	return t.handleSendContract(c)
 }
 
 // Transfer will create a normal transaction, transferring DUSK.
 func (t *Transactor) Transfer(ctx context.Context, tr *node.TransferRequest) (*node.TransactionResponse, error) {
-	return t.handleSendStandardTx(tr)
+	account, err := t.spendingAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.handleSendStandardTx(account, tr)
 }
 
 // Bid will create a bidding transaction.
 func (t *Transactor) Bid(ctx context.Context, c *node.BidRequest) (*node.TransactionResponse, error) {
-	return t.handleSendBidTx(c)
+	account, err := t.spendingAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.handleSendBidTx(account, c)
 }
 
 // Stake will create a staking transaction.
 func (t *Transactor) Stake(ctx context.Context, c *node.StakeRequest) (*node.TransactionResponse, error) {
+	account, err := t.spendingAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Are we synced?
 	if !t.canStake() {
 		return nil, errors.New("node is not synced")
 	}
 
-	return t.handleSendStakeTx(c)
+	return t.handleSendStakeTx(account, c)
 }
 
 // GetAddress returns the address of the loaded wallet.
 func (t *Transactor) GetAddress(ctx context.Context, e *node.EmptyRequest) (*node.LoadResponse, error) {
-	return t.handleAddress()
+	account, err := t.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.handleAddress(account)
 }
 
 // GetBalance returns the balance of the loaded wallet.
 func (t *Transactor) GetBalance(ctx context.Context, e *node.EmptyRequest) (*node.BalanceResponse, error) {
-	return t.handleBalance()
+	account, err := t.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.handleBalance(account)
 }
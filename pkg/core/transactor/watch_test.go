@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package transactor
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// Test that a watch-only wallet refuses every request needing a signature.
+func TestWatchOnlyRefusesSigning(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "transactor")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	pk := keys.PublicKey{AG: randomBytes(t, 32), BG: randomBytes(t, 32)}
+	vk := keys.ViewKey{A: randomBytes(t, 32), BG: randomBytes(t, 32)}
+
+	file := filepath.Join(dir, "watch.dat")
+	assert.NoError(wallet.SaveWatchOnly("password", file, pk, vk))
+
+	w, err := wallet.LoadFromFile(byte(1), nil, "password", file)
+	assert.NoError(err)
+
+	// Rusk is never reached, as the wallet is checked first
+	tr := &Transactor{w: w}
+	ctx := context.Background()
+
+	_, err = tr.Transfer(ctx, &node.TransferRequest{Amount: 1, Address: pk.ToAddr()})
+	assert.Equal(wallet.ErrWatchOnly, err)
+
+	_, err = tr.Stake(ctx, &node.StakeRequest{Amount: 1})
+	assert.Equal(wallet.ErrWatchOnly, err)
+
+	_, err = tr.Bid(ctx, &node.BidRequest{Amount: 1})
+	assert.Equal(wallet.ErrWatchOnly, err)
+
+	_, err = tr.CallContract(ctx, &node.CallContractRequest{})
+	assert.Equal(wallet.ErrWatchOnly, err)
+
+	// The incoming transactions are not reported by Rusk
+	_, err = tr.GetTxHistory(ctx, &node.EmptyRequest{})
+	assert.Equal(wallet.ErrWatchOnlyHistory, err)
+
+	// The address is still served
+	resp, err := tr.GetAddress(ctx, &node.EmptyRequest{})
+	assert.NoError(err)
+	assert.Equal(pk.ToAddr(), resp.Key.PublicKey)
+}
+
+// Test that the accounts derived from the seed are not served, as Rusk can
+// not spend their funds.
+func TestDerivedAccountRefused(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "transactor")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	pk := keys.PublicKey{AG: randomBytes(t, 32), BG: randomBytes(t, 32)}
+	vk := keys.ViewKey{A: randomBytes(t, 32), BG: randomBytes(t, 32)}
+
+	file := filepath.Join(dir, "watch.dat")
+	assert.NoError(wallet.SaveWatchOnly("password", file, pk, vk))
+
+	w, err := wallet.LoadFromFile(byte(1), nil, "password", file)
+	assert.NoError(err)
+
+	tr := &Transactor{w: w}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(wallet.AccountMetadataKey, "1"))
+
+	_, err = tr.GetAddress(ctx, &node.EmptyRequest{})
+	assert.True(errors.Is(err, errAccountUnsupported))
+
+	// The default account can still be selected explicitly
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(wallet.AccountMetadataKey, "0"))
+
+	resp, err := tr.GetAddress(ctx, &node.EmptyRequest{})
+	assert.NoError(err)
+	assert.Equal(pk.ToAddr(), resp.Key.PublicKey)
+}
+
+func randomBytes(t *testing.T, size int) []byte {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
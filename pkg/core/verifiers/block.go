@@ -11,10 +11,12 @@ import (
 	"errors"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-crypto/bls"
 )
 
@@ -42,18 +44,20 @@ func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block) erro
 		return err
 	}
 
+	// The committees of legacy blocks never exceed 64 members
+	size := committeeSize(provisioners.SubsetSizeAt(blk.Header.Height), blk.Header.Version)
+
 	// Now, check the certificate's correctness for both reduction steps
-	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, blk.Header.Hash); err != nil {
+	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, size, provisioners, blk.Header.Hash); err != nil {
 		return err
 	}
 
-	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash)
+	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, size, provisioners, blk.Header.Hash)
 }
 
-func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet uint64, round uint64, step uint8, provisioners user.Provisioners, blockHash []byte) error {
-	size := committeeSize(provisioners.SubsetSizeAt(round))
-	committee := provisioners.CreateVotingCommittee(round, step, size)
-	subcommittee := committee.IntersectCluster(bitSet)
+func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet sortedset.BitSet, round uint64, step uint8, size int, provisioners user.Provisioners, blockHash []byte) error {
+	votingCommittee := provisioners.CreateVotingCommittee(round, step, size)
+	subcommittee := votingCommittee.IntersectCluster(bitSet)
 
 	apk, err := agreement.ReconstructApk(subcommittee.Set)
 	if err != nil {
@@ -63,9 +67,9 @@ func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet uint64, roun
 	return header.VerifySignatures(round, step, blockHash, apk, batchedSig)
 }
 
-func committeeSize(memberAmount int) int {
-	if memberAmount > agreement.MaxCommitteeSize {
-		return agreement.MaxCommitteeSize
+func committeeSize(memberAmount int, version uint8) int {
+	if maxSize := committee.MaxSizeFor(version); memberAmount > maxSize {
+		return maxSize
 	}
 
 	return memberAmount
@@ -75,8 +79,9 @@ func committeeSize(memberAmount int) int {
 // These are stateless and stateful checks.
 // Returns nil, if all checks pass.
 func CheckBlockHeader(prevBlock block.Block, blk block.Block) error {
-	// Version
-	if blk.Header.Version > 0 {
+	// Version. Legacy blocks are still accepted, so that the chain can be
+	// synced from the genesis block
+	if blk.Header.Version > block.CurrentVersion {
 		return errors.New("unsupported block version")
 	}
 
@@ -192,13 +192,25 @@ func (s *Server) EnableNotifications(serverMux *http.ServeMux) error {
 			return
 		}
 
+		// Clients subscribe to a single topic, e.g /ws?topic=mempool
+		topic := r.URL.Query().Get("topic")
+
+		switch topic {
+		case "":
+			topic = notifications.TopicBlocks
+		case notifications.TopicBlocks, notifications.TopicMempool:
+		default:
+			http.Error(w, "unknown topic", http.StatusBadRequest)
+			return
+		}
+
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			log.WithError(err).Error("Failed to set websocket upgrade")
 			return
 		}
 
-		s.pool.PushConn(conn)
+		s.pool.PushConn(conn, topic)
 	}
 
 	middleware := tollbooth.LimitFuncHandler(s.lmt, wsHandler)
@@ -12,6 +12,8 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	logger "github.com/sirupsen/logrus"
@@ -23,12 +25,20 @@ const (
 	writeDeadline = 3 * time.Second
 
 	maxTxsPerMsg = 15
+
+	// TopicBlocks is the websocket topic notifying about accepted blocks. It
+	// is the default topic.
+	TopicBlocks = "blocks"
+	// TopicMempool is the websocket topic notifying about txs entering and
+	// leaving the mempool.
+	TopicMempool = "mempool"
 )
 
 var log = logger.WithField("process", "broker")
 
 // Broker is a pub/sub broker that keeps updated all subscribers (websocket
-// connections) with latest block accepted published by node layer.
+// connections) with latest block accepted, or the mempool events, published
+// by node layer.
 //
 // IMPL Notes:
 // Broker is implemented in a non-blocking manner. That means it should not be
@@ -50,6 +60,8 @@ type Broker struct {
 	eventBus          eventbus.Broker
 	acceptedBlockChan chan block.Block
 	acceptedBlockID   uint32
+	mempoolEventChan  chan message.Message
+	mempoolEventID    uint32
 }
 
 // NewBroker creates a new Broker instance.
@@ -58,6 +70,8 @@ func NewBroker(id uint, eventBus eventbus.Broker, maxClientsCount uint, connChan
 	b.eventBus = eventBus
 	b.ConnectionChan = connChan
 	b.acceptedBlockChan, b.acceptedBlockID = consensus.InitAcceptedBlockUpdate(eventBus)
+	b.mempoolEventChan = make(chan message.Message, 1000)
+	b.mempoolEventID = eventBus.Subscribe(topics.MempoolEvent, eventbus.NewChanListener(b.mempoolEventChan))
 	b.clients = list.New()
 	b.maxClientsCount = maxClientsCount
 	b.id = id
@@ -76,6 +90,7 @@ func (b *Broker) Run() {
 
 		// Unsubscribe from all eventBus events.
 		b.eventBus.Unsubscribe(topics.AcceptedBlock, b.acceptedBlockID)
+		b.eventBus.Unsubscribe(topics.MempoolEvent, b.mempoolEventID)
 
 		// Terminate all clients goroutines.
 		for e := b.clients.Front(); e != nil; e = e.Next() {
@@ -101,6 +116,9 @@ func (b *Broker) Run() {
 		// new accepted block from node
 		case blk := <-b.acceptedBlockChan:
 			b.handleBlock(blk)
+		// new mempool event from node
+		case m := <-b.mempoolEventChan:
+			b.handleMempoolEvent(m.Payload().(mempool.Event))
 		case <-time.After(30 * time.Second):
 			b.handleIdle()
 		}
@@ -123,7 +141,27 @@ func (b *Broker) handleBlock(blk block.Block) {
 		log.Errorf("encoding err: %v", err)
 	}
 
-	b.broadcastMessage(msg)
+	b.broadcastMessage(TopicBlocks, msg)
+}
+
+// handleMempoolEvent handles the topics.MempoolEvent event emitted from node
+// layer. It packs a json from the event and broadcast it to all clients
+// subscribed to TopicMempool.
+func (b *Broker) handleMempoolEvent(e mempool.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("handleMempoolEvent recovered from err: %v", r)
+		}
+	}()
+
+	b.reap()
+
+	msg, err := MarshalMempoolEventMsg(e)
+	if err != nil {
+		log.Errorf("encoding err: %v", err)
+	}
+
+	b.broadcastMessage(TopicMempool, msg)
 }
 
 // handleConn handles a new websocket conn pushed from webserver layer It stores
@@ -146,10 +184,16 @@ func (b *Broker) handleConn(conn wsConn) {
 		return
 	}
 
+	topic := TopicBlocks
+	if tc, ok := conn.(topicConn); ok {
+		topic = tc.topic
+	}
+
 	c := &wsClient{
 		conn:    conn,
 		msgChan: make(chan []byte, 100),
 		id:      conn.RemoteAddr().String(),
+		topic:   topic,
 	}
 
 	_ = b.clients.PushBack(c)
@@ -173,8 +217,10 @@ func (b *Broker) handleIdle() {
 		Debug("onidle")
 }
 
-// broadcastMessage propagates data to all active clients.
-func (b *Broker) broadcastMessage(data string) {
+// broadcastMessage propagates data to all active clients subscribed to the
+// topic. The mempool events are dropped for the clients whose queue is full,
+// while the other topics are never dropped.
+func (b *Broker) broadcastMessage(topic, data string) {
 	if len(data) == 0 || b.clients.Len() == 0 {
 		return
 	}
@@ -186,7 +232,22 @@ func (b *Broker) broadcastMessage(data string) {
 
 	for e := b.clients.Front(); e != nil; e = e.Next() {
 		c := e.Value.(*wsClient)
-		c.msgChan <- []byte(data)
+		if c.topic != topic {
+			continue
+		}
+
+		if topic != TopicMempool {
+			c.msgChan <- []byte(data)
+			continue
+		}
+
+		// The mempool events come at a far higher rate than the blocks: the
+		// broker must not be blocked by a slow client
+		select {
+		case c.msgChan <- []byte(data):
+		default:
+			log.WithField("client", c.id).Debug("client queue full, mempool event dropped")
+		}
 	}
 }
 
@@ -45,3 +45,28 @@ func TestReapClients(t *testing.T) {
 		t.Fatalf("Not all closed")
 	}
 }
+
+func TestBroadcastDropsOnlyMempoolEvents(t *testing.T) {
+	b := Broker{}
+	b.clients = list.New()
+
+	blocks := &wsClient{id: "blocks", topic: TopicBlocks, msgChan: make(chan []byte, 2)}
+	mempool := &wsClient{id: "mempool", topic: TopicMempool, msgChan: make(chan []byte, 1)}
+
+	b.clients.PushBack(blocks)
+	b.clients.PushBack(mempool)
+
+	// The mempool client queue overflows, without blocking the broker
+	b.broadcastMessage(TopicMempool, "first")
+	b.broadcastMessage(TopicMempool, "second")
+
+	if len(mempool.msgChan) != 1 || string(<-mempool.msgChan) != "first" {
+		t.Fatal("mempool event not dropped")
+	}
+
+	b.broadcastMessage(TopicBlocks, "block")
+
+	if len(blocks.msgChan) != 1 || string(<-blocks.msgChan) != "block" {
+		t.Fatal("block not delivered")
+	}
+}
@@ -19,6 +19,8 @@ type wsClient struct {
 	// closing msgChan terminates wsClient loop
 	msgChan chan []byte
 	id      string
+	// topic the client is subscribed to
+	topic string
 
 	closed int32
 }
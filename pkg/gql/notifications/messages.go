@@ -11,6 +11,8 @@ import (
 	"encoding/json"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 )
 
 // BlockMsg represents the data need by Explorer UI on each new block accepted.
@@ -58,3 +60,32 @@ func MarshalBlockMsg(blk block.Block) (string, error) {
 
 	return string(msg), nil
 }
+
+// MempoolEventMsg represents a tx entering or leaving the mempool.
+type MempoolEventMsg struct {
+	Event  string
+	TxID   string
+	TxType transactions.TxType
+	// Reason is set for rejected and evicted txs.
+	Reason string `json:",omitempty"`
+	// Height is set for txs included in a block.
+	Height uint64 `json:",omitempty"`
+}
+
+// MarshalMempoolEventMsg builds the JSON from a mempool event.
+func MarshalMempoolEventMsg(e mempool.Event) (string, error) {
+	p := MempoolEventMsg{
+		Event:  e.Type.String(),
+		TxID:   hex.EncodeToString(e.TxID),
+		TxType: e.TxType,
+		Reason: e.Reason,
+		Height: e.Height,
+	}
+
+	msg, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	return string(msg), nil
+}
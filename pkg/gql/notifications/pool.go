@@ -59,8 +59,15 @@ func NewPool(eventBus *eventbus.EventBus, brokersNum, clientsPerBroker uint) *Br
 	return bp
 }
 
-// PushConn pushes a websocket connection to the broker pool.
-func (bp *BrokerPool) PushConn(conn *websocket.Conn) {
+// topicConn is a websocket connection subscribed to a specific topic.
+type topicConn struct {
+	wsConn
+	topic string
+}
+
+// PushConn pushes a websocket connection, subscribed to the given topic, to
+// the broker pool.
+func (bp *BrokerPool) PushConn(conn *websocket.Conn, topic string) {
 	if conn == nil {
 		return
 	}
@@ -69,7 +76,7 @@ func (bp *BrokerPool) PushConn(conn *websocket.Conn) {
 	defer bp.lock.Unlock()
 
 	if bp.ConnectionsChan != nil {
-		bp.ConnectionsChan <- conn
+		bp.ConnectionsChan <- topicConn{conn, topic}
 	} else {
 		// Broker is closing, cannot manage this connection
 		_ = conn.Close()
@@ -18,6 +18,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -32,12 +34,19 @@ type mockWebsocketConn struct {
 func (c *mockWebsocketConn) WriteMessage(messageType int, data []byte) error {
 	// Mimic connection consuming the json msg
 	var p BlockMsg
-	if err := json.Unmarshal(data, &p); err == nil {
+	if err := json.Unmarshal(data, &p); err == nil && len(p.Hash) > 0 {
 		c.mu.Lock()
 		c.msgBuf[p.Hash] = true
 		c.mu.Unlock()
 	}
 
+	var e MempoolEventMsg
+	if err := json.Unmarshal(data, &e); err == nil && len(e.TxID) > 0 {
+		c.mu.Lock()
+		c.msgBuf[e.TxID] = true
+		c.mu.Unlock()
+	}
+
 	return nil
 }
 
@@ -131,3 +140,36 @@ func TestPoolBasicScenario(t *testing.T) {
 		t.Fatal("invalid test context")
 	}
 }
+
+// Test that mempool events are only sent to the clients subscribed to
+// TopicMempool.
+func TestPoolMempoolTopic(t *testing.T) {
+	eb := eventbus.New()
+
+	pool := NewPool(eb, 1, 10)
+	defer pool.Close()
+
+	blocksConn := &mockWebsocketConn{msgBuf: make(map[string]bool)}
+	mempoolConn := &mockWebsocketConn{msgBuf: make(map[string]bool)}
+
+	pool.ConnectionsChan <- blocksConn
+	pool.ConnectionsChan <- topicConn{mempoolConn, TopicMempool}
+
+	time.Sleep(1 * time.Second)
+
+	txid := transactions.Rand32Bytes()
+	e := mempool.Event{Type: mempool.EventAccepted, TxID: txid}
+
+	errList := eb.Publish(topics.MempoolEvent, message.New(topics.MempoolEvent, e))
+	require.Empty(t, errList)
+
+	time.Sleep(1 * time.Second)
+
+	mempoolConn.mu.RLock()
+	require.True(t, mempoolConn.msgBuf[hex.EncodeToString(txid)])
+	mempoolConn.mu.RUnlock()
+
+	blocksConn.mu.RLock()
+	require.Empty(t, blocksConn.msgBuf)
+	blocksConn.mu.RUnlock()
+}
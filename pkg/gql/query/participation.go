@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/graphql-go/graphql"
+)
+
+const participationKeyArg = "blskey"
+
+// queryParticipation is a data-wrapper for the consensus participation
+// counters of a provisioner.
+type queryParticipation struct {
+	BLSKey   []byte
+	Expected uint64
+	Cast     uint64
+	Missed   uint64
+}
+
+// File purpose is to define all arguments and resolvers relevant to
+// "participation" query only.
+
+type participation struct{}
+
+func (pa participation) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(Participation),
+		Args: graphql.FieldConfigArgument{
+			participationKeyArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+		},
+		Resolve: pa.resolve,
+	}
+}
+
+func (pa participation) resolve(p graphql.ResolveParams) (interface{}, error) {
+	// Retrieve DB conn from context
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	var all []database.Participation
+
+	keyStr, hasKey := p.Args[participationKeyArg].(string)
+
+	err := db.View(func(t database.Transaction) error {
+		if !hasKey {
+			var err error
+			all, err = t.FetchAllParticipation()
+			return err
+		}
+
+		pubKeyBLS, err := hex.DecodeString(keyStr)
+		if err != nil {
+			return err
+		}
+
+		stored, err := t.FetchParticipation(pubKeyBLS)
+		if err == database.ErrParticipationNotFound {
+			return nil
+		}
+
+		all = []database.Participation{stored}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	participation := make([]queryParticipation, len(all))
+	for i, stored := range all {
+		participation[i] = queryParticipation{
+			BLSKey:   stored.PubKeyBLS,
+			Expected: stored.Expected,
+			Cast:     stored.Cast,
+			Missed:   stored.Missed,
+		}
+	}
+
+	return participation, nil
+}
@@ -0,0 +1,73 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestParticipationByKey(t *testing.T) {
+	pubKeyBLS := make([]byte, 129)
+	pubKeyBLS[0] = 0xaa
+
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		return t.StoreParticipation(database.Participation{
+			PubKeyBLS: pubKeyBLS,
+			Expected:  12,
+			Cast:      10,
+			Missed:    2,
+		})
+	}))
+
+	query := fmt.Sprintf(`
+		{
+		  participation(blskey: "%s") {
+			  blskey
+			  expected
+			  cast
+			  missed
+		  }
+		}
+		`, hex.EncodeToString(pubKeyBLS))
+	response := fmt.Sprintf(`
+		{
+			"data": {
+				"participation": [
+					{
+						"blskey": "%s",
+						"expected": 12,
+						"cast": 10,
+						"missed": 2
+					}
+				]
+			}
+		}
+	`, hex.EncodeToString(pubKeyBLS))
+	assertQuery(t, query, response)
+
+	// Unknown provisioners have no participation
+	query = `
+		{
+		  participation(blskey: "bb") {
+			  blskey
+		  }
+		}
+		`
+	response = `
+		{
+			"data": {
+				"participation": []
+			}
+		}
+	`
+	assertQuery(t, query, response)
+}
@@ -16,7 +16,8 @@ type Root struct {
 	Query *graphql.Object
 }
 
-// NewRoot returns a Root with blocks, transactions and mempool setup.
+// NewRoot returns a Root with blocks, transactions, mempool and participation
+// setup.
 func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 	m := mempool{rpcBus: rpcBus}
 
@@ -25,9 +26,10 @@ func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 			graphql.ObjectConfig{
 				Name: "Query",
 				Fields: graphql.Fields{
-					"blocks":       blocks{}.getQuery(),
-					"transactions": transactions{}.getQuery(),
-					"mempool":      m.getQuery(),
+					"blocks":        blocks{}.getQuery(),
+					"transactions":  transactions{}.getQuery(),
+					"mempool":       m.getQuery(),
+					"participation": participation{}.getQuery(),
 				},
 			},
 		),
@@ -20,10 +20,17 @@ import (
 
 const (
 	txsFetchLimit = 10000
-
-	txidArg   = "txid"
-	txidsArg  = "txids"
-	txlastArg = "last"
+	// txsIndexLimit is the default number of txs returned by the secondary
+	// tx index lookups.
+	txsIndexLimit = 100
+
+	txidArg    = "txid"
+	txidsArg   = "txids"
+	txlastArg  = "last"
+	txoutArg   = "output"
+	txtypeArg  = "txtype"
+	txfromArg  = "from"
+	txlimitArg = "limit"
 )
 
 type (
@@ -115,6 +122,18 @@ func (t transactions) getQuery() *graphql.Field {
 			txlastArg: &graphql.ArgumentConfig{
 				Type: graphql.Int,
 			},
+			txoutArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+			txtypeArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+			txfromArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
+			txlimitArg: &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
 		},
 		Resolve: t.resolve,
 	}
@@ -139,6 +158,54 @@ func (t transactions) resolve(p graphql.ResolveParams) (interface{}, error) {
 		return t.fetchTxsByHash(db, ids)
 	}
 
+	output, hasOutput := p.Args[txoutArg].(string)
+	txType, hasType := p.Args[txtypeArg].(int)
+
+	if hasOutput || hasType {
+		from, _ := p.Args[txfromArg].(int)
+		if from < 0 {
+			return nil, errors.New("invalid height")
+		}
+
+		limit, ok := p.Args[txlimitArg].(int)
+		if !ok {
+			limit = txsIndexLimit
+		}
+
+		if limit <= 0 || limit >= txsFetchLimit {
+			return nil, errors.New("invalid limit")
+		}
+
+		if hasType && (txType < 0 || txType > int(core.WithdrawBid)) {
+			return nil, errors.New("invalid tx type")
+		}
+
+		if !hasOutput {
+			return t.fetchTxsByType(db, core.TxType(txType), uint64(from), limit)
+		}
+
+		pubKey, err := hex.DecodeString(output)
+		if err != nil {
+			return nil, err
+		}
+
+		txs, err := t.fetchTxsByOutput(db, pubKey, uint64(from), limit)
+		if err != nil || !hasType {
+			return txs, err
+		}
+
+		// The outputs are indexed regardless of the tx type
+		filtered := make([]queryTx, 0, len(txs))
+
+		for _, tx := range txs {
+			if tx.TxType == core.TxType(txType) {
+				filtered = append(filtered, tx)
+			}
+		}
+
+		return filtered, nil
+	}
+
 	count, ok := p.Args[txlastArg].(int)
 	if ok {
 		if count <= 0 {
@@ -181,6 +248,63 @@ func (t transactions) fetchTxsByHash(db database.DB, txids []interface{}) ([]que
 	return txs, err
 }
 
+// Fetch the txs holding an output to pubKey, from the secondary tx index.
+func (t transactions) fetchTxsByOutput(db database.DB, pubKey []byte, from uint64, limit int) ([]queryTx, error) {
+	var txs []queryTx
+
+	err := db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByOutputKey(pubKey, from, limit)
+		if err != nil {
+			return err
+		}
+
+		txs, err = fetchTxsByLocation(t, locations)
+		return err
+	})
+
+	return txs, err
+}
+
+// Fetch the txs of type txType, from the secondary tx index.
+func (t transactions) fetchTxsByType(db database.DB, txType core.TxType, from uint64, limit int) ([]queryTx, error) {
+	var txs []queryTx
+
+	err := db.View(func(t database.Transaction) error {
+		locations, err := t.FetchTxsByType(txType, from, limit)
+		if err != nil {
+			return err
+		}
+
+		txs, err = fetchTxsByLocation(t, locations)
+		return err
+	})
+
+	return txs, err
+}
+
+// fetchTxsByLocation fetches the indexed txs. Pruned txs are skipped.
+func fetchTxsByLocation(t database.Transaction, locations []database.TxLocation) ([]queryTx, error) {
+	txs := make([]queryTx, 0, len(locations))
+
+	for _, l := range locations {
+		tx, _, hash, err := t.FetchBlockTxByHash(l.TxID)
+		if err == database.ErrTxPruned {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := newQueryTx(tx, hash)
+		if err == nil {
+			txs = append(txs, d)
+		}
+	}
+
+	return txs, nil
+}
+
 // Fetch `count` number of txs from lastly accepted blocks.
 func (t transactions) fetchLastTxs(db database.DB, count int) ([]queryTx, error) {
 	txs := make([]queryTx, 0)
@@ -192,3 +192,51 @@ func TestTxSize(t *testing.T) {
 	`
 	assertQuery(t, query, response)
 }
+
+func TestTxsByType(t *testing.T) {
+	query := `
+		{
+			transactions(txtype: 3, from: 1, limit: 1)
+			{
+				txid
+				blockhash
+			}
+		}
+	`
+	response := fmt.Sprintf(`
+		{
+			"data": {
+				"transactions": [
+					{
+						"blockhash": "%s",
+						"txid": "%s"
+					}
+				]
+			}
+		}
+	`, block2, bid2Hash)
+	assertQuery(t, query, response)
+}
+
+func TestTxsByOutput(t *testing.T) {
+	query := `
+		{
+			transactions(output: "0000000000000000000000000000000000000000000000000000000000000000", txtype: 3, from: 2)
+			{
+				txid
+			}
+		}
+	`
+	response := fmt.Sprintf(`
+		{
+			"data": {
+				"transactions": [
+					{
+						"txid": "%s"
+					}
+				]
+			}
+		}
+	`, bid3Hash)
+	assertQuery(t, query, response)
+}
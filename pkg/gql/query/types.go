@@ -129,6 +129,28 @@ var Input = graphql.NewObject(
 	},
 )
 
+// Participation is the graphql object representing the consensus
+// participation of a provisioner.
+var Participation = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Participation",
+		Fields: graphql.Fields{
+			"blskey": &graphql.Field{
+				Type: Hex,
+			},
+			"expected": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"cast": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"missed": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	},
+)
+
 // Hex is the graphql object representing a hex scalar.
 var Hex = graphql.NewScalar(graphql.ScalarConfig{
 	Name:        "Hex",
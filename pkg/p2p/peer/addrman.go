@@ -0,0 +1,335 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxKnownAddresses caps the size of the address book, so that peers
+	// flooding us with addresses cannot exhaust the memory.
+	maxKnownAddresses = 1000
+
+	// maxFailures is the number of consecutive failed connection attempts
+	// after which an address is forgotten.
+	maxFailures = 10
+
+	// retryInterval is the time to wait before dialing again an address
+	// whose last connection attempt failed.
+	retryInterval = 10 * time.Minute
+)
+
+type (
+	// KnownAddress is a peer address learnt from the network.
+	KnownAddress struct {
+		Address   string `json:"address"`
+		Successes uint32 `json:"successes"`
+		// Failures counts the consecutive failed connection attempts.
+		Failures    uint32    `json:"failures"`
+		LastSeen    time.Time `json:"last_seen"`
+		LastAttempt time.Time `json:"last_attempt"`
+		LastSuccess time.Time `json:"last_success"`
+	}
+
+	// AddrManager is the address book of the node. It learns the addresses
+	// advertised by the peers through `Addr` messages, and scores them by the
+	// outcome of the connection attempts. The addresses are persisted to a
+	// file, so that the node can reconnect to the network without the voucher
+	// seeder after a restart.
+	AddrManager struct {
+		lock  sync.Mutex
+		addrs map[string]*KnownAddress
+		dirty bool
+
+		path string
+	}
+)
+
+// score ranks the addresses by reliability. Addresses we never connected to
+// score zero.
+func (k *KnownAddress) score() int64 {
+	return int64(k.Successes) - 2*int64(k.Failures)
+}
+
+// NewAddrManager returns an AddrManager loading the addresses persisted in
+// the given file. An empty path keeps the addresses in memory only.
+func NewAddrManager(path string) *AddrManager {
+	a := &AddrManager{
+		addrs: make(map[string]*KnownAddress),
+		path:  path,
+	}
+
+	if err := a.load(); err != nil {
+		log.WithField("process", "addr manager").
+			WithField("path", path).
+			WithError(err).Warn("could not load the address book")
+	}
+
+	return a
+}
+
+// Add an address advertised by a peer to the address book. Malformed
+// addresses are ignored. It returns false if the address was not added.
+func (a *AddrManager) Add(address string) bool {
+	if !validAddress(address) {
+		return false
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+
+	if k, ok := a.addrs[address]; ok {
+		k.LastSeen = now
+		a.dirty = true
+		return true
+	}
+
+	if len(a.addrs) >= maxKnownAddresses && !a.evict() {
+		return false
+	}
+
+	a.addrs[address] = &KnownAddress{Address: address, LastSeen: now}
+	a.dirty = true
+	return true
+}
+
+// Good marks a successful connection with a known address. Unknown addresses
+// are ignored, so that the voucher seeders never end up in the address book.
+func (a *AddrManager) Good(address string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	k, ok := a.addrs[address]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	k.Successes++
+	k.Failures = 0
+	k.LastAttempt = now
+	k.LastSuccess = now
+	k.LastSeen = now
+	a.dirty = true
+}
+
+// Failed marks a failed connection attempt with a known address. Addresses
+// failing too many times in a row are forgotten.
+func (a *AddrManager) Failed(address string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	k, ok := a.addrs[address]
+	if !ok {
+		return
+	}
+
+	k.Failures++
+	k.LastAttempt = time.Now()
+	a.dirty = true
+
+	if k.Failures >= maxFailures {
+		delete(a.addrs, address)
+	}
+}
+
+// Candidates returns the addresses worth dialing, the most reliable first.
+// Addresses which failed their last connection attempt are retried only
+// after a while.
+func (a *AddrManager) Candidates() []string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+	known := make([]*KnownAddress, 0, len(a.addrs))
+
+	for _, k := range a.addrs {
+		if k.Failures > 0 && now.Sub(k.LastAttempt) < retryInterval {
+			continue
+		}
+
+		known = append(known, k)
+	}
+
+	return sorted(known)
+}
+
+// Addresses returns up to n addresses we successfully connected to, the most
+// reliable first. They are the addresses shared with the peers asking for
+// some.
+func (a *AddrManager) Addresses(n int) []string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	known := make([]*KnownAddress, 0, len(a.addrs))
+
+	for _, k := range a.addrs {
+		if k.Successes > 0 && k.Failures == 0 {
+			known = append(known, k)
+		}
+	}
+
+	addrs := sorted(known)
+	if len(addrs) > n {
+		addrs = addrs[:n]
+	}
+
+	return addrs
+}
+
+// Size returns the number of known addresses.
+func (a *AddrManager) Size() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return len(a.addrs)
+}
+
+// Save persists the address book, if it changed since the last save.
+func (a *AddrManager) Save() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.dirty {
+		return
+	}
+
+	if err := a.save(); err != nil {
+		log.WithField("process", "addr manager").
+			WithError(err).Warn("could not persist the address book")
+		return
+	}
+
+	a.dirty = false
+}
+
+// evict forgets the least reliable address, the least recently seen among
+// equals, to make room for a new one. Addresses we successfully connected to
+// are never evicted in favour of unknown ones.
+func (a *AddrManager) evict() bool {
+	var worst *KnownAddress
+
+	for _, k := range a.addrs {
+		if worst == nil || k.score() < worst.score() ||
+			(k.score() == worst.score() && k.LastSeen.Before(worst.LastSeen)) {
+			worst = k
+		}
+	}
+
+	if worst == nil || worst.score() > 0 {
+		return false
+	}
+
+	delete(a.addrs, worst.Address)
+	return true
+}
+
+// load reads the addresses persisted in the address book file. A missing file
+// is not an error, as it is created on the first save.
+func (a *AddrManager) load() error {
+	if a.path == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(a.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var known []KnownAddress
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+
+	for i := range known {
+		if len(a.addrs) >= maxKnownAddresses {
+			break
+		}
+
+		if validAddress(known[i].Address) {
+			a.addrs[known[i].Address] = &known[i]
+		}
+	}
+
+	return nil
+}
+
+// save writes the addresses to the address book file. The file is replaced
+// atomically, so that a crash cannot leave a truncated address book behind.
+func (a *AddrManager) save() error {
+	if a.path == "" {
+		return nil
+	}
+
+	known := make([]KnownAddress, 0, len(a.addrs))
+	for _, k := range a.addrs {
+		known = append(known, *k)
+	}
+
+	sort.Slice(known, func(i, j int) bool {
+		return known[i].Address < known[j].Address
+	})
+
+	data, err := json.MarshalIndent(known, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := a.path + ".new"
+	if err := ioutil.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, a.path)
+}
+
+// sorted returns the addresses by descending score, the most recently seen
+// first among equals.
+func sorted(known []*KnownAddress) []string {
+	sort.Slice(known, func(i, j int) bool {
+		if known[i].score() != known[j].score() {
+			return known[i].score() > known[j].score()
+		}
+
+		return known[i].LastSeen.After(known[j].LastSeen)
+	})
+
+	addrs := make([]string, len(known))
+	for i, k := range known {
+		addrs[i] = k.Address
+	}
+
+	return addrs
+}
+
+// validAddress returns true if the address is a host:port pair with a non
+// zero port.
+func validAddress(address string) bool {
+	h, p, err := net.SplitHostPort(address)
+	if err != nil || h == "" {
+		return false
+	}
+
+	port, err := strconv.ParseUint(p, 10, 16)
+	return err == nil && port != 0
+}
@@ -0,0 +1,100 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test that the address book survives a restart.
+func TestAddrManagerPersistence(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "addrbook")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "peers.json")
+
+	a := NewAddrManager(path)
+	assert.True(a.Add("10.0.0.1:7000"))
+	assert.True(a.Add("10.0.0.2:7000"))
+	a.Good("10.0.0.2:7000")
+	a.Save()
+
+	a = NewAddrManager(path)
+	assert.Equal(2, a.Size())
+	assert.Equal([]string{"10.0.0.2:7000", "10.0.0.1:7000"}, a.Candidates())
+	assert.Equal([]string{"10.0.0.2:7000"}, a.Addresses(maxAddrsReply))
+}
+
+func TestAddrManagerScoring(t *testing.T) {
+	assert := require.New(t)
+
+	a := NewAddrManager("")
+
+	// Malformed addresses are ignored
+	assert.False(a.Add("10.0.0.1"))
+	assert.False(a.Add("10.0.0.1:0"))
+	assert.False(a.Add(":7000"))
+
+	assert.True(a.Add("10.0.0.1:7000"))
+	assert.True(a.Add("10.0.0.2:7000"))
+	assert.True(a.Add("10.0.0.3:7000"))
+
+	a.Good("10.0.0.3:7000")
+	a.Good("10.0.0.3:7000")
+	a.Good("10.0.0.2:7000")
+
+	// Unknown addresses are not learnt from the connection attempts
+	a.Good("10.0.0.4:7000")
+	assert.Equal(3, a.Size())
+
+	assert.Equal([]string{"10.0.0.3:7000", "10.0.0.2:7000", "10.0.0.1:7000"}, a.Candidates())
+
+	// A failed address is not retried straight away, nor shared
+	a.Failed("10.0.0.3:7000")
+	assert.Equal([]string{"10.0.0.2:7000", "10.0.0.1:7000"}, a.Candidates())
+	assert.Equal([]string{"10.0.0.2:7000"}, a.Addresses(maxAddrsReply))
+
+	// Addresses failing too many times are forgotten
+	for i := 0; i < maxFailures; i++ {
+		a.Failed("10.0.0.1:7000")
+	}
+
+	assert.Equal(2, a.Size())
+}
+
+// Test that a full address book makes room for new addresses, without
+// forgetting the reliable ones.
+func TestAddrManagerEviction(t *testing.T) {
+	assert := require.New(t)
+
+	a := NewAddrManager("")
+
+	for i := 0; i < maxKnownAddresses; i++ {
+		assert.True(a.Add(fmt.Sprintf("10.0.%d.%d:7000", i/256, i%256)))
+		a.Good(fmt.Sprintf("10.0.%d.%d:7000", i/256, i%256))
+	}
+
+	// Every address is reliable
+	assert.False(a.Add("10.1.0.1:7000"))
+
+	a.Failed("10.0.0.1:7000")
+	a.Failed("10.0.0.1:7000")
+
+	assert.True(a.Add("10.1.0.1:7000"))
+	assert.Equal(maxKnownAddresses, a.Size())
+	assert.NotContains(a.Candidates(), "10.0.0.1:7000")
+}
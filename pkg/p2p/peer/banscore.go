@@ -0,0 +1,359 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultBanDuration = 24 * 60 * 60
+
+	// banScoreDecay is the score forgiven per second, so that peers
+	// occasionally sending a stale or invalid message are not banned over
+	// time.
+	banScoreDecay = 1.0 / 60
+
+	// Scores of the misbehaviours detected by the MessageProcessor.
+	malformedMessageScore = 20
+	illegalTopicScore     = 20
+)
+
+// ReportMisbehaviour notifies the BanManager about a peer sending invalid or
+// unsolicited messages. The score is added to the ban score of the peer.
+// Messages which do not come from a network peer (empty srcPeerID) are not
+// reported.
+func ReportMisbehaviour(publisher eventbus.Publisher, srcPeerID string, score uint32, reason string) {
+	if srcPeerID == "" || score == 0 {
+		return
+	}
+
+	// Subsystems listening for this topic:
+	// peer.Connector
+	m := message.Misbehaviour{Address: srcPeerID, Score: score, Reason: reason}
+	errList := publisher.Publish(topics.Misbehaviour, message.New(topics.Misbehaviour, m))
+
+	diagnostics.LogPublishErrors("peer/banscore.go, topics.Misbehaviour", errList)
+}
+
+type (
+	// Ban of a peer host.
+	Ban struct {
+		Host     string    `json:"host"`
+		Reason   string    `json:"reason"`
+		BannedAt time.Time `json:"banned_at"`
+		Until    time.Time `json:"until"`
+	}
+
+	banScore struct {
+		score   float64
+		updated time.Time
+	}
+
+	// BanManager accumulates the misbehaviour reported against the network
+	// peers. A peer crossing the ban score is disconnected, and its host is
+	// refused connections until the ban expires. The bans are persisted to
+	// a file, so that they survive restarts.
+	BanManager struct {
+		lock   sync.Mutex
+		scores map[string]*banScore
+		// bans are indexed by host, as inbound connections come from
+		// ephemeral ports.
+		bans map[string]Ban
+
+		path       string
+		disconnect func(address string)
+	}
+)
+
+// NewBanManager returns a BanManager loading the bans persisted in the given
+// file. An empty path keeps the bans in memory only. The disconnect callback
+// is called with the address of the peers getting banned.
+func NewBanManager(path string, disconnect func(address string)) *BanManager {
+	b := &BanManager{
+		scores:     make(map[string]*banScore),
+		bans:       make(map[string]Ban),
+		path:       path,
+		disconnect: disconnect,
+	}
+
+	if err := b.load(); err != nil {
+		log.WithField("process", "ban manager").
+			WithField("path", path).
+			WithError(err).Warn("could not load the ban list")
+	}
+
+	return b
+}
+
+// Report adds a misbehaviour score to the peer, and bans it once the total
+// score reaches the configured threshold.
+func (b *BanManager) Report(address string, score uint32, reason string) {
+	threshold := config.Get().Network.BanScore
+	if threshold == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	b.lock.Lock()
+
+	b.pruneScores(now)
+
+	s, ok := b.scores[address]
+	if !ok {
+		s = &banScore{updated: now}
+		b.scores[address] = s
+	}
+
+	s.score = s.decayed(now)
+	s.score += float64(score)
+	s.updated = now
+
+	total := s.score
+	if total < float64(threshold) {
+		b.lock.Unlock()
+
+		log.WithField("process", "ban manager").
+			WithField("address", address).
+			WithField("score", total).
+			WithField("reason", reason).
+			Debug("peer misbehaviour")
+		return
+	}
+
+	delete(b.scores, address)
+	b.lock.Unlock()
+
+	b.Ban(address, reason)
+}
+
+// pruneScores forgets about the peers whose score decayed to zero, so that
+// the scores of the peers which misbehaved once do not pile up.
+func (b *BanManager) pruneScores(now time.Time) {
+	for address, s := range b.scores {
+		if s.decayed(now) == 0 {
+			delete(b.scores, address)
+		}
+	}
+}
+
+// decayed returns the score left at the given time.
+func (s *banScore) decayed(now time.Time) float64 {
+	score := s.score - now.Sub(s.updated).Seconds()*banScoreDecay
+	if score < 0 {
+		return 0
+	}
+
+	return score
+}
+
+// Ban disconnects a peer and refuses any further connection with its host
+// until the ban expires.
+func (b *BanManager) Ban(address, reason string) {
+	d := config.Get().Network.BanDuration
+	if d == 0 {
+		d = defaultBanDuration
+	}
+
+	now := time.Now()
+	ban := Ban{
+		Host:     host(address),
+		Reason:   reason,
+		BannedAt: now,
+		Until:    now.Add(time.Duration(d) * time.Second),
+	}
+
+	b.lock.Lock()
+	b.bans[ban.Host] = ban
+	err := b.save()
+	b.lock.Unlock()
+
+	if err != nil {
+		log.WithField("process", "ban manager").
+			WithError(err).Warn("could not persist the ban list")
+	}
+
+	log.WithField("process", "ban manager").
+		WithField("address", address).
+		WithField("reason", reason).
+		WithField("until", ban.Until).
+		Warn("banning peer")
+
+	storeBan(ban)
+
+	if b.disconnect != nil {
+		b.disconnect(address)
+	}
+}
+
+// IsBanned returns true if the host of the given address is banned.
+func (b *BanManager) IsBanned(address string) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	h := host(address)
+
+	ban, ok := b.bans[h]
+	if !ok {
+		return false
+	}
+
+	if time.Now().Before(ban.Until) {
+		return true
+	}
+
+	delete(b.bans, h)
+
+	if err := b.save(); err != nil {
+		log.WithField("process", "ban manager").
+			WithError(err).Warn("could not persist the ban list")
+	}
+
+	deleteBan(ban)
+	return false
+}
+
+// Bans returns the active bans, sorted by host.
+func (b *BanManager) Bans() []Ban {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	bans := make([]Ban, 0, len(b.bans))
+
+	for _, ban := range b.bans {
+		if now.Before(ban.Until) {
+			bans = append(bans, ban)
+		}
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Host < bans[j].Host
+	})
+
+	return bans
+}
+
+// load reads the bans persisted in the ban list file. A missing file is not an
+// error, as it is created on the first ban.
+func (b *BanManager) load() error {
+	if b.path == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bans []Ban
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, ban := range bans {
+		if now.Before(ban.Until) {
+			b.bans[ban.Host] = ban
+			storeBan(ban)
+		}
+	}
+
+	return nil
+}
+
+// save writes the bans to the ban list file. The file is replaced atomically,
+// so that a crash cannot leave a truncated ban list behind.
+func (b *BanManager) save() error {
+	if b.path == "" {
+		return nil
+	}
+
+	bans := make([]Ban, 0, len(b.bans))
+	for _, ban := range b.bans {
+		bans = append(bans, ban)
+	}
+
+	data, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := b.path + ".new"
+	if err := ioutil.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, b.path)
+}
+
+// storeBan exposes a ban to the /p2p/bans API route.
+func storeBan(ban Ban) {
+	if !config.Get().API.Enabled {
+		return
+	}
+
+	go func() {
+		store := capi.GetStormDBInstance()
+		banJSON := capi.BanJSON{
+			Host:     ban.Host,
+			Reason:   ban.Reason,
+			BannedAt: ban.BannedAt,
+			Until:    ban.Until,
+		}
+
+		if err := store.Save(&banJSON); err != nil {
+			log.Error("failed to save ban into StormDB")
+		}
+	}()
+}
+
+// deleteBan removes an expired ban from the /p2p/bans API route.
+func deleteBan(ban Ban) {
+	if !config.Get().API.Enabled {
+		return
+	}
+
+	go func() {
+		store := capi.GetStormDBInstance()
+
+		if err := store.Delete(&capi.BanJSON{Host: ban.Host}); err != nil {
+			log.Error("failed to delete ban from StormDB")
+		}
+	}()
+}
+
+// host strips the port from a peer address. Inbound connections come from
+// ephemeral ports, so bans apply to the whole host.
+func host(address string) string {
+	h, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	return h
+}
@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+func mockBanConfig(score, duration uint32) func() {
+	r := config.Get()
+	r.Network.BanScore = score
+	r.Network.BanDuration = duration
+	config.Mock(&r)
+
+	return func() {
+		r.Network.BanScore = 0
+		r.Network.BanDuration = 0
+		config.Mock(&r)
+	}
+}
+
+// Test that the bans survive a restart.
+func TestBanListPersistence(t *testing.T) {
+	assert := require.New(t)
+
+	defer mockBanConfig(100, 0)()
+
+	dir, err := ioutil.TempDir("", "banlist")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "bans.json")
+
+	var disconnected []string
+
+	b := NewBanManager(path, func(address string) {
+		disconnected = append(disconnected, address)
+	})
+
+	b.Report("10.0.0.1:7000", 60, "first")
+	assert.False(b.IsBanned("10.0.0.1:7000"))
+	assert.Empty(disconnected)
+
+	b.Report("10.0.0.1:7000", 60, "second")
+	assert.True(b.IsBanned("10.0.0.1:7000"))
+	assert.Equal([]string{"10.0.0.1:7000"}, disconnected)
+
+	b.Ban("10.0.0.2:7000", "manual")
+
+	// A new manager loads the bans from the file
+	b = NewBanManager(path, nil)
+	assert.True(b.IsBanned("10.0.0.1:42000"))
+	assert.True(b.IsBanned("10.0.0.2:7000"))
+	assert.False(b.IsBanned("10.0.0.3:7000"))
+
+	bans := b.Bans()
+	assert.Len(bans, 2)
+	assert.Equal("10.0.0.1", bans[0].Host)
+	assert.Equal("second", bans[0].Reason)
+	assert.Equal("10.0.0.2", bans[1].Host)
+}
+
+func TestBanExpiry(t *testing.T) {
+	assert := require.New(t)
+
+	defer mockBanConfig(100, 1)()
+
+	b := NewBanManager("", nil)
+	b.Ban("10.0.0.1:7000", "test")
+	assert.True(b.IsBanned("10.0.0.1:7000"))
+
+	time.Sleep(1100 * time.Millisecond)
+
+	assert.False(b.IsBanned("10.0.0.1:7000"))
+	assert.Empty(b.Bans())
+}
+
+func TestBanScoreDisabled(t *testing.T) {
+	assert := require.New(t)
+
+	b := NewBanManager("", nil)
+	b.Report("10.0.0.1:7000", 1000, "test")
+
+	assert.False(b.IsBanned("10.0.0.1:7000"))
+}
+
+// Test that the scores decayed to zero are forgotten.
+func TestBanScorePruning(t *testing.T) {
+	assert := require.New(t)
+
+	defer mockBanConfig(100, 0)()
+
+	b := NewBanManager("", nil)
+	b.Report("10.0.0.1:7000", 1, "stale")
+	b.Report("10.0.0.2:7000", 1, "stale")
+
+	// A score of 1 decays in a minute
+	b.scores["10.0.0.1:7000"].updated = time.Now().Add(-2 * time.Minute)
+
+	b.Report("10.0.0.3:7000", 1, "stale")
+
+	assert.NotContains(b.scores, "10.0.0.1:7000")
+	assert.Contains(b.scores, "10.0.0.2:7000")
+	assert.Contains(b.scores, "10.0.0.3:7000")
+}
+
+// Test that the MessageProcessor reports the peers sending malformed
+// messages or illegal topics.
+func TestProcessorReportsMisbehaviour(t *testing.T) {
+	assert := require.New(t)
+
+	eb := eventbus.New()
+	processor := NewMessageProcessor(eb)
+
+	reportChan := make(chan message.Message, 2)
+	eb.Subscribe(topics.Misbehaviour, eventbus.NewChanListener(reportChan))
+
+	// A voucher node does not route mempool requests
+	_, err := processor.Collect("10.0.0.1:7000", []byte{byte(topics.MemPool)}, nil, protocol.VoucherNode, nil)
+	assert.Error(err)
+
+	// A truncated block
+	_, err = processor.Collect("10.0.0.1:7000", []byte{byte(topics.Block), 0xff}, nil, protocol.FullNode, nil)
+	assert.Error(err)
+
+	for _, score := range []uint32{illegalTopicScore, malformedMessageScore} {
+		select {
+		case msg := <-reportChan:
+			r := msg.Payload().(message.Misbehaviour)
+			assert.Equal("10.0.0.1:7000", r.Address)
+			assert.Equal(score, r.Score)
+		case <-time.After(time.Second):
+			t.Fatal("misbehaviour not reported")
+		}
+	}
+
+	// Local messages are never reported
+	_, err = processor.Collect("", []byte{byte(topics.MemPool)}, nil, protocol.VoucherNode, nil)
+	assert.Error(err)
+	assert.Empty(reportChan)
+}
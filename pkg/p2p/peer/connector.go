@@ -25,8 +25,18 @@ import (
 const (
 	defaultDialTimeout    = 5
 	defaultMaxConnections = 50
+
+	// maintenanceInterval is the interval at which the connector checks
+	// that the node has enough connections.
+	maintenanceInterval = 30 * time.Second
+
+	// maxAddrsReply is the maximum number of addresses sent in reply to a
+	// GetAddrs message.
+	maxAddrsReply = 50
 )
 
+var errBannedPeer = errors.New("peer is banned")
+
 type connectFunc func(context.Context, *Reader, *Writer, chan bytes.Buffer)
 
 // Connector is responsible for accepting incoming connection requests, and
@@ -39,7 +49,10 @@ type Connector struct {
 	l net.Listener
 
 	lock     sync.RWMutex
-	registry map[string]struct{}
+	registry map[string]*Connection
+
+	bans  *BanManager
+	addrs *AddrManager
 
 	services protocol.ServiceFlag
 
@@ -65,12 +78,16 @@ func NewConnector(eb eventbus.Broker, gossip *protocol.Gossip, port string,
 		gossip:        gossip,
 		readerFactory: NewReaderFactory(processor),
 		l:             listener,
-		registry:      make(map[string]struct{}),
+		registry:      make(map[string]*Connection),
 		services:      services,
 		connectFunc:   connectFunc,
 	}
 
+	c.bans = NewBanManager(config.Get().Network.BanList, c.disconnect)
+	c.addrs = NewAddrManager(config.Get().Network.AddrBook)
+
 	processor.Register(topics.Addr, c.ProcessNewAddress)
+	eb.Subscribe(topics.Misbehaviour, eventbus.NewCallbackListener(c.onMisbehaviour))
 
 	go func(c *Connector) {
 		for {
@@ -89,36 +106,121 @@ func NewConnector(eb eventbus.Broker, gossip *protocol.Gossip, port string,
 	return c
 }
 
-// Close the listener.
+// Close the listener, and persist the address book.
 func (c *Connector) Close() error {
+	c.addrs.Save()
 	return c.l.Close()
 }
 
 // ProcessNewAddress will handle a new Addr message from the network.
 // Satisfies the peer.ProcessorFunc interface.
 func (c *Connector) ProcessNewAddress(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
-	maxConn := config.Get().Network.MaxConnections
-	if maxConn == 0 {
-		maxConn = defaultMaxConnections
+	a := m.Payload().(message.Addr)
+	c.addrs.Add(a.NetAddr)
+
+	if c.isConnected(a.NetAddr) {
+		return nil, nil
 	}
 
-	if c.GetConnectionsCount() > maxConn {
+	if c.GetConnectionsCount() >= maxConnections() {
 		return nil, errors.New("max amount of connections reached")
 	}
 
-	a := m.Payload().(message.Addr)
 	return nil, c.Connect(a.NetAddr)
 }
 
+// ProcessGetAddrs replies to a GetAddrs message with the addresses of the
+// peers we successfully connected to.
+// Satisfies the peer.ProcessorFunc interface.
+func (c *Connector) ProcessGetAddrs(srcPeerID string, _ message.Message) ([]bytes.Buffer, error) {
+	addrs := c.addrs.Addresses(maxAddrsReply)
+	bufs := make([]bytes.Buffer, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if addr == srcPeerID {
+			continue
+		}
+
+		buf := bytes.NewBufferString(addr)
+		if err := topics.Prepend(buf, topics.Addr); err != nil {
+			return nil, err
+		}
+
+		bufs = append(bufs, *buf)
+	}
+
+	return bufs, nil
+}
+
+// MaintainConnections periodically dials the peers of the address book while
+// the node is below the minimum amount of connections, so that it stays
+// connected to the network when the voucher seeders are unreachable. It also
+// persists the address book. It returns when the context is canceled.
+func (c *Connector) MaintainConnections(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.FillConnections()
+			c.addrs.Save()
+		case <-ctx.Done():
+			c.addrs.Save()
+			return
+		}
+	}
+}
+
+// FillConnections dials the most reliable peers of the address book, until
+// the node reaches the minimum amount of connections.
+func (c *Connector) FillConnections() {
+	target := config.Get().Network.MinimumConnections
+	if maxConn := maxConnections(); target > maxConn {
+		target = maxConn
+	}
+
+	if c.GetConnectionsCount() >= target {
+		return
+	}
+
+	for _, addr := range c.addrs.Candidates() {
+		if c.GetConnectionsCount() >= target {
+			return
+		}
+
+		if c.isConnected(addr) {
+			continue
+		}
+
+		if err := c.Connect(addr); err != nil {
+			log.WithField("process", "peer connector").
+				WithField("address", addr).
+				WithError(err).Debugln("could not connect to known peer")
+		}
+	}
+}
+
 // Connect dials a connection with its string, then on succession
 // we pass the connection and the address to the OnConn method.
+// The outcome is accounted in the address book.
 func (c *Connector) Connect(addr string) error {
+	if c.bans.IsBanned(addr) {
+		return errBannedPeer
+	}
+
 	conn, err := c.Dial(addr)
 	if err != nil {
+		c.addrs.Failed(addr)
 		return err
 	}
 
-	c.proposeConnection(conn)
+	if err := c.proposeConnection(conn); err != nil {
+		c.addrs.Failed(addr)
+		return err
+	}
+
+	c.addrs.Good(addr)
 	return nil
 }
 
@@ -140,6 +242,15 @@ func (c *Connector) Dial(addr string) (net.Conn, error) {
 }
 
 func (c *Connector) acceptConnection(conn net.Conn) {
+	if c.bans.IsBanned(conn.RemoteAddr().String()) {
+		log.WithField("process", "peer connector").
+			WithField("address", conn.RemoteAddr().String()).
+			Debugln("refusing connection from banned peer")
+
+		_ = conn.Close()
+		return
+	}
+
 	writeQueueChan := make(chan bytes.Buffer, 1000)
 	pConn := NewConnection(conn, c.gossip)
 	peerReader := c.readerFactory.SpawnReader(pConn, writeQueueChan)
@@ -156,7 +267,7 @@ func (c *Connector) acceptConnection(conn net.Conn) {
 
 	peerWriter := NewWriter(pConn, c.eventBus)
 
-	c.addPeer(peerReader.Addr())
+	c.addPeer(peerReader.Addr(), pConn)
 
 	go func() {
 		c.connectFunc(context.Background(), peerReader, peerWriter, writeQueueChan)
@@ -164,7 +275,7 @@ func (c *Connector) acceptConnection(conn net.Conn) {
 	}()
 }
 
-func (c *Connector) proposeConnection(conn net.Conn) {
+func (c *Connector) proposeConnection(conn net.Conn) error {
 	writeQueueChan := make(chan bytes.Buffer, 1000)
 	pConn := NewConnection(conn, c.gossip)
 	peerWriter := NewWriter(pConn, c.eventBus)
@@ -172,7 +283,9 @@ func (c *Connector) proposeConnection(conn net.Conn) {
 	if err := peerWriter.Connect(c.services); err != nil {
 		log.WithField("process", "peer connector").
 			WithError(err).Warnln("problem performing outgoing handshake")
-		return
+
+		_ = conn.Close()
+		return err
 	}
 
 	address := peerWriter.Addr()
@@ -183,19 +296,21 @@ func (c *Connector) proposeConnection(conn net.Conn) {
 
 	peerReader := c.readerFactory.SpawnReader(pConn, writeQueueChan)
 
-	c.addPeer(peerWriter.Addr())
+	c.addPeer(peerWriter.Addr(), pConn)
 
 	go func() {
 		c.connectFunc(context.Background(), peerReader, peerWriter, writeQueueChan)
 		c.removePeer(peerWriter.Addr())
 	}()
+
+	return nil
 }
 
-func (c *Connector) addPeer(address string) {
+func (c *Connector) addPeer(address string, conn *Connection) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.registry[address] = struct{}{}
+	c.registry[address] = conn
 }
 
 func (c *Connector) removePeer(address string) {
@@ -217,6 +332,16 @@ func (c *Connector) removePeer(address string) {
 	}
 }
 
+// isConnected returns true if the node has a connection with the given
+// address.
+func (c *Connector) isConnected(address string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	_, ok := c.registry[address]
+	return ok
+}
+
 // GetConnectionsCount returns the amount of active connections the node has.
 func (c *Connector) GetConnectionsCount() int {
 	c.lock.RLock()
@@ -224,3 +349,32 @@ func (c *Connector) GetConnectionsCount() int {
 
 	return len(c.registry)
 }
+
+// onMisbehaviour accounts the misbehaviour reported by the message
+// processors.
+func (c *Connector) onMisbehaviour(m message.Message) {
+	r := m.Payload().(message.Misbehaviour)
+	c.bans.Report(r.Address, r.Score, r.Reason)
+}
+
+// disconnect closes the connection with a peer. Closing the connection
+// terminates both the read and the write loop, which in turn remove the peer
+// from the registry.
+func (c *Connector) disconnect(address string) {
+	c.lock.RLock()
+	conn, ok := c.registry[address]
+	c.lock.RUnlock()
+
+	if ok {
+		_ = conn.Close()
+	}
+}
+
+func maxConnections() int {
+	maxConn := config.Get().Network.MaxConnections
+	if maxConn == 0 {
+		maxConn = defaultMaxConnections
+	}
+
+	return maxConn
+}
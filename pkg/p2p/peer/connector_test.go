@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that a peer crossing the ban score is disconnected, and that its host
+// can not connect again.
+func TestBanPeer(t *testing.T) {
+	assert := require.New(t)
+
+	r := config.Get()
+	r.Network.BanScore = 100
+	r.Network.BanList = ""
+	r.Network.AddrBook = ""
+	config.Mock(&r)
+
+	defer func() {
+		r.Network.BanScore = 0
+		config.Mock(&r)
+	}()
+
+	eb := eventbus.New()
+	processor := NewMessageProcessor(eb)
+
+	port := freePort(t)
+	c := NewConnector(eb, protocol.NewGossip(protocol.TestNet), port, processor, protocol.FullNode, Create)
+	defer c.Close()
+
+	client, srv := net.Pipe()
+	c.addPeer("127.0.0.1:7000", NewConnection(srv, protocol.NewGossip(protocol.TestNet)))
+
+	ReportMisbehaviour(eb, "127.0.0.1:7000", 60, "test")
+	assert.False(c.bans.IsBanned("127.0.0.1:7000"))
+
+	ReportMisbehaviour(eb, "127.0.0.1:7000", 60, "test")
+
+	// The connection is closed
+	assert.NoError(client.SetReadDeadline(time.Now().Add(time.Second)))
+
+	_, err := client.Read(make([]byte, 1))
+	assert.Error(err)
+
+	// Any address of the same host is refused
+	assert.True(c.bans.IsBanned("127.0.0.1:7001"))
+	assert.False(c.bans.IsBanned("127.0.0.2:7000"))
+	assert.Equal(errBannedPeer, c.Connect("127.0.0.1:"+port))
+}
+
+// Test that a node without voucher connects to the peers of its address
+// book, and shares them with the network.
+func TestFillConnections(t *testing.T) {
+	assert := require.New(t)
+
+	r := config.Get()
+	r.Network.MinimumConnections = 1
+	r.Network.AddrBook = ""
+	config.Mock(&r)
+
+	defer func() {
+		r.Network.MinimumConnections = 0
+		config.Mock(&r)
+	}()
+
+	gossip := protocol.NewGossip(protocol.TestNet)
+
+	// A node which is already part of the network
+	port := freePort(t)
+	eb := eventbus.New()
+	known := NewConnector(eb, gossip, port, NewMessageProcessor(eb), protocol.FullNode, Create)
+
+	defer known.Close()
+
+	eb = eventbus.New()
+	c := NewConnector(eb, gossip, freePort(t), NewMessageProcessor(eb), protocol.FullNode, Create)
+
+	defer c.Close()
+
+	address := "127.0.0.1:" + port
+
+	// Addresses of the unreachable peers are retried later
+	unreachable := "127.0.0.1:" + freePort(t)
+
+	_, err := c.ProcessNewAddress("", message.New(topics.Addr, message.Addr{NetAddr: unreachable}))
+	assert.Error(err)
+
+	c.addrs.Add(address)
+	c.FillConnections()
+
+	assert.Equal(1, c.GetConnectionsCount())
+	assert.Equal([]string{address}, c.addrs.Candidates())
+
+	// The connected peer is shared with the ones asking for addresses
+	bufs, err := c.ProcessGetAddrs("", message.New(topics.GetAddrs, bytes.Buffer{}))
+	assert.NoError(err)
+	assert.Len(bufs, 1)
+
+	topic, err := topics.Extract(&bufs[0])
+	assert.NoError(err)
+	assert.Equal(topics.Addr, topic)
+	assert.Equal(address, bufs[0].String())
+}
+
+// freePort picks a free port for a connector.
+func freePort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer l.Close()
+
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+}
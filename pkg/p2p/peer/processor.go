@@ -26,6 +26,7 @@ type ProcessorFunc func(srcPeerID string, m message.Message) ([]bytes.Buffer, er
 // MessageProcessor is connected to all of the processing units that are tied to the peer.
 // It sends an incoming message in the right direction, according to its topic.
 type MessageProcessor struct {
+	publisher  eventbus.Publisher
 	dupeMap    *dupemap.DupeMap
 	processors map[topics.Topic]ProcessorFunc
 }
@@ -33,6 +34,7 @@ type MessageProcessor struct {
 // NewMessageProcessor returns an initialized MessageProcessor.
 func NewMessageProcessor(bus eventbus.Broker) *MessageProcessor {
 	return &MessageProcessor{
+		publisher:  bus,
 		dupeMap:    dupemap.NewDupeMapDefault(),
 		processors: make(map[topics.Topic]ProcessorFunc),
 	}
@@ -51,6 +53,7 @@ func (m *MessageProcessor) Collect(srcPeerID string, packet []byte, respChan cha
 
 	msg, err := message.Unmarshal(b)
 	if err != nil {
+		ReportMisbehaviour(m.publisher, srcPeerID, malformedMessageScore, "malformed message")
 		return nil, err
 	}
 
@@ -78,6 +81,7 @@ func (m *MessageProcessor) shouldBeCached(t topics.Topic) bool {
 func (m *MessageProcessor) process(srcPeerID string, msg message.Message, respChan chan<- bytes.Buffer, services protocol.ServiceFlag) ([]bytes.Buffer, error) {
 	category := msg.Category()
 	if !canRoute(services, category) {
+		ReportMisbehaviour(m.publisher, srcPeerID, illegalTopicScore, "illegal topic "+category.String())
 		return nil, fmt.Errorf("attempted to process an illegal topic %s for node type %v", category, services)
 	}
 
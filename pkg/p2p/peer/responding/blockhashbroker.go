@@ -11,6 +11,7 @@ import (
 	"errors"
 
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -51,11 +52,27 @@ func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Mes
 
 		var hash []byte
 
+		first := inv.InvList == nil
+
 		err = b.db.View(func(t database.Transaction) error {
 			hash, err = t.FetchBlockHashByHeight(height)
-			return err
+			if err != nil || !first {
+				return err
+			}
+
+			// Blocks are pruned from the genesis onwards, so we can only
+			// deliver the requested range if its first block was not pruned
+			if _, err = t.FetchBlockTxs(hash); err == database.ErrTxPruned {
+				return err
+			}
+
+			return nil
 		})
 
+		if err == database.ErrTxPruned {
+			return nil, nil
+		}
+
 		// This means we passed the tip of the chain, so we can exit the loop
 		// TODO: does it really, or do we need a more sophisticated way to go about it?
 		if err != nil {
@@ -79,7 +96,9 @@ func (b *BlockHashBroker) AdvertiseMissingBlocks(srcPeerID string, m message.Mes
 	return nil, nil
 }
 
-// Determine a peer's height from his locator hash.
+// Determine a peer's height from his locator hashes. Locators are sorted from
+// the most recent one, so the first locator known to us identifies the most
+// recent block we have in common with the peer.
 func (b *BlockHashBroker) fetchLocatorHeight(msg message.GetBlocks) (uint64, error) {
 	if len(msg.Locators) == 0 {
 		return 0, errors.New("empty locators array")
@@ -88,13 +107,21 @@ func (b *BlockHashBroker) fetchLocatorHeight(msg message.GetBlocks) (uint64, err
 	var height uint64
 
 	err := b.db.View(func(t database.Transaction) error {
-		header, err := t.FetchBlockHeader(msg.Locators[0])
-		if err != nil {
-			return err
+		var err error
+
+		for _, locator := range msg.Locators {
+			var header *block.Header
+
+			header, err = t.FetchBlockHeader(locator)
+			if err != nil {
+				continue
+			}
+
+			height = header.Height
+			return nil
 		}
 
-		height = header.Height
-		return nil
+		return err
 	})
 
 	return height, err
@@ -54,6 +54,39 @@ func TestAdvertiseBlocks(t *testing.T) {
 	}
 }
 
+// Test that the block hash broker starts advertising from the first locator
+// it knows about, so that peers on a different branch can find the fork point.
+func TestAdvertiseBlocksFromCommonLocator(t *testing.T) {
+	assert := assert.New(t)
+	_, db := lite.CreateDBConnection()
+
+	defer func() {
+		_ = db.Close()
+	}()
+
+	hashes, blocks := generateBlocks(5)
+	assert.NoError(storeBlocks(db, blocks))
+
+	blockHashBroker := responding.NewBlockHashBroker(db)
+
+	// The first locator belongs to a branch we do not know about
+	unknown := helper.RandomBlock(4, 1).Header.Hash
+	msg := createGetBlocks(unknown, hashes[2], hashes[0])
+
+	blksBuf, err := blockHashBroker.AdvertiseMissingBlocks("", msg)
+	assert.NoError(err)
+
+	topic, _ := topics.Extract(&blksBuf[0])
+	assert.Equal(topics.Inv, topic)
+
+	inv := &message.Inv{}
+	assert.NoError(inv.Decode(&blksBuf[0]))
+
+	assert.Len(inv.InvList, 2)
+	assert.Equal(hashes[3], inv.InvList[0].Hash)
+	assert.Equal(hashes[4], inv.InvList[1].Hash)
+}
+
 // Generate a set of random blocks, which follow each other up in the chain.
 func generateBlocks(amount int) ([][]byte, []*block.Block) {
 	var hashes [][]byte
@@ -68,9 +101,9 @@ func generateBlocks(amount int) ([][]byte, []*block.Block) {
 	return hashes, blocks
 }
 
-func createGetBlocks(locator []byte) message.Message {
+func createGetBlocks(locators ...[]byte) message.Message {
 	getBlocks := &message.GetBlocks{}
-	getBlocks.Locators = append(getBlocks.Locators, locator)
+	getBlocks.Locators = append(getBlocks.Locators, locators...)
 	return message.New(topics.GetBlocks, *getBlocks)
 }
 
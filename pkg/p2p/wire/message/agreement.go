@@ -26,6 +26,13 @@ import (
 	"github.com/dusk-network/dusk-crypto/bls"
 )
 
+// versionedVotesMarker precedes the version of the StepVotes encoding in the
+// Agreement messages. The legacy encoding starts with the number of StepVotes
+// instead, which is never zero, so that it is told apart from the versioned
+// one, and the nodes supporting only the legacy encoding refuse the versioned
+// one as malformed.
+const versionedVotesMarker = 0
+
 type (
 	// StepVotes represents the aggregated votes for one reduction step.
 	// Normally an Agreement event includes two of these structures. They need to
@@ -34,7 +41,7 @@ type (
 	// the committee for both Reduction steps.
 	StepVotes struct {
 		Apk       *bls.Apk
-		BitSet    uint64
+		BitSet    sortedset.BitSet
 		Signature *bls.Signature
 		Step      uint8
 	}
@@ -60,8 +67,14 @@ type (
 
 // Copy deeply the StepVotes.
 func (s *StepVotes) Copy() *StepVotes {
+	var bitSet sortedset.BitSet
+	if s.BitSet != nil {
+		bitSet = make(sortedset.BitSet, len(s.BitSet))
+		copy(bitSet, s.BitSet)
+	}
+
 	return &StepVotes{
-		BitSet:    s.BitSet,
+		BitSet:    bitSet,
 		Step:      s.Step,
 		Apk:       s.Apk.Copy(),
 		Signature: s.Signature.Copy(),
@@ -128,14 +141,14 @@ func NewStepVotesMsg(round uint64, hash []byte, sender []byte, sv StepVotes) Ste
 func (s StepVotesMsg) Copy() payload.Safe {
 	b := new(bytes.Buffer)
 
-	err := MarshalStepVotes(b, &s.StepVotes)
+	err := MarshalStepVotes(b, &s.StepVotes, block.CurrentVersion)
 	if err != nil {
 		log.WithError(err).Error("StepVotesMsg.Copy, could not MarshalStepVotes")
 		// FIXME: creating a empty stepvotes with round 0 does not seem optimal, how can this be improved ?
 		return NewStepVotesMsg(0, []byte{}, []byte{}, *NewStepVotes())
 	}
 
-	sv, err := UnmarshalStepVotes(b)
+	sv, err := UnmarshalStepVotes(b, block.CurrentVersion)
 	if err != nil {
 		// FIXME: creating a empty stepvotes with round 0 does not seem optimal, how can this be improved ?
 		log.WithError(err).Error("StepVotesMsg.Copy, could not UnmarshalStepVotes")
@@ -174,7 +187,7 @@ func (s StepVotes) IsEmpty() bool {
 // String representation.
 func (s StepVotes) String() string {
 	var sb strings.Builder
-	_, _ = sb.WriteString(fmt.Sprintf("BitSet: %d Step: %d\n Sig: %v\n Apk: %v\n", s.BitSet, s.Step, s.Signature, s.Apk))
+	_, _ = sb.WriteString(fmt.Sprintf("BitSet: %x Step: %d\n Sig: %v\n Apk: %v\n", s.BitSet, s.Step, s.Signature, s.Apk))
 	return sb.String()
 }
 
@@ -240,7 +253,7 @@ func UnmarshalAgreementMessage(r *bytes.Buffer, m SerializableMessage) error {
 func NewStepVotes() *StepVotes {
 	return &StepVotes{
 		Apk:       nil,
-		BitSet:    uint64(0),
+		BitSet:    nil,
 		Signature: nil,
 		Step:      uint8(0),
 	}
@@ -364,12 +377,29 @@ func SignAgreement(a *Agreement, keys key.Keys) error {
 }
 
 // UnmarshalVotes unmarshals the array of StepVotes for a single Agreement.
+// Both the legacy and the versioned encodings are accepted. See MarshalVotes.
 func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
+	version := block.LegacyVersion
+
 	length, err := encoding.ReadVarInt(r)
 	if err != nil {
 		return err
 	}
 
+	if length == versionedVotesMarker {
+		if err = encoding.ReadUint8(r, &version); err != nil {
+			return err
+		}
+
+		if version == block.LegacyVersion || version > block.CurrentVersion {
+			return fmt.Errorf("unsupported StepVotes version %d", version)
+		}
+
+		if length, err = encoding.ReadVarInt(r); err != nil {
+			return err
+		}
+	}
+
 	// Agreement can only ever have two StepVotes, for the two
 	// reduction steps.
 	if length != 2 {
@@ -377,7 +407,7 @@ func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
 	}
 
 	for i := uint64(0); i < length; i++ {
-		sv, err := UnmarshalStepVotes(r)
+		sv, err := UnmarshalStepVotes(r, version)
 		if err != nil {
 			return err
 		}
@@ -388,8 +418,9 @@ func UnmarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
 	return nil
 }
 
-// UnmarshalStepVotes unmarshals a single StepVote.
-func UnmarshalStepVotes(r *bytes.Buffer) (*StepVotes, error) {
+// UnmarshalStepVotes unmarshals a single StepVote encoded with the given
+// version.
+func UnmarshalStepVotes(r *bytes.Buffer, version uint8) (*StepVotes, error) {
 	sv := NewStepVotes()
 
 	// APK
@@ -406,8 +437,8 @@ func UnmarshalStepVotes(r *bytes.Buffer) (*StepVotes, error) {
 	}
 
 	// BitSet
-	if e := encoding.ReadUint64LE(r, &sv.BitSet); e != nil {
-		return nil, e
+	if sv.BitSet, err = readBitSet(r, version); err != nil {
+		return nil, err
 	}
 
 	// Signature
@@ -424,14 +455,30 @@ func UnmarshalStepVotes(r *bytes.Buffer) (*StepVotes, error) {
 	return sv, nil
 }
 
-// MarshalVotes marshals an array of StepVotes.
+// MarshalVotes marshals an array of StepVotes. As long as the committee
+// bitsets fit 64 bits, the legacy encoding is used, so that the nodes which
+// do not support larger committees can still decode the votes. Otherwise, the
+// StepVotes are preceded by the versionedVotesMarker and the version of their
+// encoding.
 func MarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
+	version := votesVersion(votes)
+
+	if version != block.LegacyVersion {
+		if err := encoding.WriteVarInt(r, versionedVotesMarker); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint8(r, version); err != nil {
+			return err
+		}
+	}
+
 	if err := encoding.WriteVarInt(r, uint64(len(votes))); err != nil {
 		return err
 	}
 
 	for _, stepVotes := range votes {
-		if err := MarshalStepVotes(r, stepVotes); err != nil {
+		if err := MarshalStepVotes(r, stepVotes, version); err != nil {
 			return err
 		}
 	}
@@ -439,9 +486,26 @@ func MarshalVotes(r *bytes.Buffer, votes []*StepVotes) error {
 	return nil
 }
 
+// votesVersion returns the version of the encoding needed by the committee
+// bitsets of the votes.
+func votesVersion(votes []*StepVotes) uint8 {
+	for _, stepVotes := range votes {
+		if stepVotes == nil {
+			continue
+		}
+
+		if _, ok := stepVotes.BitSet.Uint64(); !ok {
+			return block.CurrentVersion
+		}
+	}
+
+	return block.LegacyVersion
+}
+
 // MarshalStepVotes marshals the aggregated form of the BLS PublicKey and Signature
-// for an ordered set of votes.
-func MarshalStepVotes(r *bytes.Buffer, vote *StepVotes) error {
+// for an ordered set of votes. The encoding of the committee bitset depends on
+// the version, as for the block certificates.
+func MarshalStepVotes(r *bytes.Buffer, vote *StepVotes, version uint8) error {
 	// #611
 	if vote == nil || vote.Apk == nil || vote.Signature == nil {
 		log.
@@ -457,7 +521,7 @@ func MarshalStepVotes(r *bytes.Buffer, vote *StepVotes) error {
 	}
 
 	// BitSet
-	if err := encoding.WriteUint64LE(r, vote.BitSet); err != nil {
+	if err := writeBitSet(r, vote.BitSet, version); err != nil {
 		return err
 	}
 
@@ -539,7 +603,7 @@ func GenVotes(hash []byte, round uint64, step uint8, keys []key.Keys, p *user.Pr
 	return []*StepVotes{stepVotes1, stepVotes2}
 }
 
-func createBitSet(set sortedset.Set, round uint64, step uint8, size int, p *user.Provisioners) uint64 {
+func createBitSet(set sortedset.Set, round uint64, step uint8, size int, p *user.Provisioners) sortedset.BitSet {
 	committee := p.CreateVotingCommittee(round, step, size)
 	return committee.Bits(set)
 }
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-crypto/bls"
 	"github.com/stretchr/testify/assert"
@@ -47,15 +48,54 @@ func TestStepVotes(t *testing.T) {
 
 	buf := new(bytes.Buffer)
 
-	assert.NoError(t, MarshalStepVotes(buf, expectedStepVotes))
+	assert.NoError(t, MarshalStepVotes(buf, expectedStepVotes, block.CurrentVersion))
 
-	result, err := UnmarshalStepVotes(buf)
+	result, err := UnmarshalStepVotes(buf, block.CurrentVersion)
 	assert.NoError(t, err)
 
 	assert.Equal(t, expectedStepVotes, result)
 	assert.NoError(t, bls.Verify(result.Apk, hash, result.Signature))
 }
 
+// Test that the votes of committees of up to 64 members keep the legacy
+// encoding, and that larger committees use the versioned one.
+func TestVotesVersion(t *testing.T) {
+	hash := []byte("this is a mock message")
+	set := sortedset.New()
+
+	sv := NewStepVotes()
+	assert.NoError(t, sv.Add(genReduction(hash, &set)))
+
+	sv.BitSet = sortedset.BitSetFromUint64(1 << 63)
+	votes := []*StepVotes{sv, sv.Copy()}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, MarshalVotes(buf, votes))
+
+	// The legacy encoding starts with the number of StepVotes
+	assert.Equal(t, byte(2), buf.Bytes()[0])
+
+	result := make([]*StepVotes, 2)
+	assert.NoError(t, UnmarshalVotes(buf, result))
+	assert.Equal(t, sv.BitSet, result[0].BitSet)
+
+	// A bitset of 65 members needs the versioned encoding
+	votes[1].BitSet = make(sortedset.BitSet, 9)
+	votes[1].BitSet[8] = 1
+
+	buf = new(bytes.Buffer)
+	assert.NoError(t, MarshalVotes(buf, votes))
+	assert.Equal(t, []byte{versionedVotesMarker, block.CurrentVersion, 2}, buf.Bytes()[:3])
+
+	assert.NoError(t, UnmarshalVotes(buf, result))
+	assert.Equal(t, sv.BitSet, result[0].BitSet)
+	assert.Equal(t, votes[1].BitSet, result[1].BitSet)
+
+	// Unknown versions are refused
+	buf = bytes.NewBuffer([]byte{versionedVotesMarker, block.CurrentVersion + 1, 2})
+	assert.Error(t, UnmarshalVotes(buf, result))
+}
+
 // Test that adding Reduction events to a StepVotes struct results in a properly
 // aggregated public key and signature.
 func TestStepVotesAdd(t *testing.T) {
@@ -0,0 +1,70 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+)
+
+// MaxBitSetSize is the maximum size in bytes of an encoded committee bitset.
+// It bounds the voting committees to 8192 members.
+const MaxBitSetSize = 1024
+
+// writeBitSet encodes a committee bitset. Legacy blocks encode it as a 64 bit
+// integer. Later versions, and the StepVotes, encode it as variable-length
+// bytes.
+func writeBitSet(r *bytes.Buffer, bitSet sortedset.BitSet, version uint8) error {
+	if version == block.LegacyVersion {
+		bits, ok := bitSet.Uint64()
+		if !ok {
+			return fmt.Errorf("committee bitset of %d bytes does not fit a version %d block", len(bitSet), version)
+		}
+
+		return encoding.WriteUint64LE(r, bits)
+	}
+
+	bitSet = bitSet.Trim()
+	if len(bitSet) > MaxBitSetSize {
+		return fmt.Errorf("committee bitset of %d bytes exceeds the maximum of %d", len(bitSet), MaxBitSetSize)
+	}
+
+	return encoding.WriteVarBytes(r, bitSet)
+}
+
+// readBitSet decodes a committee bitset encoded by writeBitSet.
+func readBitSet(r *bytes.Buffer, version uint8) (sortedset.BitSet, error) {
+	if version == block.LegacyVersion {
+		var bits uint64
+		if err := encoding.ReadUint64LE(r, &bits); err != nil {
+			return nil, err
+		}
+
+		return sortedset.BitSetFromUint64(bits), nil
+	}
+
+	size, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > MaxBitSetSize {
+		return nil, fmt.Errorf("committee bitset of %d bytes exceeds the maximum of %d", size, MaxBitSetSize)
+	}
+
+	bitSet := make(sortedset.BitSet, size)
+	if n, _ := r.Read(bitSet); uint64(n) != size {
+		return nil, errors.New("committee bitset is truncated")
+	}
+
+	return bitSet.Trim(), nil
+}
@@ -114,7 +114,7 @@ func MarshalHeader(r *bytes.Buffer, h *block.Header) error {
 		return err
 	}
 
-	if err := MarshalCertificate(r, h.Certificate); err != nil {
+	if err := MarshalCertificate(r, h.Certificate, h.Version); err != nil {
 		return err
 	}
 
@@ -157,7 +157,7 @@ func UnmarshalHeader(r *bytes.Buffer, h *block.Header) error {
 		return err
 	}
 
-	if err := UnmarshalCertificate(r, h.Certificate); err != nil {
+	if err := UnmarshalCertificate(r, h.Certificate, h.Version); err != nil {
 		return err
 	}
 
@@ -169,8 +169,9 @@ func UnmarshalHeader(r *bytes.Buffer, h *block.Header) error {
 	return nil
 }
 
-// MarshalCertificate marshals a certificate.
-func MarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
+// MarshalCertificate marshals a certificate. The encoding of the committee
+// bitsets depends on the version of the block header.
+func MarshalCertificate(r *bytes.Buffer, c *block.Certificate, version uint8) error {
 	if err := encoding.WriteBLS(r, c.StepOneBatchedSig); err != nil {
 		return err
 	}
@@ -183,19 +184,20 @@ func MarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
 		return err
 	}
 
-	if err := encoding.WriteUint64LE(r, c.StepOneCommittee); err != nil {
+	if err := writeBitSet(r, c.StepOneCommittee, version); err != nil {
 		return err
 	}
 
-	if err := encoding.WriteUint64LE(r, c.StepTwoCommittee); err != nil {
+	if err := writeBitSet(r, c.StepTwoCommittee, version); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// UnmarshalCertificate unmarshals a certificate.
-func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
+// UnmarshalCertificate unmarshals a certificate of a block header of the given
+// version.
+func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate, version uint8) error {
 	c.StepOneBatchedSig = make([]byte, 33)
 	if err := encoding.ReadBLS(r, c.StepOneBatchedSig); err != nil {
 		return err
@@ -210,11 +212,13 @@ func UnmarshalCertificate(r *bytes.Buffer, c *block.Certificate) error {
 		return err
 	}
 
-	if err := encoding.ReadUint64LE(r, &c.StepOneCommittee); err != nil {
+	var err error
+
+	if c.StepOneCommittee, err = readBitSet(r, version); err != nil {
 		return err
 	}
 
-	if err := encoding.ReadUint64LE(r, &c.StepTwoCommittee); err != nil {
+	if c.StepTwoCommittee, err = readBitSet(r, version); err != nil {
 		return err
 	}
 
@@ -51,23 +51,44 @@ func TestEncodeDecodeBlock(t *testing.T) {
 func TestEncodeDecodeCert(t *testing.T) {
 	assert := assert.New(t)
 
-	// random certificate
-	cert := helper.RandomCertificate()
+	for _, version := range []uint8{block.LegacyVersion, block.CurrentVersion} {
+		// random certificate
+		cert := helper.RandomCertificate()
 
-	// Encode certificate into a buffer
-	buf := new(bytes.Buffer)
+		// Encode certificate into a buffer
+		buf := new(bytes.Buffer)
 
-	err := message.MarshalCertificate(buf, cert)
-	assert.Nil(err)
+		err := message.MarshalCertificate(buf, cert, version)
+		assert.Nil(err)
 
-	// Decode buffer into a certificate struct
-	decCert := &block.Certificate{}
+		// Decode buffer into a certificate struct
+		decCert := &block.Certificate{}
 
-	err = message.UnmarshalCertificate(buf, decCert)
-	assert.Nil(err)
+		err = message.UnmarshalCertificate(buf, decCert, version)
+		assert.Nil(err)
 
-	// Check both structs are equal
+		// Check both structs are equal
+		assert.True(cert.Equals(decCert))
+	}
+}
+
+func TestEncodeDecodeLargeCommitteeCert(t *testing.T) {
+	assert := assert.New(t)
+
+	// A committee of 72 members, with the last one voting
+	cert := helper.RandomCertificate()
+	cert.StepOneCommittee = []byte{1, 2, 3, 4, 5, 6, 7, 8, 128}
+	cert.StepTwoCommittee = []byte{255}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(message.MarshalCertificate(buf, cert, block.CurrentVersion))
+
+	decCert := &block.Certificate{}
+	assert.NoError(message.UnmarshalCertificate(buf, decCert, block.CurrentVersion))
 	assert.True(cert.Equals(decCert))
+
+	// Legacy blocks can not hold such a committee
+	assert.Error(message.MarshalCertificate(new(bytes.Buffer), cert, block.LegacyVersion))
 }
 
 func TestEncodeDecodeHeader(t *testing.T) {
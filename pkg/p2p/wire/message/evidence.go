@@ -0,0 +1,117 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"bytes"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-crypto/hash"
+)
+
+// Evidence of a provisioner signing two different block hashes for the same
+// round and step. It is only published internally, on
+// topics.SlashingEvidence.
+type Evidence struct {
+	// Topic of the two votes, either topics.Reduction or topics.Agreement.
+	Topic     topics.Topic
+	Round     uint64
+	Step      uint8
+	PubKeyBLS []byte
+	// First and Second are the two signed votes, in their wire encoding.
+	First  []byte
+	Second []byte
+}
+
+// Copy an Evidence.
+// Implements the payload.Safe interface.
+func (e Evidence) Copy() payload.Safe {
+	cpy := e
+	cpy.PubKeyBLS = append([]byte{}, e.PubKeyBLS...)
+	cpy.First = append([]byte{}, e.First...)
+	cpy.Second = append([]byte{}, e.Second...)
+
+	return cpy
+}
+
+// ID identifies the equivocation of a provisioner, regardless of the votes
+// it is proved with.
+func (e Evidence) ID() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := encoding.WriteUint8(buf, uint8(e.Topic)); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteUint64LE(buf, e.Round); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteUint8(buf, e.Step); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteVarBytes(buf, e.PubKeyBLS); err != nil {
+		return nil, err
+	}
+
+	return hash.Sha3256(buf.Bytes())
+}
+
+// MarshalEvidence marshals an Evidence into a buffer.
+func MarshalEvidence(r *bytes.Buffer, e Evidence) error {
+	if err := encoding.WriteUint8(r, uint8(e.Topic)); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, e.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(r, e.Step); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, e.PubKeyBLS); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, e.First); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(r, e.Second)
+}
+
+// UnmarshalEvidence unmarshals an Evidence from a buffer.
+func UnmarshalEvidence(r *bytes.Buffer, e *Evidence) error {
+	var topic uint8
+	if err := encoding.ReadUint8(r, &topic); err != nil {
+		return err
+	}
+
+	e.Topic = topics.Topic(topic)
+
+	if err := encoding.ReadUint64LE(r, &e.Round); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadUint8(r, &e.Step); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadVarBytes(r, &e.PubKeyBLS); err != nil {
+		return err
+	}
+
+	if err := encoding.ReadVarBytes(r, &e.First); err != nil {
+		return err
+	}
+
+	return encoding.ReadVarBytes(r, &e.Second)
+}
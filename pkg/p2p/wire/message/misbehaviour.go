@@ -0,0 +1,27 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+)
+
+// Misbehaviour reports a network peer for sending invalid or unsolicited
+// messages. It is only published internally, on topics.Misbehaviour.
+type Misbehaviour struct {
+	// Address of the peer, as passed to the message processors.
+	Address string
+	// Score is added to the ban score of the peer.
+	Score  uint32
+	Reason string
+}
+
+// Copy a Misbehaviour.
+// Implements the payload.Safe interface.
+func (m Misbehaviour) Copy() payload.Safe {
+	return m
+}
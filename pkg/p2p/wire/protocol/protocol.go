@@ -35,8 +35,8 @@ const (
 // NodeVer is the current node version.
 var NodeVer = &Version{
 	Major: 0,
-	Minor: 4,
-	Patch: 1,
+	Minor: 5,
+	Patch: 0,
 }
 
 // Magic is the network that Dusk is running on.
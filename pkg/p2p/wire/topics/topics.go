@@ -91,6 +91,18 @@ const (
 
 	// Kadcast wire point-to-point messaging.
 	KadcastPoint
+
+	// Chain reorganisation topics.
+	RevertedBlock
+
+	// Mempool notification topics.
+	MempoolEvent
+
+	// Peer management topics.
+	Misbehaviour
+
+	// Consensus slashing topics.
+	SlashingEvidence
 )
 
 type topicBuf struct {
@@ -151,6 +163,11 @@ var Topics = [...]topicBuf{
 	{GetCandidate, *(bytes.NewBuffer([]byte{byte(GetCandidate)})), "getcandidate"},
 	{SyncProgress, *(bytes.NewBuffer([]byte{byte(SyncProgress)})), "syncprogress"},
 	{Kadcast, *(bytes.NewBuffer([]byte{byte(Kadcast)})), "kadcast"},
+	{KadcastPoint, *(bytes.NewBuffer([]byte{byte(KadcastPoint)})), "kadcastpoint"},
+	{RevertedBlock, *(bytes.NewBuffer([]byte{byte(RevertedBlock)})), "revertedblock"},
+	{MempoolEvent, *(bytes.NewBuffer([]byte{byte(MempoolEvent)})), "mempoolevent"},
+	{Misbehaviour, *(bytes.NewBuffer([]byte{byte(Misbehaviour)})), "misbehaviour"},
+	{SlashingEvidence, *(bytes.NewBuffer([]byte{byte(SlashingEvidence)})), "slashingevidence"},
 }
 
 func checkConsistency(topics []topicBuf) {
@@ -107,6 +107,17 @@ func CreateWalletClient(ctx context.Context, address string) (rusk.WalletClient,
 	return rusk.NewWalletClient(conn), conn
 }
 
+// CreateTransactionServiceClient creates a client for the generic
+// Transaction service.
+func CreateTransactionServiceClient(ctx context.Context, address string) (rusk.TransactionServiceClient, *grpc.ClientConn) {
+	conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return rusk.NewTransactionServiceClient(conn), conn
+}
+
 type (
 	// AuthClient is the client used to test the authorization service.
 	AuthClient struct {
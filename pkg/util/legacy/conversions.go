@@ -8,6 +8,7 @@ package legacy
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
 
 	ristretto "github.com/bwesterb/go-ristretto"
@@ -15,6 +16,7 @@ import (
 	newblock "github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	newtx "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-crypto/mlsag"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 	"github.com/dusk-network/dusk-wallet/v2/block"
@@ -26,7 +28,13 @@ import (
 // NewBlockToOldBlock will convert a dusk-blockchain block into a dusk-wallet block.
 func NewBlockToOldBlock(b *newblock.Block) (*block.Block, error) {
 	ob := block.NewBlock()
-	ob.Header = newHeaderToOldHeader(b.Header)
+
+	header, err := newHeaderToOldHeader(b.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	ob.Header = header
 	rtxs := make([]*rusk.Transaction, len(b.Txs))
 
 	for i, call := range b.Txs {
@@ -61,7 +69,12 @@ func OldBlockToNewBlock(b *block.Block) (*newblock.Block, error) {
 	return nb, nil
 }
 
-func newHeaderToOldHeader(h *newblock.Header) *block.Header {
+func newHeaderToOldHeader(h *newblock.Header) (*block.Header, error) {
+	cert, err := newCertificateToOldCertificate(h.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
 	oh := block.NewHeader()
 	oh.Version = h.Version
 	oh.Height = h.Height
@@ -69,9 +82,9 @@ func newHeaderToOldHeader(h *newblock.Header) *block.Header {
 	oh.PrevBlockHash = h.PrevBlockHash
 	oh.Seed = h.Seed
 	oh.TxRoot = h.TxRoot
-	oh.Certificate = newCertificateToOldCertificate(h.Certificate)
+	oh.Certificate = cert
 	oh.Hash = h.Hash
-	return oh
+	return oh, nil
 }
 
 func oldHeaderToNewHeader(h *block.Header) *newblock.Header {
@@ -87,14 +100,26 @@ func oldHeaderToNewHeader(h *block.Header) *newblock.Header {
 	return nh
 }
 
-func newCertificateToOldCertificate(c *newblock.Certificate) *block.Certificate {
+// newCertificateToOldCertificate fails for the certificates of committees of
+// more than 64 members, which legacy certificates can not hold.
+func newCertificateToOldCertificate(c *newblock.Certificate) (*block.Certificate, error) {
+	stepOneCommittee, ok := sortedset.BitSet(c.StepOneCommittee).Uint64()
+	if !ok {
+		return nil, fmt.Errorf("step one committee of %d bytes does not fit a legacy certificate", len(c.StepOneCommittee))
+	}
+
+	stepTwoCommittee, ok := sortedset.BitSet(c.StepTwoCommittee).Uint64()
+	if !ok {
+		return nil, fmt.Errorf("step two committee of %d bytes does not fit a legacy certificate", len(c.StepTwoCommittee))
+	}
+
 	oc := block.EmptyCertificate()
 	oc.StepOneBatchedSig = c.StepOneBatchedSig
 	oc.StepTwoBatchedSig = c.StepTwoBatchedSig
 	oc.Step = c.Step
-	oc.StepOneCommittee = c.StepOneCommittee
-	oc.StepTwoCommittee = c.StepTwoCommittee
-	return oc
+	oc.StepOneCommittee = stepOneCommittee
+	oc.StepTwoCommittee = stepTwoCommittee
+	return oc, nil
 }
 
 func oldCertificateToNewCertificate(c *block.Certificate) *newblock.Certificate {
@@ -102,8 +127,8 @@ func oldCertificateToNewCertificate(c *block.Certificate) *newblock.Certificate
 	nc.StepOneBatchedSig = c.StepOneBatchedSig
 	nc.StepTwoBatchedSig = c.StepTwoBatchedSig
 	nc.Step = c.Step
-	nc.StepOneCommittee = c.StepOneCommittee
-	nc.StepTwoCommittee = c.StepTwoCommittee
+	nc.StepOneCommittee = sortedset.BitSetFromUint64(c.StepOneCommittee)
+	nc.StepTwoCommittee = sortedset.BitSetFromUint64(c.StepTwoCommittee)
 	return nc
 }
 
@@ -18,6 +18,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
 	"github.com/dusk-network/dusk-blockchain/pkg/rpc/client"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/legacy"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/ruskmock"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 	"github.com/stretchr/testify/assert"
@@ -172,6 +173,18 @@ func TestBlockIntegrity(t *testing.T) {
 	assert.True(t, blk.Equals(blk2))
 }
 
+// Test that a certificate of more than 64 committee members is refused, rather
+// than truncated, by the legacy conversion.
+func TestLargeCommitteeCertificate(t *testing.T) {
+	blk := helper.RandomBlock(2, 1)
+	blk.Header.Certificate.StepOneCommittee = sortedset.BitSetFromUint64(1)
+	blk.Header.Certificate.StepTwoCommittee = make(sortedset.BitSet, 9)
+	blk.Header.Certificate.StepTwoCommittee[8] = 1
+
+	_, err := legacy.NewBlockToOldBlock(blk)
+	assert.Error(t, err)
+}
+
 func setupRuskMock(t *testing.T) *ruskmock.Server {
 	c := config.Registry{}
 	// Hardcode wallet values, so that it always starts up correctly
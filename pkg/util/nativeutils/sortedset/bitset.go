@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package sortedset
+
+import (
+	"encoding/binary"
+)
+
+// BitSet is the bit representation of a subset of a Set. The i-th bit is set
+// if the i-th element of the Set belongs to the subset. Bits are packed in
+// little-endian order, so that the BitSet of a Set of up to 64 elements is
+// the little-endian encoding of its legacy uint64 representation.
+//
+// A BitSet carries no trailing zero bytes, so that equal subsets have equal
+// representations.
+type BitSet []byte
+
+// BitSetFromUint64 returns the BitSet of a legacy uint64 representation.
+func BitSetFromUint64(bits uint64) BitSet {
+	b := make(BitSet, 8)
+	binary.LittleEndian.PutUint64(b, bits)
+	return b.Trim()
+}
+
+// Uint64 returns the legacy uint64 representation of the BitSet. The boolean
+// is false if bits beyond the first 64 are set, as they are then dropped.
+func (b BitSet) Uint64() (uint64, bool) {
+	buf := make([]byte, 8)
+	copy(buf, b)
+
+	return binary.LittleEndian.Uint64(buf), len(b.Trim()) <= 8
+}
+
+// IsSet returns true if the i-th bit is set.
+func (b BitSet) IsSet(i int) bool {
+	return i/8 < len(b) && b[i/8]&(1<<uint(i%8)) != 0
+}
+
+// Trim returns the BitSet without its trailing zero bytes. An empty BitSet is
+// nil.
+func (b BitSet) Trim() BitSet {
+	n := len(b)
+	for n > 0 && b[n-1] == 0 {
+		n--
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	return b[:n]
+}
+
+// set flips the i-th bit to 1. The BitSet must be large enough.
+func (b BitSet) set(i int) {
+	b[i/8] |= 1 << uint(i%8)
+}
+
+// newBitSet returns a zeroed BitSet large enough for size bits.
+func newBitSet(size int) BitSet {
+	return make(BitSet, (size+7)/8)
+}
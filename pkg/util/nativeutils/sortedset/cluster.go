@@ -121,8 +121,8 @@ func (c *Cluster) Remove(b []byte) bool {
 }
 
 // IntersectCluster performs an intersect operation with a Cluster represented
-// through a uint64 bitmap.
-func (c *Cluster) IntersectCluster(committeeSet uint64) Cluster {
+// through a BitSet.
+func (c *Cluster) IntersectCluster(committeeSet BitSet) Cluster {
 	set := c.Intersect(committeeSet)
 
 	elems := make(map[string]int)
@@ -7,16 +7,13 @@
 package sortedset
 
 import (
-	"math"
+	"bytes"
 	"math/big"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-// All represents the bitmap of the whole set.
-const All uint64 = math.MaxUint64
-
 // Set is an ordered set of big.Int.
 type Set []*big.Int
 
@@ -125,8 +122,8 @@ func (v *Set) Remove(pubKeyBLS []byte) bool {
 }
 
 // Intersect the bit representation of a VotingCommittee subset with the whole VotingCommittee set.
-func (v Set) Intersect(committeeSet uint64) Set {
-	if committeeSet == All || committeeSet == v.Whole() {
+func (v Set) Intersect(committeeSet BitSet) Set {
+	if bytes.Equal(committeeSet.Trim(), v.Whole()) {
 		return v[:]
 	}
 
@@ -134,7 +131,7 @@ func (v Set) Intersect(committeeSet uint64) Set {
 
 	for i, elem := range v {
 		// looping on all bits to see which one is set to 1
-		if ((committeeSet >> uint(i)) & 1) != 0 {
+		if committeeSet.IsSet(i) {
 			c = append(c, elem)
 		}
 	}
@@ -143,11 +140,11 @@ func (v Set) Intersect(committeeSet uint64) Set {
 }
 
 // Bits creates a bit representation of the subset of a Set. The subset is passed by value.
-func (v *Set) Bits(subset Set) uint64 {
-	ret := uint64(0)
+func (v *Set) Bits(subset Set) BitSet {
+	ret := newBitSet(len(*v))
 
 	if len(subset) == 0 {
-		return ret
+		return ret.Trim()
 	}
 
 	var head *big.Int
@@ -156,7 +153,7 @@ func (v *Set) Bits(subset Set) uint64 {
 
 	for i, elem := range *v {
 		if elem.Cmp(head) == 0 {
-			ret |= 1 << uint(i) // flip the i-th bit to 1
+			ret.set(i) // flip the i-th bit to 1
 
 			if len(subset) == 0 {
 				break
@@ -166,7 +163,7 @@ func (v *Set) Bits(subset Set) uint64 {
 		}
 	}
 
-	return ret
+	return ret.Trim()
 }
 
 func (v Set) String() string {
@@ -184,13 +181,13 @@ func (v Set) String() string {
 }
 
 // Whole returns the bitmap of all the elements within the set.
-func (v Set) Whole() uint64 {
-	ret := uint64(0)
+func (v Set) Whole() BitSet {
+	ret := newBitSet(len(v))
 	for i := range v {
-		ret |= 1 << uint(i)
+		ret.set(i)
 	}
 
-	return ret
+	return ret.Trim()
 }
 
 func shortStr(i *big.Int) string {
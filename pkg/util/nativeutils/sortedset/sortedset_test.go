@@ -32,7 +32,7 @@ func TestBits(t *testing.T) {
 	sort.Sort(subset)
 
 	repr := set.Bits(subset)
-	expected := uint64(12) // 0011
+	expected := BitSet{12} // 0011
 
 	assert.Equal(t, expected, repr)
 }
@@ -60,6 +60,48 @@ func TestBitIntersect(t *testing.T) {
 	assert.Equal(t, subset, sub)
 }
 
+func TestBitIntersectLargeSet(t *testing.T) {
+	set := New()
+	subset := New()
+
+	for i := 0; i < 300; i++ {
+		k, _ := crypto.RandEntropy(32)
+		bk := (&big.Int{}).SetBytes(k)
+		set = append(set, bk)
+
+		if i%3 == 0 {
+			subset = append(subset, bk)
+		}
+	}
+
+	sort.Sort(set)
+	sort.Sort(subset)
+
+	bRepr := set.Bits(subset)
+	assert.Equal(t, subset, set.Intersect(bRepr))
+	assert.Equal(t, set, set.Intersect(set.Whole()))
+}
+
+func TestBitSetUint64(t *testing.T) {
+	bits := uint64(1<<63 | 1<<10 | 1)
+
+	b := BitSetFromUint64(bits)
+	assert.True(t, b.IsSet(0))
+	assert.True(t, b.IsSet(10))
+	assert.True(t, b.IsSet(63))
+	assert.False(t, b.IsSet(64))
+
+	legacy, ok := b.Uint64()
+	assert.True(t, ok)
+	assert.Equal(t, bits, legacy)
+
+	// Bits beyond the first 64 do not fit the legacy representation
+	_, ok = append(b, 1).Uint64()
+	assert.False(t, ok)
+
+	assert.Nil(t, BitSetFromUint64(0))
+}
+
 func TestRemove(t *testing.T) {
 	nr := 5
 	set := New()